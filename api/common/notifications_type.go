@@ -0,0 +1,56 @@
+package common
+
+import "slices"
+
+// NotificationFormat represents the payload format of a notification endpoint.
+// +kubebuilder:validation:Enum=cloudevents;slack;teams
+type NotificationFormat string
+
+const (
+	// NotificationFormatCloudEvents posts CloudEvents in structured JSON mode.
+	NotificationFormatCloudEvents NotificationFormat = "cloudevents"
+	// NotificationFormatSlack posts messages to a Slack incoming webhook.
+	NotificationFormatSlack NotificationFormat = "slack"
+	// NotificationFormatTeams posts message cards to a Microsoft Teams incoming webhook.
+	NotificationFormatTeams NotificationFormat = "teams"
+)
+
+// NotificationEvent represents a kind of event a notification endpoint subscribes to.
+// +kubebuilder:validation:Enum=PeriodChanged;ScalingFailed;ScalingRecovered;ScaleDownWarning
+type NotificationEvent string
+
+const (
+	// NotificationPeriodChanged is sent once the resources were scaled for a new active period.
+	NotificationPeriodChanged NotificationEvent = "PeriodChanged"
+	// NotificationScalingFailed is sent when resources start failing to scale.
+	NotificationScalingFailed NotificationEvent = "ScalingFailed"
+	// NotificationScalingRecovered is sent when every resource scales again after failures.
+	NotificationScalingRecovered NotificationEvent = "ScalingRecovered"
+	// NotificationScaleDownWarning is sent ahead of a period scaling resources down, as set by the
+	// warnings of the period.
+	NotificationScaleDownWarning NotificationEvent = "ScaleDownWarning"
+)
+
+// NotificationEndpoint is an HTTP endpoint notified of the transitions of a scaler.
+type NotificationEndpoint struct {
+	// Name of the endpoint, used in logs
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// URL the notifications are posted to; overridden by the url key of Secret, if any
+	URL string `json:"url,omitempty"`
+	// Format of the payload (default: cloudevents)
+	// +kubebuilder:default:=cloudevents
+	Format NotificationFormat `json:"format,omitempty"`
+	// Events sent to the endpoint (default: all)
+	Events []NotificationEvent `json:"events,omitempty"`
+	// Go template of the message, executed with the notification event (default: a summary of the event)
+	Template string `json:"template,omitempty"`
+	// Secret, in the operator namespace, holding the URL of the endpoint (key url) when it
+	// embeds credentials, and the key signing the payloads with HMAC-SHA256 (key signingKey)
+	Secret *string `json:"secret,omitempty"`
+}
+
+// Subscribes returns whether the endpoint is sent event.
+func (e NotificationEndpoint) Subscribes(event NotificationEvent) bool {
+	return len(e.Events) == 0 || slices.Contains(e.Events, event)
+}
@@ -3,6 +3,10 @@
 // +kubebuilder:object:generate=true
 package common
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // PeriodType represents the type of a scaling period.
 // +kubebuilder:validation:Enum=down;up
 type PeriodType string
@@ -41,6 +45,10 @@ type ScalerPeriod struct {
 	// Name of the period
 	// +kubebuilder:validation:Pattern=`^(|[a-zA-Z0-9][a-zA-Z0-9_-]*)$`
 	Name string `json:"name,omitempty"`
+	// How long before the period scales resources down warnings are sent, such as 30m and 5m
+	// (down periods only, up to 24h)
+	// +kubebuilder:validation:MaxItems=10
+	Warnings []metav1.Duration `json:"warnings,omitempty"`
 }
 
 // TimePeriod defines the time configuration for a scaling period.
@@ -81,20 +89,186 @@ type FixedPeriod struct {
 	Reverse *bool `json:"reverse,omitempty"`
 }
 
+// OverrideType represents the action applied by a manual override.
+// +kubebuilder:validation:Enum=up;down;restore
+type OverrideType string
+
+const (
+	// OverrideTypeUp scales resources up until the override expires.
+	OverrideTypeUp OverrideType = "up"
+	// OverrideTypeDown scales resources down until the override expires.
+	OverrideTypeDown OverrideType = "down"
+	// OverrideTypeRestore keeps resources in their original state until the override expires,
+	// as if no period were active.
+	OverrideTypeRestore OverrideType = "restore"
+)
+
+// ScalerOverride temporarily takes precedence over the periods of a scaler.
+// It is removed from the scaler once expired.
+type ScalerOverride struct {
+	Type OverrideType `json:"type"`
+	// Time at which the override expires (RFC3339)
+	Until metav1.Time `json:"until"`
+	// Minimum replicas
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// Maximum replicas
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// Reason of the override, such as an incident reference
+	Reason string `json:"reason,omitempty"`
+}
+
+// SuspendMode represents how a suspended scaler leaves its resources.
+// +kubebuilder:validation:Enum=freeze;restore
+type SuspendMode string
+
+const (
+	// SuspendFreeze stops scaling, leaving resources in their current state.
+	SuspendFreeze SuspendMode = "freeze"
+	// SuspendRestore restores resources to their original state, then stops scaling.
+	SuspendRestore SuspendMode = "restore"
+)
+
+// Condition types set on the status of K8s and Gcp scalers.
+const (
+	// ConditionReady is true when the last reconciliation brought every resource to the desired state.
+	ConditionReady = "Ready"
+	// ConditionScaling is true while an up or down period is applied to the resources.
+	ConditionScaling = "Scaling"
+	// ConditionDegraded is true when resources failed to scale or the periods are invalid.
+	ConditionDegraded = "Degraded"
+	// ConditionSuspended is true while the scaler is suspended.
+	ConditionSuspended = "Suspended"
+)
+
 // ScalerStatus defines the observed state of Scaler.
 type ScalerStatus struct {
 	CurrentPeriod *ScalerStatusPeriod `json:"currentPeriod,omitempty"`
 	Comments      *string             `json:"comments,omitempty"`
+	// Generation of the spec last reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Number of resources handled by the last reconciliation
+	Resources *ScalerStatusResources `json:"resources,omitempty"`
+	// Most recent period transitions and scaling outcomes, newest first
+	History []ScalerStatusHistory `json:"history,omitempty"`
+	// Estimated cost saved by scaling resources down, when a price table is configured
+	Savings *ScalerStatusSavings `json:"savings,omitempty"`
+	// Last warning sent before a period scales resources down
+	LastWarning *ScalerStatusWarning `json:"lastWarning,omitempty"`
+	// Hooks of the pending or last transition to an up or down period
+	Hooks *ScalerStatusHooks `json:"hooks,omitempty"`
+	// Ready, Scaling, Degraded and Suspended conditions of the scaler
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ScalerStatusResources counts the resources handled by the last reconciliation.
+type ScalerStatusResources struct {
+	// Resources managed by the scaler
+	Managed int32 `json:"managed"`
+	// Resources scaled successfully
+	Succeeded int32 `json:"succeeded"`
+	// Resources that failed to scale
+	Failed int32 `json:"failed"`
+	// Resources whose scale-down is postponed because they were recently deployed
+	Postponed int32 `json:"postponed,omitempty"`
+	// Resources handled in each cluster, for scalers targeting several clusters
+	Clusters []ScalerStatusClusterResources `json:"clusters,omitempty"`
+}
+
+// ScalerStatusClusterResources counts the resources of a cluster handled by the last
+// reconciliation.
+type ScalerStatusClusterResources struct {
+	// Name of the cluster
+	Name string `json:"name"`
+	// Resources managed by the scaler in the cluster
+	Managed int32 `json:"managed"`
+	// Resources scaled successfully
+	Succeeded int32 `json:"succeeded"`
+	// Resources that failed to scale
+	Failed int32 `json:"failed"`
+	// Resources whose scale-down is postponed because they were recently deployed
+	Postponed int32 `json:"postponed,omitempty"`
+}
+
+// ScalerStatusSavings estimates the cost saved by scaling resources down. Amounts are decimal
+// numbers in the currency of the price table.
+type ScalerStatusSavings struct {
+	// Currency of the amounts
+	Currency string `json:"currency,omitempty"`
+	// Estimated cost saved per hour by the resources currently scaled down
+	HourlyRate string `json:"hourlyRate"`
+	// Estimated cost saved since the estimation started
+	Total string `json:"total"`
+	// Time at which the estimation started
+	Since metav1.Time `json:"since"`
+	// Time at which the total was last updated
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// ScalerStatusWarning records a warning sent before a period scales resources down.
+type ScalerStatusWarning struct {
+	// Name of the period about to scale resources down
+	Period string `json:"period,omitempty"`
+	// Time at which the period scales resources down
+	ScaleDownTime metav1.Time `json:"scaleDownTime"`
+	// How long before the scale-down the warning was due
+	Before metav1.Duration `json:"before"`
+	// Time at which the warning was sent
+	Time metav1.Time `json:"time"`
 }
 
 // ScalerStatusPeriod defines the current period status for a scaler.
 type ScalerStatusPeriod struct {
-	Spec       *TimePeriod           `json:"spec"`
-	SpecSHA    string                `json:"specSHA"`
-	Name       string                `json:"name,omitempty"`
-	Type       string                `json:"type"`
-	Successful []ScalerStatusSuccess `json:"success,omitempty"`
-	Failed     []ScalerStatusFailed  `json:"failed,omitempty"`
+	Spec    *TimePeriod `json:"spec"`
+	SpecSHA string      `json:"specSHA"`
+	Name    string      `json:"name,omitempty"`
+	Type    string      `json:"type"`
+	// Time at which the period became active
+	LastTransitionTime *metav1.Time          `json:"lastTransitionTime,omitempty"`
+	Successful         []ScalerStatusSuccess `json:"success,omitempty"`
+	Failed             []ScalerStatusFailed  `json:"failed,omitempty"`
+	// Manual override in effect, if any
+	Override *ScalerStatusOverride `json:"override,omitempty"`
+}
+
+// Outcomes counts the resources of the period scaled successfully and those whose scale-down is
+// postponed, which are listed among the successful ones.
+func (p *ScalerStatusPeriod) Outcomes() (succeeded, postponed int32) {
+	for _, s := range p.Successful {
+		if s.PostponedUntil != nil {
+			postponed++
+		} else {
+			succeeded++
+		}
+	}
+	return succeeded, postponed
+}
+
+// ScalerStatusOverride describes the manual override in effect.
+type ScalerStatusOverride struct {
+	ScalerOverride `json:",inline"`
+	// Time at which the override took effect
+	Since metav1.Time `json:"since"`
+}
+
+// ScalerStatusHistory records a period transition, or a change in the outcome of scaling.
+type ScalerStatusHistory struct {
+	// Time of the reconciliation
+	Time metav1.Time `json:"time"`
+	// Name of the period
+	Name string `json:"name,omitempty"`
+	// Type of the period
+	Type string `json:"type"`
+	// Number of resources scaled successfully
+	Succeeded int32 `json:"succeeded"`
+	// Number of resources that failed to scale
+	Failed int32 `json:"failed"`
+	// Number of resources whose scale-down is postponed
+	Postponed int32 `json:"postponed,omitempty"`
+	// Resources that failed to scale, possibly truncated
+	FailedItems []ScalerStatusFailed `json:"failedItems,omitempty"`
 }
 
 // ScalerStatusSuccess represents a successful scaling operation.
@@ -102,6 +276,10 @@ type ScalerStatusSuccess struct {
 	Kind    string `json:"kind"`
 	Name    string `json:"name"`
 	Comment string `json:"comment,omitempty"`
+	// Time until which the scale-down of the resource is postponed, as it was recently deployed
+	PostponedUntil *metav1.Time `json:"postponedUntil,omitempty"`
+	// Cluster of the resource, for scalers targeting several clusters
+	Cluster string `json:"cluster,omitempty"`
 }
 
 // ScalerStatusFailed represents a failed scaling operation.
@@ -109,4 +287,50 @@ type ScalerStatusFailed struct {
 	Kind   string `json:"kind"`
 	Name   string `json:"name"`
 	Reason string `json:"reason"`
+	// Cluster of the resource, for scalers targeting several clusters
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// HookState is the state of a hook run.
+type HookState string
+
+const (
+	// HookStateRunning is the state of a Job hook not completed yet.
+	HookStateRunning HookState = "Running"
+	// HookStateSucceeded is the state of a hook that completed successfully.
+	HookStateSucceeded HookState = "Succeeded"
+	// HookStateFailed is the state of a hook that failed or timed out.
+	HookStateFailed HookState = "Failed"
+)
+
+// ScalerStatusHooks reports the hooks run for a transition to an up or down period.
+type ScalerStatusHooks struct {
+	// Name of the period of the transition
+	Period string `json:"period,omitempty"`
+	// Type of the period of the transition
+	Type string `json:"type"`
+	// Hooks run so far, in order
+	Hooks []ScalerStatusHook `json:"hooks,omitempty"`
+}
+
+// ScalerStatusHook reports a hook run.
+type ScalerStatusHook struct {
+	// Name of the hook
+	Name string `json:"name"`
+	// Phase of the hook, pre or post
+	Phase string `json:"phase"`
+	// State of the hook
+	State HookState `json:"state"`
+	// Job created for the hook, as namespace/name
+	Job string `json:"job,omitempty"`
+	// Number of calls made to an HTTP hook
+	Attempts int32 `json:"attempts,omitempty"`
+	// Reason of a failure
+	Message string `json:"message,omitempty"`
+	// Generation of the scaler the hook ran for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Time at which the hook started
+	StartTime metav1.Time `json:"startTime"`
+	// Time at which the hook completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
@@ -19,6 +19,9 @@ const (
 	ResourceHPA           ResourceKind = "hpa"
 	ResourceScaledObjects ResourceKind = "scaledobjects"
 	ResourceCNPGClusters  ResourceKind = "cnpg-clusters"
+	ResourceServices      ResourceKind = "services"
+	ResourceIngresses     ResourceKind = "ingresses"
+	ResourceHTTPRoutes    ResourceKind = "httproutes"
 	ResourceVMInstances   ResourceKind = "vm-instances"
 )
 
@@ -33,3 +36,15 @@ type Resources struct {
 	// Labels selectors
 	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 }
+
+// SleepingService references the Service that receives Ingress and HTTPRoute
+// traffic while workloads are scaled down, typically serving a maintenance page.
+type SleepingService struct {
+	// Name of the Service; it is looked up in the namespace of each routed resource
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Port of the Service
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
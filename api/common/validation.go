@@ -3,8 +3,11 @@ package common
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"slices"
 	"strings"
+	"text/template"
+	"time"
 )
 
 // isValidDay checks if a DayOfWeek value is valid, matching the period package's isDay logic:
@@ -38,10 +41,29 @@ var (
 	ErrStartTimeRequired = errors.New("startTime is required")
 	// ErrEndTimeRequired is returned when endTime is empty.
 	ErrEndTimeRequired = errors.New("endTime is required")
+	// ErrInvalidOverrideType is returned when the override type is not "up", "down" or "restore".
+	ErrInvalidOverrideType = errors.New("type must be 'up', 'down' or 'restore'")
+	// ErrUntilRequired is returned when the override has no expiry.
+	ErrUntilRequired = errors.New("until is required")
+	// ErrRestoreReplicas is returned when replicas are set on a restore override.
+	ErrRestoreReplicas = errors.New("minReplicas and maxReplicas cannot be set on a restore override")
+	// ErrWarningsNotDown is returned when warnings are set on a period that does not scale down.
+	ErrWarningsNotDown = errors.New("warnings can only be set on down periods")
+	// ErrInvalidWarning is returned when a warning offset is not between 1s and 24h.
+	ErrInvalidWarning = errors.New("warnings must be between 1s and 24h")
+	// ErrNotificationTarget is returned when a notification endpoint has neither a URL nor a secret.
+	ErrNotificationTarget = errors.New("either url or secret is required")
+	// ErrNotificationURL is returned when the URL of a notification endpoint is not an HTTP(S) URL.
+	ErrNotificationURL = errors.New("url must be an absolute http or https URL")
+	// ErrNotificationTemplate is returned when the template of a notification endpoint does not parse.
+	ErrNotificationTemplate = errors.New("invalid template")
 )
 
 const dayPrefixLength = 3
 
+// MaxWarning is how long before a scale-down a warning can be sent at most.
+const MaxWarning = 24 * time.Hour
+
 // validDayPrefixes is the list of valid 3-char day prefixes (lowercase).
 // Matches the period package's isDay logic: lowercase + first 3 chars.
 var validDayPrefixes = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
@@ -60,6 +82,39 @@ func (p ScalerPeriod) Validate() error {
 		return fmt.Errorf("%w: %d > %d", ErrMinGreaterThanMax, *p.MinReplicas, *p.MaxReplicas)
 	}
 
+	if len(p.Warnings) > 0 && p.Type != PeriodTypeDown {
+		return ErrWarningsNotDown
+	}
+
+	for _, warning := range p.Warnings {
+		if warning.Duration < time.Second || warning.Duration > MaxWarning {
+			return fmt.Errorf("%w: got %s", ErrInvalidWarning, warning.Duration)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the ScalerOverride configuration is valid.
+func (o ScalerOverride) Validate() error {
+	switch o.Type {
+	case OverrideTypeUp, OverrideTypeDown:
+	case OverrideTypeRestore:
+		if o.MinReplicas != nil || o.MaxReplicas != nil {
+			return ErrRestoreReplicas
+		}
+	default:
+		return fmt.Errorf("%w: got %q", ErrInvalidOverrideType, o.Type)
+	}
+
+	if o.Until.IsZero() {
+		return ErrUntilRequired
+	}
+
+	if o.MinReplicas != nil && o.MaxReplicas != nil && *o.MinReplicas > *o.MaxReplicas {
+		return fmt.Errorf("%w: %d > %d", ErrMinGreaterThanMax, *o.MinReplicas, *o.MaxReplicas)
+	}
+
 	return nil
 }
 
@@ -102,3 +157,26 @@ func (r RecurringPeriod) Validate() error {
 
 	return nil
 }
+
+// Validate checks that the NotificationEndpoint configuration is valid. The URL held by its
+// secret, if any, is only checked when notifications are sent.
+func (e NotificationEndpoint) Validate() error {
+	if e.URL == "" && e.Secret == nil {
+		return ErrNotificationTarget
+	}
+
+	if e.URL != "" {
+		u, err := url.Parse(e.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: got %q", ErrNotificationURL, e.URL)
+		}
+	}
+
+	if e.Template != "" {
+		if _, err := template.New(e.Name).Parse(e.Template); err != nil {
+			return fmt.Errorf("%w: %w", ErrNotificationTemplate, err)
+		}
+	}
+
+	return nil
+}
@@ -2,9 +2,11 @@ package common
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
@@ -507,3 +509,155 @@ func TestTimePeriod_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestScalerOverride_Validate(t *testing.T) {
+	until := metav1.NewTime(time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		override ScalerOverride
+		wantErr  error
+	}{
+		{
+			name:     "valid up override",
+			override: ScalerOverride{Type: OverrideTypeUp, Until: until, MinReplicas: ptr.To(int32(2))},
+			wantErr:  nil,
+		},
+		{
+			name:     "valid restore override",
+			override: ScalerOverride{Type: OverrideTypeRestore, Until: until},
+			wantErr:  nil,
+		},
+		{
+			name:     "invalid type",
+			override: ScalerOverride{Type: "noaction", Until: until},
+			wantErr:  ErrInvalidOverrideType,
+		},
+		{
+			name:     "missing until",
+			override: ScalerOverride{Type: OverrideTypeDown},
+			wantErr:  ErrUntilRequired,
+		},
+		{
+			name:     "replicas on restore",
+			override: ScalerOverride{Type: OverrideTypeRestore, Until: until, MaxReplicas: ptr.To(int32(3))},
+			wantErr:  ErrRestoreReplicas,
+		},
+		{
+			name: "min greater than max",
+			override: ScalerOverride{
+				Type:        OverrideTypeUp,
+				Until:       until,
+				MinReplicas: ptr.To(int32(5)),
+				MaxReplicas: ptr.To(int32(3)),
+			},
+			wantErr: ErrMinGreaterThanMax,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.override.Validate()
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationEndpoint_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint NotificationEndpoint
+		wantErr  error
+	}{
+		{
+			name:     "valid url",
+			endpoint: NotificationEndpoint{Name: "events", URL: "https://events.example.com/hooks"},
+			wantErr:  nil,
+		},
+		{
+			name:     "valid secret with template",
+			endpoint: NotificationEndpoint{Name: "slack", Format: NotificationFormatSlack, Secret: ptr.To("slack-webhook"), Template: "{{ .Scaler }} is going to sleep"},
+			wantErr:  nil,
+		},
+		{
+			name:     "missing target",
+			endpoint: NotificationEndpoint{Name: "events"},
+			wantErr:  ErrNotificationTarget,
+		},
+		{
+			name:     "relative url",
+			endpoint: NotificationEndpoint{Name: "events", URL: "/hooks"},
+			wantErr:  ErrNotificationURL,
+		},
+		{
+			name:     "unsupported scheme",
+			endpoint: NotificationEndpoint{Name: "events", URL: "ftp://events.example.com"},
+			wantErr:  ErrNotificationURL,
+		},
+		{
+			name:     "invalid template",
+			endpoint: NotificationEndpoint{Name: "events", URL: "https://events.example.com", Template: "{{ .Scaler"},
+			wantErr:  ErrNotificationTemplate,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.endpoint.Validate()
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationEndpoint_Subscribes(t *testing.T) {
+	all := NotificationEndpoint{Name: "all"}
+	assert.True(t, all.Subscribes(NotificationPeriodChanged))
+	assert.True(t, all.Subscribes(NotificationScalingFailed))
+
+	failures := NotificationEndpoint{Name: "pager", Events: []NotificationEvent{NotificationScalingFailed}}
+	assert.True(t, failures.Subscribes(NotificationScalingFailed))
+	assert.False(t, failures.Subscribes(NotificationPeriodChanged))
+}
+
+func TestScalerPeriod_Validate_Warnings(t *testing.T) {
+	period := func(periodType PeriodType, warnings ...time.Duration) ScalerPeriod {
+		p := ScalerPeriod{
+			Type: periodType,
+			Time: TimePeriod{Recurring: &RecurringPeriod{Days: []DayOfWeek{DayAll}, StartTime: "19:00", EndTime: "23:00"}},
+		}
+		for _, warning := range warnings {
+			p.Warnings = append(p.Warnings, metav1.Duration{Duration: warning})
+		}
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		period  ScalerPeriod
+		wantErr error
+	}{
+		{name: "warnings before a down period", period: period(PeriodTypeDown, 30*time.Minute, 5*time.Minute)},
+		{name: "warnings on an up period", period: period(PeriodTypeUp, 5*time.Minute), wantErr: ErrWarningsNotDown},
+		{name: "zero warning", period: period(PeriodTypeDown, 0), wantErr: ErrInvalidWarning},
+		{name: "warning beyond a day", period: period(PeriodTypeDown, 25*time.Hour), wantErr: ErrInvalidWarning},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.period.Validate()
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
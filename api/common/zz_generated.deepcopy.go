@@ -59,6 +59,31 @@ func (in *FixedPeriod) DeepCopy() *FixedPeriod {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationEndpoint) DeepCopyInto(out *NotificationEndpoint) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEvent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationEndpoint.
+func (in *NotificationEndpoint) DeepCopy() *NotificationEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RecurringPeriod) DeepCopyInto(out *RecurringPeriod) {
 	*out = *in
@@ -129,6 +154,32 @@ func (in *Resources) DeepCopy() *Resources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerOverride) DeepCopyInto(out *ScalerOverride) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerOverride.
+func (in *ScalerOverride) DeepCopy() *ScalerOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalerPeriod) DeepCopyInto(out *ScalerPeriod) {
 	*out = *in
@@ -143,6 +194,11 @@ func (in *ScalerPeriod) DeepCopyInto(out *ScalerPeriod) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]v1.Duration, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerPeriod.
@@ -168,6 +224,40 @@ func (in *ScalerStatus) DeepCopyInto(out *ScalerStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ScalerStatusResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ScalerStatusHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Savings != nil {
+		in, out := &in.Savings, &out.Savings
+		*out = new(ScalerStatusSavings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastWarning != nil {
+		in, out := &in.LastWarning, &out.LastWarning
+		*out = new(ScalerStatusWarning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(ScalerStatusHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatus.
@@ -180,6 +270,21 @@ func (in *ScalerStatus) DeepCopy() *ScalerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerStatusClusterResources) DeepCopyInto(out *ScalerStatusClusterResources) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusClusterResources.
+func (in *ScalerStatusClusterResources) DeepCopy() *ScalerStatusClusterResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerStatusClusterResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalerStatusFailed) DeepCopyInto(out *ScalerStatusFailed) {
 	*out = *in
@@ -195,6 +300,86 @@ func (in *ScalerStatusFailed) DeepCopy() *ScalerStatusFailed {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerStatusHistory) DeepCopyInto(out *ScalerStatusHistory) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.FailedItems != nil {
+		in, out := &in.FailedItems, &out.FailedItems
+		*out = make([]ScalerStatusFailed, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusHistory.
+func (in *ScalerStatusHistory) DeepCopy() *ScalerStatusHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerStatusHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerStatusHook) DeepCopyInto(out *ScalerStatusHook) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusHook.
+func (in *ScalerStatusHook) DeepCopy() *ScalerStatusHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerStatusHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerStatusHooks) DeepCopyInto(out *ScalerStatusHooks) {
+	*out = *in
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]ScalerStatusHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusHooks.
+func (in *ScalerStatusHooks) DeepCopy() *ScalerStatusHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerStatusHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerStatusOverride) DeepCopyInto(out *ScalerStatusOverride) {
+	*out = *in
+	in.ScalerOverride.DeepCopyInto(&out.ScalerOverride)
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusOverride.
+func (in *ScalerStatusOverride) DeepCopy() *ScalerStatusOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerStatusOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalerStatusPeriod) DeepCopyInto(out *ScalerStatusPeriod) {
 	*out = *in
@@ -203,16 +388,27 @@ func (in *ScalerStatusPeriod) DeepCopyInto(out *ScalerStatusPeriod) {
 		*out = new(TimePeriod)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Successful != nil {
 		in, out := &in.Successful, &out.Successful
 		*out = make([]ScalerStatusSuccess, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Failed != nil {
 		in, out := &in.Failed, &out.Failed
 		*out = make([]ScalerStatusFailed, len(*in))
 		copy(*out, *in)
 	}
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(ScalerStatusOverride)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusPeriod.
@@ -225,9 +421,50 @@ func (in *ScalerStatusPeriod) DeepCopy() *ScalerStatusPeriod {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerStatusResources) DeepCopyInto(out *ScalerStatusResources) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ScalerStatusClusterResources, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusResources.
+func (in *ScalerStatusResources) DeepCopy() *ScalerStatusResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerStatusResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerStatusSavings) DeepCopyInto(out *ScalerStatusSavings) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusSavings.
+func (in *ScalerStatusSavings) DeepCopy() *ScalerStatusSavings {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerStatusSavings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalerStatusSuccess) DeepCopyInto(out *ScalerStatusSuccess) {
 	*out = *in
+	if in.PostponedUntil != nil {
+		in, out := &in.PostponedUntil, &out.PostponedUntil
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusSuccess.
@@ -240,6 +477,39 @@ func (in *ScalerStatusSuccess) DeepCopy() *ScalerStatusSuccess {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalerStatusWarning) DeepCopyInto(out *ScalerStatusWarning) {
+	*out = *in
+	in.ScaleDownTime.DeepCopyInto(&out.ScaleDownTime)
+	out.Before = in.Before
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalerStatusWarning.
+func (in *ScalerStatusWarning) DeepCopy() *ScalerStatusWarning {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalerStatusWarning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleepingService) DeepCopyInto(out *SleepingService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepingService.
+func (in *SleepingService) DeepCopy() *SleepingService {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepingService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TimePeriod) DeepCopyInto(out *TimePeriod) {
 	*out = *in
@@ -33,11 +33,15 @@ type GcpSpec struct {
 
 	// dry-run mode
 	DryRun bool `json:"dryRun,omitempty"`
+	// Suspend scaling, leaving resources in their current state (freeze) or restoring them first (restore)
+	Suspend common.SuspendMode `json:"suspend,omitempty"`
 
 	// Time period to scale
 	Periods []common.ScalerPeriod `json:"periods"`
 	// Resources
 	Resources common.Resources `json:"resources"`
+	// Temporary override taking precedence over the periods until it expires
+	Override *common.ScalerOverride `json:"override,omitempty"`
 
 	Config GcpConfig `json:"config,omitempty"`
 }
@@ -58,16 +62,27 @@ type GcpConfig struct {
 	RestoreOnDelete bool `json:"restoreOnDelete"`
 	// Wait for operation to complete
 	WaitForOperation bool `json:"waitForOperation,omitempty"`
+	// Disable events
+	DisableEvents bool `json:"disableEvents,omitempty"`
 	// Default status for resources
 	// +kubebuilder:validation:Enum=down;up
 	// +kubebuilder:default:=down
 	DefaultPeriodType string `json:"defaultPeriodType"`
+	// HTTP endpoints notified of period transitions and scaling failures, on top of the
+	// operator-wide ones
+	// +listType=map
+	// +listMapKey=name
+	Notifications []common.NotificationEndpoint `json:"notifications,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Period",type=string,JSONPath=`.status.currentPeriod.name`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.status.currentPeriod.type`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +genclient
 
 // Gcp is the Schema for the gcps API
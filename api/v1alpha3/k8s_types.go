@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha3
 
 import (
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
@@ -24,17 +27,21 @@ import (
 
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // K8sSpec defines the desired state of K8s
 type K8sSpec struct {
 	// dry-run mode
 	DryRun bool `json:"dryRun,omitempty"`
+	// Suspend scaling, leaving resources in their current state (freeze) or restoring them first (restore)
+	Suspend common.SuspendMode `json:"suspend,omitempty"`
 
 	// Time period to scale
 	Periods []common.ScalerPeriod `json:"periods"`
 	// Resources
 	Resources common.Resources `json:"resources"`
+	// Temporary override taking precedence over the periods until it expires
+	Override *common.ScalerOverride `json:"override,omitempty"`
 
 	Config K8sConfig `json:"config,omitempty"`
 }
@@ -45,24 +52,169 @@ type K8sConfig struct {
 	Namespaces []string `json:"namespaces,omitempty"`
 	// Exclude namespaces from downscaling; will be ignored if `Namespaces` is set
 	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	// Labels of the namespaces to scale, on top of ExcludeNamespaces; will be ignored if `Namespaces` is set
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 	// Force exclude system namespaces
 	// +kubebuilder:default:=true
 	ForceExcludeSystemNamespaces bool `json:"forceExcludeSystemNamespaces"`
-	// Deployment time annotation
+	// Deployment time annotation: annotation holding the last deploy time of a workload
+	// (RFC3339 or Unix seconds). Recently deployed workloads are not scaled down until
+	// DeploymentGracePeriod has elapsed since their deployment.
 	DeploymentTimeAnnotation string `json:"deploymentTimeAnnotation,omitempty"`
+	// Grace period after a deployment during which scale-down is postponed (default: 1h)
+	DeploymentGracePeriod *metav1.Duration `json:"deploymentGracePeriod,omitempty"`
 	// Disable events
 	DisableEvents bool `json:"disableEvents,omitempty"`
+	// Maximum number of resource kinds, namespaces and resources processed in parallel
+	// (default: the operator's --scaling-concurrency)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	Concurrency *int32 `json:"concurrency,omitempty"`
+	// Time allowed for workloads scaled up or restored to become ready before they are reported
+	// as failed, at most 15m; readiness is re-checked on later reconciliations (default: not verified)
+	ReadinessTimeout *metav1.Duration `json:"readinessTimeout,omitempty"`
+	// Ordered groups of resources: each group is scaled up after the previous one is ready, and
+	// scaled down after the following ones. Resources outside every group are scaled up last and
+	// down first.
+	// +listType=map
+	// +listMapKey=name
+	ScalingGroups []K8sScalingGroup `json:"scalingGroups,omitempty"`
 	// AuthSecret name
 	AuthSecret *string `json:"authSecret,omitempty"`
+	// Namespace of AuthSecret (default: the operator's namespace)
+	// +kubebuilder:validation:MinLength=1
+	AuthSecretNamespace *string `json:"authSecretNamespace,omitempty"`
+	// Clusters whose resources are scaled, instead of the single cluster reached with AuthSecret
+	// +listType=map
+	// +listMapKey=name
+	Clusters []K8sCluster `json:"clusters,omitempty"`
 	// Restore resource state on CR deletion (default: true)
 	// +kubebuilder:default:=true
 	RestoreOnDelete bool `json:"restoreOnDelete"`
+	// Service receiving Ingress and HTTPRoute traffic during down periods
+	SleepingService *common.SleepingService `json:"sleepingService,omitempty"`
+	// HTTP endpoints notified of period transitions and scaling failures, on top of the
+	// operator-wide ones
+	// +listType=map
+	// +listMapKey=name
+	Notifications []common.NotificationEndpoint `json:"notifications,omitempty"`
+	// Jobs or HTTP calls run before and after resources are scaled for a new up or down period
+	// +listType=map
+	// +listMapKey=name
+	Hooks []K8sHook `json:"hooks,omitempty"`
+}
+
+// K8sScalingGroup selects resources scaled in a separate step. A resource belongs to the first
+// group selecting it.
+type K8sScalingGroup struct {
+	// Name of the group
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Types of resources in the group, among the scaler's (default: all of the scaler's types)
+	Types []common.ResourceKind `json:"types,omitempty"`
+	// Labels selectors of the group's resources, on top of the scaler's
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// Maximum time to wait for the group's resources to be ready before scaling up the next
+	// group, at most 15m (default: 5m)
+	ReadinessTimeout *metav1.Duration `json:"readinessTimeout,omitempty"`
+}
+
+// K8sCluster is a cluster whose resources are scaled by the scaler.
+type K8sCluster struct {
+	// Name of the cluster, reported in status
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// AuthSecret name; the cluster of the operator when unset
+	AuthSecret *string `json:"authSecret,omitempty"`
+	// Namespace of AuthSecret (default: the operator's namespace)
+	// +kubebuilder:validation:MinLength=1
+	AuthSecretNamespace *string `json:"authSecretNamespace,omitempty"`
+	// Namespaces of the cluster, instead of the scaler's
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Namespaces excluded in the cluster, instead of the scaler's; ignored if Namespaces is set
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+}
+
+// HookPhase is when a hook runs, relative to the scaling of a transition.
+// +kubebuilder:validation:Enum=pre;post
+type HookPhase string
+
+const (
+	// HookPhasePre runs the hook before resources are scaled; the transition waits for it.
+	HookPhasePre HookPhase = "pre"
+	// HookPhasePost runs the hook once resources are scaled.
+	HookPhasePost HookPhase = "post"
+)
+
+// HookFailurePolicy is what a failed pre-hook does to the transition.
+// +kubebuilder:validation:Enum=Fail;Ignore
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyFail blocks the transition, leaving resources as they are.
+	HookFailurePolicyFail HookFailurePolicy = "Fail"
+	// HookFailurePolicyIgnore scales resources despite the failure.
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
+)
+
+// K8sHook runs a Job or an HTTP call when the scaler transitions to an up or down period. Exactly
+// one of Job and HTTP is set.
+type K8sHook struct {
+	// Name of the hook, part of the names of its Jobs
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Whether the hook runs before (pre) or after (post) resources are scaled
+	Phase HookPhase `json:"phase"`
+	// Types of the periods whose transitions run the hook (default: up and down)
+	On []common.PeriodType `json:"on,omitempty"`
+	// Job that must complete
+	Job *K8sHookJob `json:"job,omitempty"`
+	// HTTP call that must return a 2xx status
+	HTTP *K8sHookHTTP `json:"http,omitempty"`
+	// Time allowed for the hook to complete (default: 5m)
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Whether a pre-hook failing or timing out blocks the transition (Fail) or not (Ignore)
+	// +kubebuilder:default:=Fail
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// K8sHookJob is a Job created in the cluster of the scaler.
+type K8sHookJob struct {
+	// Namespace of the Job
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Spec of the Job
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec batchv1.JobSpec `json:"spec"`
+}
+
+// K8sHookHTTP is an HTTP call posting the transition as JSON.
+type K8sHookHTTP struct {
+	// URL called
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// HTTP method of the call
+	// +kubebuilder:validation:Enum=GET;POST;PUT
+	// +kubebuilder:default:=POST
+	Method string `json:"method,omitempty"`
+}
+
+// Runs reports whether the hook runs in phase on transitions to periods of type.
+func (h *K8sHook) Runs(phase HookPhase, periodType common.PeriodType) bool {
+	return h.Phase == phase && (len(h.On) == 0 || slices.Contains(h.On, periodType))
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Period",type=string,JSONPath=`.status.currentPeriod.name`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.status.currentPeriod.type`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +genclient
 
 // K8s is the Schema for the k8s API
@@ -207,6 +207,13 @@ func (in *GcpConfig) DeepCopyInto(out *GcpConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]common.NotificationEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpConfig.
@@ -279,6 +286,11 @@ func (in *GcpSpec) DeepCopyInto(out *GcpSpec) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(common.ScalerOverride)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Config.DeepCopyInto(&out.Config)
 }
 
@@ -319,6 +331,41 @@ func (in *K8s) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K8sCluster) DeepCopyInto(out *K8sCluster) {
+	*out = *in
+	if in.AuthSecret != nil {
+		in, out := &in.AuthSecret, &out.AuthSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthSecretNamespace != nil {
+		in, out := &in.AuthSecretNamespace, &out.AuthSecretNamespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sCluster.
+func (in *K8sCluster) DeepCopy() *K8sCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(K8sCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *K8sConfig) DeepCopyInto(out *K8sConfig) {
 	*out = *in
@@ -332,11 +379,69 @@ func (in *K8sConfig) DeepCopyInto(out *K8sConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeploymentGracePeriod != nil {
+		in, out := &in.DeploymentGracePeriod, &out.DeploymentGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadinessTimeout != nil {
+		in, out := &in.ReadinessTimeout, &out.ReadinessTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScalingGroups != nil {
+		in, out := &in.ScalingGroups, &out.ScalingGroups
+		*out = make([]K8sScalingGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AuthSecret != nil {
 		in, out := &in.AuthSecret, &out.AuthSecret
 		*out = new(string)
 		**out = **in
 	}
+	if in.AuthSecretNamespace != nil {
+		in, out := &in.AuthSecretNamespace, &out.AuthSecretNamespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]K8sCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SleepingService != nil {
+		in, out := &in.SleepingService, &out.SleepingService
+		*out = new(common.SleepingService)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]common.NotificationEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]K8sHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sConfig.
@@ -349,6 +454,72 @@ func (in *K8sConfig) DeepCopy() *K8sConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K8sHook) DeepCopyInto(out *K8sHook) {
+	*out = *in
+	if in.On != nil {
+		in, out := &in.On, &out.On
+		*out = make([]common.PeriodType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(K8sHookJob)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(K8sHookHTTP)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sHook.
+func (in *K8sHook) DeepCopy() *K8sHook {
+	if in == nil {
+		return nil
+	}
+	out := new(K8sHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K8sHookHTTP) DeepCopyInto(out *K8sHookHTTP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sHookHTTP.
+func (in *K8sHookHTTP) DeepCopy() *K8sHookHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(K8sHookHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K8sHookJob) DeepCopyInto(out *K8sHookJob) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sHookJob.
+func (in *K8sHookJob) DeepCopy() *K8sHookJob {
+	if in == nil {
+		return nil
+	}
+	out := new(K8sHookJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *K8sList) DeepCopyInto(out *K8sList) {
 	*out = *in
@@ -398,6 +569,36 @@ func (in *K8sResource) DeepCopy() *K8sResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K8sScalingGroup) DeepCopyInto(out *K8sScalingGroup) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]common.ResourceKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessTimeout != nil {
+		in, out := &in.ReadinessTimeout, &out.ReadinessTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sScalingGroup.
+func (in *K8sScalingGroup) DeepCopy() *K8sScalingGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(K8sScalingGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *K8sSpec) DeepCopyInto(out *K8sSpec) {
 	*out = *in
@@ -409,6 +610,11 @@ func (in *K8sSpec) DeepCopyInto(out *K8sSpec) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(common.ScalerOverride)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Config.DeepCopyInto(&out.Config)
 }
 
@@ -18,10 +18,12 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -30,6 +32,7 @@ import (
 
 	"github.com/rs/zerolog"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -43,16 +46,24 @@ import (
 	kubecloudscalerv1alpha1 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha1"
 	kubecloudscalerv1alpha2 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha2"
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/activator"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/config"
 	flowController "github.com/kubecloudscaler/kubecloudscaler/internal/controller/flow"
 	gcpController "github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp"
 	k8sController "github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/metrics"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/pricing"
 	webhookv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/internal/webhook/v1alpha3"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
 	// +kubebuilder:scaffold:imports
 )
 
 const (
 	webhookDisabledEnvValue = "false"
+	// tracingShutdownTimeout bounds the export of the last spans on shutdown.
+	tracingShutdownTimeout = 5 * time.Second
 )
 
 var (
@@ -102,6 +113,42 @@ func main() {
 	var metricsDisableAuth bool
 	flag.BoolVar(&metricsDisableAuth, "metrics-disable-auth", false,
 		"If set, the metrics endpoint is served without authentication/authorization (useful for local dev only).")
+	var activatorAddr string
+	var activatorWakeDuration, activatorWaitTimeout time.Duration
+	flag.StringVar(&activatorAddr, "activator-bind-address", "0", "The address the wake-on-request activator binds to. "+
+		"Use :8082 to serve it, or leave as 0 to disable the activator.")
+	flag.DurationVar(&activatorWakeDuration, "activator-wake-duration", activator.DefaultWakeDuration,
+		"How long a scaler woken up by the activator stays awake. Requests served once awake do not extend it.")
+	flag.DurationVar(&activatorWaitTimeout, "activator-wait-timeout", activator.DefaultWaitTimeout,
+		"How long the activator holds a request while the environment wakes up.")
+	var scalingConcurrency int
+	flag.IntVar(&scalingConcurrency, "scaling-concurrency", k8sUtils.DefaultConcurrency,
+		"How many resource kinds, namespaces and resources a K8s scaler processes in parallel, "+
+			"unless the scaler sets spec.config.concurrency.")
+	var k8sConcurrentReconciles int
+	flag.IntVar(&k8sConcurrentReconciles, "k8s-max-concurrent-reconciles", k8sController.DefaultMaxConcurrentReconciles,
+		"How many K8s scalers are reconciled in parallel. A scaler waiting for its workloads to be ready "+
+			"or for its hooks holds one of them.")
+	var priceTable string
+	flag.StringVar(&priceTable, "price-table-configmap", "",
+		"The name of the ConfigMap, in the operator namespace, holding the prices used to estimate the savings "+
+			"of scalers. Leave empty to disable the estimation.")
+	var notificationEndpoints string
+	flag.StringVar(&notificationEndpoints, "notifications-configmap", "",
+		"The name of the ConfigMap, in the operator namespace, holding the notification endpoints notified "+
+			"of the transitions of every scaler, on top of their own.")
+	var execCommands string
+	flag.StringVar(&execCommands, "kubeconfig-exec-commands", "",
+		"Comma-separated list of the exec plugin commands, such as kubelogin, that the kubeconfigs of K8s "+
+			"scaler auth secrets may run in the operator pod. Leave empty to reject kubeconfigs relying on exec plugins.")
+	var tracingOpts tracing.Options
+	flag.StringVar(&tracingOpts.Endpoint, "otlp-endpoint", "",
+		"The host:port of the OTLP gRPC collector traces of reconciliations are exported to. "+
+			"Leave empty to disable tracing.")
+	flag.BoolVar(&tracingOpts.Insecure, "otlp-insecure", false,
+		"If set, traces are exported to the OTLP collector without TLS.")
+	flag.Float64Var(&tracingOpts.SampleRatio, "trace-sample-ratio", 1,
+		"The ratio of reconciliations traced, from 0 to 1.")
 	flag.StringVar(&logFormat, "log-format", "json", "Set log format \"raw\" or \"json\"")
 	flag.StringVar(&logLevel, "log-level", "info", "Set log level \"debug\", \"info\", \"warn\", \"error\", \"fatal\"")
 	opts := zap.Options{
@@ -136,6 +183,12 @@ func main() {
 		Timestamp().
 		Logger()
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -254,15 +307,41 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = k8sController.NewScalerReconciler(
-		mgr.GetClient(), mgr.GetScheme(), &logger, nil,
-	).SetupWithManager(mgr); err != nil {
+	// The price table is read directly so that the operator does not cache every ConfigMap
+	var prices pricing.Source
+	if priceTable != "" {
+		prices = pricing.NewConfigMapSource(mgr.GetAPIReader(), types.NamespacedName{
+			Namespace: config.DefaultNamespaceResolver().Resolve(),
+			Name:      priceTable,
+		})
+	}
+
+	// Notification endpoints and their secrets are read directly as well
+	var globalEndpoints notify.EndpointSource
+	if notificationEndpoints != "" {
+		globalEndpoints = notify.NewConfigMapSource(mgr.GetAPIReader(), types.NamespacedName{
+			Namespace: config.DefaultNamespaceResolver().Resolve(),
+			Name:      notificationEndpoints,
+		})
+	}
+	notifier := notify.NewDispatcher(mgr.GetAPIReader(), config.DefaultNamespaceResolver().Resolve(), globalEndpoints, &logger)
+
+	k8sReconciler := k8sController.NewScalerReconciler(mgr.GetClient(), mgr.GetScheme(), &logger, nil)
+	k8sReconciler.ScalingConcurrency = scalingConcurrency
+	k8sReconciler.MaxConcurrentReconciles = k8sConcurrentReconciles
+	k8sReconciler.Prices = prices
+	k8sReconciler.Notifier = notifier
+	if execCommands != "" {
+		k8sReconciler.ExecCommands = strings.Split(execCommands, ",")
+	}
+	if err = k8sReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "K8sScaler")
 		os.Exit(1)
 	}
-	if err = gcpController.NewScalerReconciler(
-		mgr.GetClient(), mgr.GetScheme(), &logger, nil,
-	).SetupWithManager(mgr); err != nil {
+	gcpReconciler := gcpController.NewScalerReconciler(mgr.GetClient(), mgr.GetScheme(), &logger, nil)
+	gcpReconciler.Prices = prices
+	gcpReconciler.Notifier = notifier
+	if err = gcpReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GcpScaler")
 		os.Exit(1)
 	}
@@ -293,6 +372,18 @@ func main() {
 	}
 	// +kubebuilder:scaffold:builder
 
+	if activatorAddr != "0" {
+		activatorServer := activator.New(mgr.GetClient(), mgr.GetCache(), &logger, activator.Config{
+			Addr:         activatorAddr,
+			WakeDuration: activatorWakeDuration,
+			WaitTimeout:  activatorWaitTimeout,
+		})
+		if err := activatorServer.SetupWithManager(context.Background(), mgr); err != nil {
+			setupLog.Error(err, "unable to add activator to manager")
+			os.Exit(1)
+		}
+	}
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -319,8 +410,17 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		setupLog.Error(err, "problem running manager")
+	startErr := mgr.Start(ctrl.SetupSignalHandler())
+
+	// Flush the spans not exported yet
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
+	cancel()
+
+	if startErr != nil {
+		setupLog.Error(startErr, "problem running manager")
 		os.Exit(1)
 	}
 }
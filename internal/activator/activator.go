@@ -0,0 +1,233 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package activator provides the wake-on-request HTTP server for scaled-down environments.
+//
+// Ingresses and HTTPRoutes of a sleeping environment point at the configured sleeping Service,
+// which forwards to the activator. On the first request the activator annotates the owning K8s
+// scaler with a wake deadline, waits for the environment to come back and redirects the client
+// to the same URL. The PeriodHandler restores resources until the deadline expires.
+package activator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	networkingV1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
+)
+
+const (
+	// DefaultWakeDuration is how long a scaler stays awake once woken up.
+	DefaultWakeDuration = 1 * time.Hour
+	// DefaultWaitTimeout is how long a request is held while the environment wakes up.
+	DefaultWaitTimeout = 1 * time.Minute
+	// DefaultPollInterval is the interval between two readiness checks.
+	DefaultPollInterval = 2 * time.Second
+
+	// retryAfterSeconds is sent to clients whose request timed out while waking up.
+	retryAfterSeconds = 5
+	// shutdownTimeout bounds the graceful shutdown of the HTTP server.
+	shutdownTimeout = 10 * time.Second
+)
+
+// Config holds the activator settings.
+type Config struct {
+	// Addr is the address the activator listens on.
+	Addr string
+	// WakeDuration is how long a scaler stays awake once woken up. The activator only sees the
+	// requests of sleeping routes: the traffic served by the environment once awake does not
+	// extend it, and the next request after it expires wakes the scaler up again.
+	WakeDuration time.Duration
+	// WaitTimeout is how long a request is held while the environment wakes up.
+	WaitTimeout time.Duration
+	// PollInterval is the interval between two readiness checks.
+	PollInterval time.Duration
+}
+
+// Activator wakes up K8s scalers when their sleeping routes receive traffic.
+type Activator struct {
+	client client.Client
+	reader client.Reader
+	logger *zerolog.Logger
+	config Config
+	// httpRoutes is set once HTTPRoutes are indexed, unless the Gateway API is not installed
+	httpRoutes bool
+}
+
+// New creates a new Activator. Reads go through reader, the manager cache, where SetupWithManager
+// indexes the sleeping Ingresses and HTTPRoutes by host. The cache rather than the manager client
+// is needed for HTTPRoutes, which are read as unstructured objects.
+func New(c client.Client, reader client.Reader, logger *zerolog.Logger, config Config) *Activator {
+	if config.WakeDuration <= 0 {
+		config.WakeDuration = DefaultWakeDuration
+	}
+	if config.WaitTimeout <= 0 {
+		config.WaitTimeout = DefaultWaitTimeout
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+
+	return &Activator{
+		client: c,
+		reader: reader,
+		logger: logger,
+		config: config,
+	}
+}
+
+// SetupWithManager indexes the sleeping routes by host in the manager cache and adds the
+// activator to the manager.
+func (a *Activator) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(ctx, &networkingV1.Ingress{}, hostIndex, ingressHosts); err != nil {
+		return fmt.Errorf("unable to index ingresses: %w", err)
+	}
+
+	httpRoute := &unstructured.Unstructured{}
+	httpRoute.SetGroupVersionKind(httpRouteListGVK.GroupVersion().WithKind("HTTPRoute"))
+	err := indexer.IndexField(ctx, httpRoute, hostIndex, httpRouteHosts)
+	switch {
+	case err == nil:
+		a.httpRoutes = true
+	case meta.IsNoMatchError(err):
+		// The Gateway API is optional: skip HTTPRoutes when the CRD is not installed.
+		a.logger.Info().Msg("Gateway API not installed, the activator ignores HTTPRoutes")
+	default:
+		return fmt.Errorf("unable to index httproutes: %w", err)
+	}
+
+	return mgr.Add(a)
+}
+
+// Start runs the HTTP server until ctx is canceled. It implements manager.Runnable.
+func (a *Activator) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              a.config.Addr,
+		Handler:           a,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			a.logger.Error().Err(err).Msg("unable to shut down activator")
+		}
+	}()
+
+	a.logger.Info().Str("addr", a.config.Addr).Msg("starting activator")
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("activator server failed: %w", err)
+	}
+
+	return nil
+}
+
+// NeedLeaderElection returns false so that every replica serves requests.
+func (a *Activator) NeedLeaderElection() bool {
+	return false
+}
+
+// ServeHTTP wakes up the scaler owning the requested host and redirects the client once its
+// environment is ready. Requests that outlive WaitTimeout get a 503 with a Retry-After header.
+func (a *Activator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	host := hostname(r.Host)
+	logger := a.logger.With().Str("host", host).Logger()
+
+	target, err := a.resolve(ctx, host)
+	if err != nil {
+		if errors.Is(err, ErrNoTarget) {
+			logger.Debug().Msg("no sleeping route for host")
+			http.Error(w, "no sleeping environment for this host", http.StatusNotFound)
+			return
+		}
+		logger.Error().Err(err).Msg("unable to resolve sleeping route")
+		http.Error(w, "unable to resolve sleeping environment", http.StatusInternalServerError)
+		return
+	}
+
+	logger = logger.With().
+		Str("scaler", target.scaler.Name).
+		Str("namespace", target.namespace).
+		Str("route", target.name).
+		Logger()
+
+	if err := a.wake(ctx, target.scaler); err != nil {
+		logger.Error().Err(err).Msg("unable to wake up scaler")
+		http.Error(w, "unable to wake up environment", http.StatusInternalServerError)
+		return
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, a.config.PollInterval, a.config.WaitTimeout, true, func(ctx context.Context) (bool, error) {
+		return a.ready(ctx, target)
+	})
+	if err != nil {
+		logger.Info().Err(err).Msg("environment still waking up")
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+		http.Error(w, "environment is waking up, please retry shortly", http.StatusServiceUnavailable)
+		return
+	}
+
+	logger.Info().Msg("environment awake, redirecting")
+	http.Redirect(w, r, r.URL.RequestURI(), http.StatusTemporaryRedirect)
+}
+
+// wake annotates the scaler with a wake deadline WakeDuration from now. The patch is skipped
+// while the current deadline is still more than half the wake duration away, to avoid one write
+// per request.
+func (a *Activator) wake(ctx context.Context, scaler *kubecloudscalerv1alpha3.K8s) error {
+	now := time.Now()
+	if until, awake := utils.WakeUntil(scaler, now); awake && until.Sub(now) > a.config.WakeDuration/2 {
+		return nil
+	}
+
+	patch := client.MergeFrom(scaler.DeepCopy())
+	annotations := scaler.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[utils.WakeAnnotation] = now.Add(a.config.WakeDuration).UTC().Format(time.RFC3339)
+	scaler.SetAnnotations(annotations)
+
+	return a.client.Patch(ctx, scaler, patch)
+}
+
+// hostname strips the port from a Host header.
+func hostname(hostport string) string {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return hostport
+	}
+
+	return host
+}
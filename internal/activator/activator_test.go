@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/activator"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+var _ = Describe("Activator", func() {
+	const (
+		testNamespace = "preview"
+		testHost      = "preview.example.com"
+	)
+
+	var (
+		scheme  *runtime.Scheme
+		logger  zerolog.Logger
+		scaler  *kubecloudscalerv1alpha3.K8s
+		ingress *networkingV1.Ingress
+		deploy  *appsV1.Deployment
+		origKey = k8sUtils.AnnotationsPrefix + "/" + k8sUtils.AnnotationsOrigValue
+	)
+
+	newActivator := func(funcs interceptor.Funcs, objs ...client.Object) (*activator.Activator, client.Client) {
+		c := fakeclient.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithIndex(&networkingV1.Ingress{}, activator.HostIndex, activator.IngressHosts).
+			WithInterceptorFuncs(funcs).
+			Build()
+		return activator.New(c, c, &logger, activator.Config{
+			WakeDuration: time.Hour,
+			WaitTimeout:  200 * time.Millisecond,
+			PollInterval: 10 * time.Millisecond,
+		}), c
+	}
+
+	serve := func(a *activator.Activator, host string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://"+host+":8082/app?x=1", nil)
+		rec := httptest.NewRecorder()
+		a.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// restoreOnWake simulates the controller switching the ingress back once the scaler is patched.
+	restoreOnWake := interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if err := c.Patch(ctx, obj, patch, opts...); err != nil {
+				return err
+			}
+			ing := &networkingV1.Ingress{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "web"}, ing); err != nil {
+				return err
+			}
+			ing.Annotations = nil
+			return c.Update(ctx, ing)
+		},
+	}
+
+	BeforeEach(func() {
+		logger = zerolog.Nop()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(kubecloudscalerv1alpha3.AddToScheme(scheme)).To(Succeed())
+
+		scaler = &kubecloudscalerv1alpha3.K8s{
+			ObjectMeta: metaV1.ObjectMeta{Name: "preview-nightly"},
+			Spec: kubecloudscalerv1alpha3.K8sSpec{
+				Resources: common.Resources{
+					Types: []common.ResourceKind{common.ResourceDeployments, common.ResourceIngresses},
+				},
+				Config: kubecloudscalerv1alpha3.K8sConfig{
+					Namespaces:      []string{testNamespace},
+					SleepingService: &common.SleepingService{Name: "activator", Port: 8082},
+				},
+			},
+		}
+
+		ingress = &networkingV1.Ingress{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:        "web",
+				Namespace:   testNamespace,
+				Annotations: map[string]string{origKey: "{}"},
+			},
+			Spec: networkingV1.IngressSpec{
+				Rules: []networkingV1.IngressRule{{Host: testHost}},
+			},
+		}
+
+		deploy = &appsV1.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: testNamespace},
+			Spec:       appsV1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+			Status:     appsV1.DeploymentStatus{AvailableReplicas: 2},
+		}
+	})
+
+	It("should return 404 for hosts without a sleeping route", func() {
+		a, _ := newActivator(interceptor.Funcs{}, scaler, ingress)
+
+		rec := serve(a, "other.example.com")
+
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should ignore scalers acting on remote clusters", func() {
+		scaler.Spec.Config.AuthSecret = ptr.To("remote")
+		a, _ := newActivator(interceptor.Funcs{}, scaler, ingress)
+
+		rec := serve(a, testHost)
+
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should match the cluster of a scaler without an auth secret", func() {
+		scaler.Spec.Config.Clusters = []kubecloudscalerv1alpha3.K8sCluster{
+			{Name: "eu", AuthSecret: ptr.To("eu")},
+		}
+		a, _ := newActivator(interceptor.Funcs{}, scaler, ingress)
+
+		Expect(serve(a, testHost).Code).To(Equal(http.StatusNotFound))
+
+		scaler.Spec.Config.Clusters = append(scaler.Spec.Config.Clusters,
+			kubecloudscalerv1alpha3.K8sCluster{Name: "local", Namespaces: []string{"production"}})
+		a, _ = newActivator(interceptor.Funcs{}, scaler, ingress)
+
+		Expect(serve(a, testHost).Code).To(Equal(http.StatusNotFound))
+
+		scaler.Spec.Config.Clusters[1].Namespaces = []string{testNamespace}
+		a, _ = newActivator(interceptor.Funcs{}, scaler, ingress)
+
+		Expect(serve(a, testHost).Code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	It("should not match system namespaces", func() {
+		scaler.Spec.Config.Namespaces = []string{"kube-system"}
+		scaler.Spec.Config.ForceExcludeSystemNamespaces = true
+		ingress.Namespace = "kube-system"
+		a, _ := newActivator(interceptor.Funcs{}, scaler, ingress)
+
+		Expect(serve(a, testHost).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should only match the namespaces selected by label", func() {
+		scaler.Spec.Config.Namespaces = nil
+		scaler.Spec.Config.NamespaceSelector = &metaV1.LabelSelector{MatchLabels: map[string]string{"env": "preview"}}
+		namespace := &coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: testNamespace}}
+		a, _ := newActivator(interceptor.Funcs{}, scaler, ingress, namespace)
+
+		Expect(serve(a, testHost).Code).To(Equal(http.StatusNotFound))
+
+		namespace.Labels = map[string]string{"env": "preview"}
+		a, _ = newActivator(interceptor.Funcs{}, scaler, ingress, namespace)
+
+		Expect(serve(a, testHost).Code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	It("should wake the scaler and ask the client to retry while the environment starts", func() {
+		a, c := newActivator(interceptor.Funcs{}, scaler, ingress)
+
+		rec := serve(a, testHost)
+
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(rec.Header().Get("Retry-After")).ToNot(BeEmpty())
+
+		woken := &kubecloudscalerv1alpha3.K8s{}
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(scaler), woken)).To(Succeed())
+		until, awake := utils.WakeUntil(woken, time.Now())
+		Expect(awake).To(BeTrue())
+		Expect(until).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+	})
+
+	It("should redirect to the same URL once the environment is ready", func() {
+		a, _ := newActivator(restoreOnWake, scaler, ingress, deploy)
+
+		rec := serve(a, testHost)
+
+		Expect(rec.Code).To(Equal(http.StatusTemporaryRedirect))
+		Expect(rec.Header().Get("Location")).To(Equal("/app?x=1"))
+	})
+
+	It("should wait for deployments to become available", func() {
+		deploy.Status.AvailableReplicas = 0
+		a, _ := newActivator(restoreOnWake, scaler, ingress, deploy)
+
+		rec := serve(a, testHost)
+
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	It("should match wildcard hosts", func() {
+		ingress.Spec.Rules[0].Host = "*.example.com"
+		a, _ := newActivator(restoreOnWake, scaler, ingress, deploy)
+
+		Expect(serve(a, "a.b.example.com").Code).To(Equal(http.StatusNotFound))
+		Expect(serve(a, testHost).Code).To(Equal(http.StatusTemporaryRedirect))
+	})
+
+	It("should match hosts regardless of case", func() {
+		ingress.Spec.Rules[0].Host = "Preview.Example.com"
+		a, _ := newActivator(restoreOnWake, scaler, ingress, deploy)
+
+		Expect(serve(a, "PREVIEW.example.com").Code).To(Equal(http.StatusTemporaryRedirect))
+	})
+})
@@ -0,0 +1,26 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+// Test-only exports compiled only into the test binary.
+
+// HostIndex and IngressHosts let tests register the host index on a fake client, which has no
+// field indexer for SetupWithManager to use.
+var (
+	HostIndex    = hostIndex
+	IngressHosts = ingressHosts
+)
@@ -0,0 +1,327 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/consts"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+// ErrNoTarget is returned when no sleeping route managed by a K8s scaler matches the request host.
+var ErrNoTarget = errors.New("no sleeping route for host")
+
+// origValueAnnotation marks routes whose backends were switched to the sleeping Service.
+const origValueAnnotation = consts.AnnotationsPrefix + "/" + consts.AnnotationsOrigValue
+
+// hostIndex indexes the sleeping Ingresses and HTTPRoutes by their lowercased hostnames.
+const hostIndex = consts.AnnotationsPrefix + "/sleeping-host"
+
+// httpRouteListGVK is the Gateway API HTTPRoute list kind.
+var httpRouteListGVK = schema.GroupVersionKind{
+	Group:   "gateway.networking.k8s.io",
+	Version: "v1",
+	Kind:    "HTTPRouteList",
+}
+
+// target is a sleeping route and the scaler managing it.
+type target struct {
+	kind      common.ResourceKind
+	namespace string
+	name      string
+	labels    map[string]string
+	// namespaceLabels are the labels of the route namespace, only read when a scaler selects
+	// namespaces by label
+	namespaceLabels map[string]string
+	scaler          *kubecloudscalerv1alpha3.K8s
+}
+
+// resolve finds the sleeping route serving host and the local K8s scaler managing it.
+func (a *Activator) resolve(ctx context.Context, host string) (*target, error) {
+	routes, err := a.sleepingRoutes(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return nil, ErrNoTarget
+	}
+
+	scalers := &kubecloudscalerv1alpha3.K8sList{}
+	if err := a.reader.List(ctx, scalers); err != nil {
+		return nil, fmt.Errorf("error listing k8s scalers: %w", err)
+	}
+
+	selectsNamespaces := slices.ContainsFunc(scalers.Items, func(scaler kubecloudscalerv1alpha3.K8s) bool {
+		return scaler.Spec.Config.NamespaceSelector != nil
+	})
+
+	for _, route := range routes {
+		if selectsNamespaces {
+			namespace := &coreV1.Namespace{}
+			if err := a.reader.Get(ctx, client.ObjectKey{Name: route.namespace}, namespace); err != nil {
+				return nil, fmt.Errorf("error getting namespace %s: %w", route.namespace, err)
+			}
+			route.namespaceLabels = namespace.Labels
+		}
+
+		for i := range scalers.Items {
+			if manages(&scalers.Items[i], route) {
+				route.scaler = &scalers.Items[i]
+				return route, nil
+			}
+		}
+	}
+
+	return nil, ErrNoTarget
+}
+
+// sleepingRoutes lists the Ingresses and HTTPRoutes serving host whose backends are switched,
+// looking them up in hostIndex by host and by the wildcard hostname matching it.
+func (a *Activator) sleepingRoutes(ctx context.Context, host string) ([]*target, error) {
+	var routes []*target
+	// A route can match both host and its wildcard hostname
+	seen := map[string]bool{}
+	add := func(kind common.ResourceKind, obj metaV1.Object) {
+		key := string(kind) + "/" + obj.GetNamespace() + "/" + obj.GetName()
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		routes = append(routes, &target{
+			kind:      kind,
+			namespace: obj.GetNamespace(),
+			name:      obj.GetName(),
+			labels:    obj.GetLabels(),
+		})
+	}
+
+	for _, hostname := range hostnames(host) {
+		ingresses := &networkingV1.IngressList{}
+		if err := a.reader.List(ctx, ingresses, client.MatchingFields{hostIndex: hostname}); err != nil {
+			return nil, fmt.Errorf("error listing ingresses: %w", err)
+		}
+		for i := range ingresses.Items {
+			add(common.ResourceIngresses, &ingresses.Items[i])
+		}
+
+		if !a.httpRoutes {
+			continue
+		}
+		httpRoutes := &unstructured.UnstructuredList{}
+		httpRoutes.SetGroupVersionKind(httpRouteListGVK)
+		if err := a.reader.List(ctx, httpRoutes, client.MatchingFields{hostIndex: hostname}); err != nil {
+			return nil, fmt.Errorf("error listing httproutes: %w", err)
+		}
+		for i := range httpRoutes.Items {
+			add(common.ResourceHTTPRoutes, &httpRoutes.Items[i])
+		}
+	}
+
+	return routes, nil
+}
+
+// manages reports whether scaler switches the backends of route. Only scalers acting on the
+// local cluster are considered, since the activator cannot reach remote clusters' routes: those
+// without an auth secret, or with a cluster without one among their clusters.
+func manages(scaler *kubecloudscalerv1alpha3.K8s, route *target) bool {
+	cfg := scaler.Spec.Config
+	if cfg.SleepingService == nil {
+		return false
+	}
+
+	namespaces, excludeNamespaces := cfg.Namespaces, cfg.ExcludeNamespaces
+	if len(cfg.Clusters) > 0 {
+		i := slices.IndexFunc(cfg.Clusters, func(cluster kubecloudscalerv1alpha3.K8sCluster) bool {
+			return cluster.AuthSecret == nil
+		})
+		if i < 0 {
+			return false
+		}
+		local := cfg.Clusters[i]
+		if len(local.Namespaces) > 0 {
+			namespaces = local.Namespaces
+		}
+		if len(local.ExcludeNamespaces) > 0 {
+			excludeNamespaces = local.ExcludeNamespaces
+		}
+	} else if cfg.AuthSecret != nil {
+		return false
+	}
+
+	if !slices.Contains(scaler.Spec.Resources.Types, route.kind) {
+		return false
+	}
+
+	if cfg.ForceExcludeSystemNamespaces && slices.Contains(k8sUtils.DefaultExcludeNamespaces, route.namespace) {
+		return false
+	}
+	if len(namespaces) > 0 {
+		if !slices.Contains(namespaces, route.namespace) {
+			return false
+		}
+	} else {
+		if slices.Contains(excludeNamespaces, route.namespace) {
+			return false
+		}
+		if cfg.NamespaceSelector != nil {
+			selector, err := metaV1.LabelSelectorAsSelector(cfg.NamespaceSelector)
+			if err != nil || !selector.Matches(labels.Set(route.namespaceLabels)) {
+				return false
+			}
+		}
+	}
+
+	if len(scaler.Spec.Resources.Names) > 0 && !slices.Contains(scaler.Spec.Resources.Names, route.name) {
+		return false
+	}
+
+	if scaler.Spec.Resources.LabelSelector != nil {
+		selector, err := metaV1.LabelSelectorAsSelector(scaler.Spec.Resources.LabelSelector)
+		if err != nil || !selector.Matches(labels.Set(route.labels)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ready reports whether the route was switched back and the Deployments managed by the scaler
+// in the route namespace are available.
+func (a *Activator) ready(ctx context.Context, t *target) (bool, error) {
+	restored, err := a.routeRestored(ctx, t)
+	if err != nil || !restored {
+		return false, err
+	}
+
+	types := t.scaler.Spec.Resources.Types
+	if len(types) > 0 && !slices.Contains(types, common.ResourceDeployments) {
+		return true, nil
+	}
+
+	opts := []client.ListOption{client.InNamespace(t.namespace)}
+	if t.scaler.Spec.Resources.LabelSelector != nil {
+		selector, err := metaV1.LabelSelectorAsSelector(t.scaler.Spec.Resources.LabelSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid label selector: %w", err)
+		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	deployments := &appsV1.DeploymentList{}
+	if err := a.reader.List(ctx, deployments, opts...); err != nil {
+		return false, fmt.Errorf("error listing deployments: %w", err)
+	}
+
+	for _, deploy := range deployments.Items {
+		names := t.scaler.Spec.Resources.Names
+		if len(names) > 0 && !slices.Contains(names, deploy.Name) {
+			continue
+		}
+		if deploy.Status.ObservedGeneration < deploy.Generation ||
+			deploy.Status.AvailableReplicas < ptr.Deref(deploy.Spec.Replicas, 1) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// routeRestored reports whether the route no longer points at the sleeping Service.
+func (a *Activator) routeRestored(ctx context.Context, t *target) (bool, error) {
+	var obj client.Object
+	switch t.kind {
+	case common.ResourceHTTPRoutes:
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(httpRouteListGVK.GroupVersion().WithKind("HTTPRoute"))
+		obj = route
+	default:
+		obj = &networkingV1.Ingress{}
+	}
+
+	if err := a.reader.Get(ctx, client.ObjectKey{Namespace: t.namespace, Name: t.name}, obj); err != nil {
+		return false, fmt.Errorf("error getting %s: %w", t.kind, err)
+	}
+
+	_, sleeping := obj.GetAnnotations()[origValueAnnotation]
+	return !sleeping, nil
+}
+
+// hostnames returns the Ingress or HTTPRoute hostnames matching host: host itself, and the
+// hostname replacing its first label with a "*" wildcard.
+func hostnames(host string) []string {
+	host = strings.ToLower(host)
+	if _, domain, ok := strings.Cut(host, "."); ok {
+		return []string{host, "*." + domain}
+	}
+
+	return []string{host}
+}
+
+// ingressHosts indexes a sleeping Ingress by the hostnames of its rules.
+func ingressHosts(obj client.Object) []string {
+	ing, ok := obj.(*networkingV1.Ingress)
+	if !ok {
+		return nil
+	}
+	if _, sleeping := ing.Annotations[origValueAnnotation]; !sleeping {
+		return nil
+	}
+
+	hosts := make([]string, 0, len(ing.Spec.Rules))
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, strings.ToLower(rule.Host))
+		}
+	}
+
+	return hosts
+}
+
+// httpRouteHosts indexes a sleeping HTTPRoute by its hostnames.
+func httpRouteHosts(obj client.Object) []string {
+	route, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	if _, sleeping := route.GetAnnotations()[origValueAnnotation]; !sleeping {
+		return nil
+	}
+
+	hosts, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	for i := range hosts {
+		hosts[i] = strings.ToLower(hosts[i])
+	}
+
+	return hosts
+}
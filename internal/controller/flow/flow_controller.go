@@ -34,6 +34,7 @@ import (
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/shared"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/metrics"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
 )
 
 // FlowReconciler reconciles a Flow object.
@@ -106,6 +107,10 @@ func (r *FlowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 
 	logger := r.Logger.With().Str("controller", "flow").Str("name", req.Name).Logger()
 
+	ctx, span := tracing.Start(ctx, "flow.Reconcile",
+		tracing.AttrController.String(metrics.ControllerFlow), tracing.AttrScaler.String(req.Name))
+	defer span.End()
+
 	reconCtx := &service.FlowReconciliationContext{
 		Ctx:     ctx,
 		Request: req,
@@ -121,6 +126,7 @@ func (r *FlowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 
 	chainErr := r.chain.Execute(reconCtx)
 	duration := time.Since(start).Seconds()
+	tracing.SetError(span, chainErr)
 
 	// ProcessingError classification dominates the chain's returned error: a user-config
 	// ValidationError must surface as Critical (no requeue) even when StatusHandler itself
@@ -170,10 +176,10 @@ func (r *FlowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 // Handler Order:
 // 1. FetchHandler → 2. FinalizerHandler → 3. ProcessingHandler → 4. StatusHandler
 func (r *FlowReconciler) initializeChain() service.Handler {
-	fetchHandler := handlers.NewFetchHandler()
-	finalizerHandler := handlers.NewFinalizerHandler()
-	processingHandler := handlers.NewProcessingHandler(r.flowProcessor)
-	statusHandler := handlers.NewStatusHandler(r.statusUpdater)
+	fetchHandler := service.Traced("flow.FetchHandler", handlers.NewFetchHandler())
+	finalizerHandler := service.Traced("flow.FinalizerHandler", handlers.NewFinalizerHandler())
+	processingHandler := service.Traced("flow.ProcessingHandler", handlers.NewProcessingHandler(r.flowProcessor))
+	statusHandler := service.Traced("flow.StatusHandler", handlers.NewStatusHandler(r.statusUpdater))
 
 	fetchHandler.SetNext(finalizerHandler)
 	finalizerHandler.SetNext(processingHandler)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/rs/zerolog"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -32,16 +33,26 @@ import (
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp/service"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp/service/handlers"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/metrics"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/pricing"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
 )
 
 // ScalerReconciler reconciles a Scaler object
 // It manages the lifecycle of GCP resources by scaling them up/down based on configured periods
 type ScalerReconciler struct {
 	client.Client
-	Scheme    *runtime.Scheme
-	Logger    *zerolog.Logger
+	Scheme *runtime.Scheme
+	Logger *zerolog.Logger
+	// Prices provides the price table used to estimate savings (nil disables the estimation).
+	Prices pricing.Source
+	// Notifier sends the transitions of scalers to their notification endpoints (nil disables
+	// notifications).
+	Notifier notify.Notifier
+
 	recorder  metrics.Recorder
+	events    events.EventRecorder
 	chain     service.Handler
 	chainOnce sync.Once
 }
@@ -63,6 +74,7 @@ func NewScalerReconciler(c client.Client, scheme *runtime.Scheme, logger *zerolo
 // +kubebuilder:rbac:groups=kubecloudscaler.cloud,resources=gcps/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kubecloudscaler.cloud,resources=gcps/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -88,12 +100,18 @@ func (r *ScalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	logger := r.Logger.With().Str("controller", "gcp").Str("name", req.Name).Logger()
 	logger.Info().Msg("reconciling scaler")
 
+	ctx, span := tracing.Start(ctx, "gcp.Reconcile",
+		tracing.AttrController.String(metrics.ControllerGcpScaler), tracing.AttrScaler.String(req.Name))
+	defer span.End()
+
 	// Create reconciliation context
 	reconCtx := &service.ReconciliationContext{
-		Ctx:     ctx,
-		Request: req,
-		Client:  r.Client,
-		Logger:  &logger,
+		Ctx:      ctx,
+		Request:  req,
+		Client:   r.Client,
+		Logger:   &logger,
+		Events:   r.events,
+		Notifier: r.Notifier,
 	}
 
 	// Initialize chain lazily if not set (e.g., in tests without SetupWithManager)
@@ -103,9 +121,19 @@ func (r *ScalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		}
 	})
 
+	// Load the price table used to estimate savings
+	if r.Prices != nil {
+		prices, err := r.Prices.Table(ctx)
+		if err != nil {
+			logger.Warn().Err(err).Msg("unable to load the price table, savings are not estimated")
+		}
+		reconCtx.Prices = prices
+	}
+
 	// Execute the handler chain
 	err := r.chain.Execute(reconCtx)
 	duration := time.Since(start).Seconds()
+	tracing.SetError(span, err)
 
 	// Note: GCPClient is owned by the AuthHandler cache (keyed by secret + ResourceVersion).
 	// Do NOT close it here — the cache handles lifecycle, closing stale entries on rotation.
@@ -138,6 +166,7 @@ func (r *ScalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 	metrics.RecordScalingFromResults(rec, metrics.ControllerGcpScaler,
 		toScalingResults(reconCtx.SuccessResults), toScalingResultsFailed(reconCtx.FailedResults))
+	recordScaler(rec, reconCtx.Scaler, reconCtx.ShouldFinalize)
 	rec.RecordReconcile(metrics.ControllerGcpScaler, metrics.ResultSuccess, duration)
 
 	// Successful reconciliation - use requeue from context or default
@@ -147,6 +176,38 @@ func (r *ScalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	return ctrl.Result{}, nil
 }
 
+// recordScaler reports the state and the savings estimated in the status of scaler, or forgets
+// them once the scaler is deleted.
+func recordScaler(rec metrics.Recorder, scaler *kubecloudscalerv1alpha3.Gcp, deleted bool) {
+	if scaler == nil {
+		return
+	}
+	if deleted {
+		rec.ForgetScaler(metrics.ControllerGcpScaler, scaler.Name)
+		return
+	}
+
+	var state metrics.ScalerState
+	if cp := scaler.Status.CurrentPeriod; cp != nil {
+		state.PeriodType = cp.Type
+	}
+	if resources := scaler.Status.Resources; resources != nil {
+		state.Managed, state.Failed = int(resources.Managed), int(resources.Failed)
+		if state.PeriodType == string(common.PeriodTypeDown) {
+			state.ScaledDown = int(resources.Succeeded)
+		}
+	}
+	if next, ok := utils.NextTransition(scaler.Spec.Periods, scaler.Spec.Override, time.Now()); ok {
+		state.NextTransition = next
+	}
+	rec.RecordScalerState(metrics.ControllerGcpScaler, scaler.Name, state)
+
+	if savings := scaler.Status.Savings; savings != nil {
+		rec.RecordSavings(metrics.ControllerGcpScaler, scaler.Name, savings.Currency,
+			utils.ParseAmount(savings.HourlyRate), utils.ParseAmount(savings.Total))
+	}
+}
+
 func toScalingResults(s []common.ScalerStatusSuccess) []metrics.ScalingResult {
 	out := make([]metrics.ScalingResult, 0, len(s))
 	for _, r := range s {
@@ -172,20 +233,30 @@ func toScalingResultsFailed(s []common.ScalerStatusFailed) []metrics.ScalingResu
 // 4. PeriodHandler → 5. ScalingHandler → 6. StatusHandler
 func (r *ScalerReconciler) initializeChain() service.Handler {
 	return service.BuildHandlerChain(
-		handlers.NewFetchHandler(),
-		handlers.NewFinalizerHandler(),
-		handlers.NewAuthHandler(nil),
-		handlers.NewPeriodHandler(),
-		handlers.NewScalingHandler(),
-		handlers.NewStatusHandler(),
+		service.Traced("gcp.FetchHandler", handlers.NewFetchHandler()),
+		service.Traced("gcp.FinalizerHandler", handlers.NewFinalizerHandler()),
+		service.Traced("gcp.AuthHandler", handlers.NewAuthHandler(nil, handlers.WithCallObserver(r.recordGCPCall))),
+		service.Traced("gcp.PeriodHandler", handlers.NewPeriodHandler()),
+		service.Traced("gcp.ScalingHandler", handlers.NewScalingHandler()),
+		service.Traced("gcp.StatusHandler", handlers.NewStatusHandler()),
 	)
 }
 
+// recordGCPCall records the duration and outcome of a GCP API call.
+func (r *ScalerReconciler) recordGCPCall(operation string, duration time.Duration, err error) {
+	rec := r.recorder
+	if rec == nil {
+		rec = metrics.GetRecorder()
+	}
+	rec.RecordGCPCall(operation, duration.Seconds(), err != nil)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 // This method configures the controller to watch for GCP Scaler resources
 // and defines the reconciliation behavior.
 func (r *ScalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.chain = r.initializeChain()
+	r.events = mgr.GetEventRecorder(utils.EventRecorderName)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kubecloudscalerv1alpha3.Gcp{}).              // Watch for GCP Scaler resources
@@ -22,11 +22,14 @@ import (
 
 	"github.com/rs/zerolog"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/pricing"
 	gcpUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/gcp/utils"
 	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/resources"
@@ -41,7 +44,7 @@ import (
 //   - After Finalizer: ShouldFinalize flag set if deletion in progress
 //   - After Auth: GCPClient and Secret populated
 //   - After Period: Period and ResourceConfig populated
-//   - After Scaling: SuccessResults and FailedResults populated
+//   - After Scaling: SuccessResults, FailedResults and ReleasedMachineTypes populated
 //   - After Status: Context ready for next reconciliation cycle
 //
 // Validation Rules:
@@ -65,6 +68,9 @@ type ReconciliationContext struct {
 	// Logger is the structured logger for observability
 	Logger *zerolog.Logger
 
+	// Events records Kubernetes events on the scaler (nil in tests without a manager)
+	Events events.EventRecorder
+
 	// Scaler is the GCP scaler resource being reconciled (populated by fetch handler)
 	Scaler *kubecloudscalerv1alpha3.Gcp
 
@@ -77,6 +83,10 @@ type ReconciliationContext struct {
 	// Period is the current time period configuration (populated by period validation handler)
 	Period *periodPkg.Period
 
+	// PreviousPeriod is the period status before the reconciliation (populated by period validation
+	// handler, nil on the first one)
+	PreviousPeriod *common.ScalerStatusPeriod
+
 	// ResourceConfig is the resource management configuration (populated by period validation handler)
 	ResourceConfig resources.Config
 
@@ -86,6 +96,16 @@ type ReconciliationContext struct {
 	// FailedResults contains failed scaling operations (populated by scaling handler)
 	FailedResults []common.ScalerStatusFailed
 
+	// Prices is the price table used to estimate savings (set by controller, nil disables the estimation)
+	Prices *pricing.Table
+
+	// Notifier sends the transitions of the scaler to its notification endpoints (set by controller,
+	// nil disables notifications)
+	Notifier notify.Notifier
+
+	// ReleasedMachineTypes lists the machine type of each instance kept stopped (populated by scaling handler)
+	ReleasedMachineTypes []string
+
 	// ShouldFinalize indicates if finalizer cleanup is needed (set by finalizer handler)
 	ShouldFinalize bool
 
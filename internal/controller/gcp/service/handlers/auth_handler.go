@@ -53,6 +53,14 @@ func WithClientFactory(factory ClientFactory) AuthHandlerOption {
 	}
 }
 
+// WithCallObserver sets the function called after each GCP API call made through the clients
+// built by the handler.
+func WithCallObserver(fn gcpUtils.CallFunc) AuthHandlerOption {
+	return func(h *AuthHandler) {
+		h.onCall = fn
+	}
+}
+
 // withClientCloser overrides the cache's Close seam. Package-private — only used by tests
 // in this package via a small white-box helper. Production must use the default.
 func withClientCloser(fn clientCloser) AuthHandlerOption {
@@ -82,6 +90,7 @@ type AuthHandler struct {
 	clientCache       *gcpClientCache
 	namespaceResolver config.NamespaceResolver
 	clientFactory     ClientFactory
+	onCall            gcpUtils.CallFunc
 }
 
 // NewAuthHandler creates a new authentication handler. If nsResolver is nil, uses
@@ -131,7 +140,11 @@ func (h *AuthHandler) Execute(ctx *service.ReconciliationContext) error {
 	}
 
 	clientSet, err := h.clientCache.GetOrBuild(key, secretRV, func() (*gcpUtils.ClientSet, error) {
-		return h.clientFactory(ctx.Ctx, secret)
+		clientSet, err := h.clientFactory(ctx.Ctx, secret)
+		if clientSet != nil {
+			clientSet.OnCall = h.onCall
+		}
+		return clientSet, err
 	})
 	if err != nil {
 		// Either the factory failed, or the factory succeeded but closing the prior stale
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -119,6 +120,22 @@ var _ = Describe("AuthHandler", func() {
 			Expect(factory.invocations[0]).To(BeNil())
 		})
 
+		It("should observe the API calls made through the client", func() {
+			var operations []string
+			authHandler = handlers.NewAuthHandler(
+				stubNamespaceResolver{ns: "default"},
+				handlers.WithClientFactory(factory.build),
+				handlers.WithCallObserver(func(operation string, _ time.Duration, _ error) {
+					operations = append(operations, operation)
+				}),
+			)
+
+			Expect(authHandler.Execute(reconCtx)).To(Succeed())
+			reconCtx.GCPClient.Observe(gcpUtils.OperationRegionsGet, time.Now(), nil)
+
+			Expect(operations).To(Equal([]string{gcpUtils.OperationRegionsGet}))
+		})
+
 		It("should propagate factory errors as CriticalError", func() {
 			factory.err = fmt.Errorf("ADC unreachable")
 
@@ -32,6 +32,9 @@ import (
 const (
 	// RequeueDelaySeconds is the delay in seconds before requeuing a run-once period.
 	RequeueDelaySeconds = 5
+
+	// snoozeResource is the kubectl resource type of Gcp scalers, used in snooze commands.
+	snoozeResource = "gcps"
 )
 
 // PeriodHandler validates and determines the current time period.
@@ -39,8 +42,12 @@ const (
 //
 // Responsibilities:
 //   - Validate period configuration
-//   - Determine current active period
+//   - Determine current active period, applying a manual override instead of the periods
+//     until it expires, then removing it from the scaler
+//   - Handle suspended scalers: stop the chain in freeze mode, or apply the "noaction" period
+//     like RestoreOnDelete in restore mode
 //   - Configure resource management settings
+//   - Record a PeriodChanged event on the scaler when the active period changes
 //   - Handle "no action" periods (skip remaining handlers)
 //   - Handle run-once periods (requeue until period ends)
 //
@@ -61,6 +68,18 @@ func NewPeriodHandler() service.Handler {
 func (h *PeriodHandler) Execute(ctx *service.ReconciliationContext) error {
 	scaler := ctx.Scaler
 
+	if !ctx.ShouldFinalize && scaler.Spec.Suspend == common.SuspendFreeze {
+		ctx.Logger.Debug().Msg("scaler suspended, leaving resources in their current state")
+		if scaler.Status.ObservedGeneration != scaler.Generation {
+			utils.SetReconciledConditions(&scaler.Status, scaler.Generation, scaler.Spec.Suspend)
+			if err := patchStatus(ctx); err != nil {
+				ctx.Logger.Warn().Err(err).Msg("failed to persist the suspended status")
+			}
+		}
+		ctx.SkipRemaining = true
+		return nil
+	}
+
 	// Configure resource management settings
 	resourceConfig := resources.Config{
 		GCP: &gcpUtils.Config{
@@ -79,13 +98,16 @@ func (h *PeriodHandler) Execute(ctx *service.ReconciliationContext) error {
 		periods[i] = &scaler.Spec.Periods[i]
 	}
 
+	utils.WarnScaleDown(scalerReconciliation(ctx))
+
 	// Capture previous period name before SetActivePeriod mutates the status in-place.
 	// Same fix as the K8s controller: SetActivePeriod overwrites status.CurrentPeriod
 	// immediately, so comparing scaler.Status.CurrentPeriod.Name after the call always
 	// sees the new value, causing the noaction skip to fire on every transition.
+	prevPeriod := scaler.Status.CurrentPeriod
 	prevPeriodName := ""
-	if scaler.Status.CurrentPeriod != nil {
-		prevPeriodName = scaler.Status.CurrentPeriod.Name
+	if prevPeriod != nil {
+		prevPeriodName = prevPeriod.Name
 	}
 
 	// Validate and determine the current time period
@@ -93,7 +115,8 @@ func (h *PeriodHandler) Execute(ctx *service.ReconciliationContext) error {
 		ctx.Logger,
 		periods,
 		&scaler.Status,
-		scaler.Spec.Config.RestoreOnDelete && ctx.ShouldFinalize,
+		utils.ApplyOverride(scalerReconciliation(ctx)),
+		(scaler.Spec.Config.RestoreOnDelete && ctx.ShouldFinalize) || scaler.Spec.Suspend == common.SuspendRestore,
 	)
 	if err != nil {
 		// Handle run-once period - requeue until the period ends
@@ -106,20 +129,30 @@ func (h *PeriodHandler) Execute(ctx *service.ReconciliationContext) error {
 			return nil
 		}
 
-		// Invalid period configuration - critical error
+		// Invalid period configuration - critical error. Best-effort persist of the conditions
+		// so the user sees why: the chain stops before StatusHandler runs.
 		ctx.Logger.Error().Err(err).Msg("period validation failed")
+		utils.SetInvalidPeriodConditions(&scaler.Status, scaler.Generation, err)
+		if patchErr := patchStatus(ctx); patchErr != nil {
+			ctx.Logger.Warn().Err(patchErr).Msg("failed to persist status")
+		}
 		return service.NewCriticalError(fmt.Errorf("period validation: %w", err))
 	}
 
 	resourceConfig.GCP.Period = period
 	ctx.Period = period
+	ctx.PreviousPeriod = prevPeriod
 	ctx.ResourceConfig = resourceConfig
 
+	utils.RecordPeriodChange(utils.ScalerEvents(ctx.Events, scaler.Spec.Config.DisableEvents), scaler, prevPeriod, period)
+
 	// Skip reconciliation only when the controller was already in "noaction" on the previous
 	// cycle. If we just transitioned from an active period the scaling handler must still run
 	// to restore resource state.
 	// During deletion (ShouldFinalize), never skip: StatusHandler must run to remove the finalizer.
-	if !ctx.ShouldFinalize && prevPeriodName == periodPkg.NoactionPeriodName && period.Name == periodPkg.NoactionPeriodName {
+	// A spec changed in the meantime is reconciled so the status reflects it.
+	if !ctx.ShouldFinalize && scaler.Status.ObservedGeneration == scaler.Generation &&
+		prevPeriodName == periodPkg.NoactionPeriodName && period.Name == periodPkg.NoactionPeriodName {
 		ctx.Logger.Debug().Str("period", periodPkg.NoactionPeriodName).Msg("no action period, skipping")
 		ctx.SkipRemaining = true
 		ctx.RequeueAfter = utils.ReconcileSuccessDuration
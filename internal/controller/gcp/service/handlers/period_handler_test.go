@@ -18,11 +18,15 @@ package handlers_test
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -31,6 +35,8 @@ import (
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp/service"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp/service/handlers"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
 	gcpUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/gcp/utils"
 )
 
@@ -187,6 +193,177 @@ var _ = Describe("PeriodHandler", func() {
 		})
 	})
 
+	Context("When a manual override is set", func() {
+		BeforeEach(func() {
+			scaler.Spec.Periods = []common.ScalerPeriod{
+				{
+					Name: "always-down",
+					Type: common.PeriodTypeDown,
+					Time: common.TimePeriod{
+						Recurring: &common.RecurringPeriod{
+							Days:      []common.DayOfWeek{common.DayAll},
+							StartTime: "00:00",
+							EndTime:   "23:59",
+							Once:      ptr.To(false),
+						},
+					},
+				},
+			}
+			scaler.Spec.Override = &common.ScalerOverride{
+				Type:  common.OverrideTypeUp,
+				Until: metav1.NewTime(time.Now().Add(time.Hour)),
+			}
+		})
+
+		JustBeforeEach(func() {
+			reconCtx = &service.ReconciliationContext{
+				Ctx:       context.Background(),
+				Request:   ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-scaler", Namespace: "default"}},
+				Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaler).Build(),
+				Logger:    &logger,
+				Scaler:    scaler,
+				GCPClient: &gcpUtils.ClientSet{},
+			}
+		})
+
+		It("should apply it over the periods until it expires", func() {
+			Expect(periodHandler.Execute(reconCtx)).To(Succeed())
+			Expect(reconCtx.Period.Name).To(Equal(utils.OverridePeriodName))
+			Expect(reconCtx.Period.Type).To(Equal(common.PeriodTypeUp))
+			Expect(reconCtx.Scaler.Status.CurrentPeriod.Override).ToNot(BeNil())
+			Expect(reconCtx.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		})
+
+		It("should remove it from the scaler once expired", func() {
+			scaler.Spec.Override.Until = metav1.NewTime(time.Now().Add(-time.Minute))
+			reconCtx.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaler).Build()
+
+			Expect(periodHandler.Execute(reconCtx)).To(Succeed())
+			Expect(reconCtx.Period.Name).To(Equal("always-down"))
+
+			persisted := &kubecloudscalerv1alpha3.Gcp{}
+			Expect(reconCtx.Client.Get(reconCtx.Ctx, reconCtx.Request.NamespacedName, persisted)).To(Succeed())
+			Expect(persisted.Spec.Override).To(BeNil())
+		})
+	})
+
+	Context("When the scaler is suspended", func() {
+		BeforeEach(func() {
+			scaler.Spec.Periods = []common.ScalerPeriod{
+				{
+					Name: "always-up",
+					Type: common.PeriodTypeUp,
+					Time: common.TimePeriod{
+						Recurring: &common.RecurringPeriod{
+							Days:      []common.DayOfWeek{common.DayAll},
+							StartTime: "00:00",
+							EndTime:   "23:59",
+							Once:      ptr.To(false),
+						},
+					},
+				},
+			}
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "always-up", Type: string(common.PeriodTypeUp)}
+
+			reconCtx = &service.ReconciliationContext{
+				Ctx:       context.Background(),
+				Request:   ctrl.Request{},
+				Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaler).Build(),
+				Logger:    &logger,
+				Scaler:    scaler,
+				GCPClient: &gcpUtils.ClientSet{},
+			}
+		})
+
+		It("should skip remaining handlers in freeze mode", func() {
+			scaler.Spec.Suspend = common.SuspendFreeze
+
+			Expect(periodHandler.Execute(reconCtx)).To(Succeed())
+			Expect(reconCtx.SkipRemaining).To(BeTrue())
+			Expect(reconCtx.Period).To(BeNil())
+		})
+
+		It("should apply the noaction period once, then skip in restore mode", func() {
+			scaler.Spec.Suspend = common.SuspendRestore
+
+			Expect(periodHandler.Execute(reconCtx)).To(Succeed())
+			Expect(reconCtx.Period.Name).To(Equal("noaction"))
+			Expect(reconCtx.SkipRemaining).To(BeFalse())
+
+			Expect(periodHandler.Execute(reconCtx)).To(Succeed())
+			Expect(reconCtx.SkipRemaining).To(BeTrue())
+		})
+	})
+
+	Context("When a down period with warnings is about to start", func() {
+		var (
+			recorder *events.FakeRecorder
+			notifier *recordingNotifier
+		)
+
+		BeforeEach(func() {
+			start := time.Now().UTC().Add(2 * time.Minute).Truncate(time.Second)
+			scaler.Spec.Periods = []common.ScalerPeriod{
+				{
+					Name: "maintenance",
+					Type: common.PeriodTypeDown,
+					Time: common.TimePeriod{
+						Fixed: &common.FixedPeriod{
+							StartTime: start.Format(time.DateTime),
+							EndTime:   start.Add(time.Hour).Format(time.DateTime),
+							Timezone:  ptr.To("UTC"),
+						},
+					},
+					Warnings: []metav1.Duration{{Duration: 15 * time.Minute}, {Duration: time.Minute}},
+				},
+			}
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{
+				Name:       "noaction",
+				Type:       "noaction",
+				Successful: []common.ScalerStatusSuccess{{Kind: "vm-instance", Name: "worker-1"}},
+			}
+
+			recorder = events.NewFakeRecorder(10)
+			notifier = &recordingNotifier{}
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(scaler).
+				WithStatusSubresource(scaler).
+				Build()
+			reconCtx = &service.ReconciliationContext{
+				Ctx:       context.Background(),
+				Request:   ctrl.Request{},
+				Client:    k8sClient,
+				Logger:    &logger,
+				Scaler:    scaler,
+				GCPClient: &gcpUtils.ClientSet{},
+				Events:    recorder,
+				Notifier:  notifier,
+			}
+		})
+
+		It("should warn, then requeue in time for the next warning", func() {
+			Expect(periodHandler.Execute(reconCtx)).To(Succeed())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring("Normal ScaleDownWarning")))
+			Expect(notifier.events).To(HaveLen(1))
+			Expect(notifier.events[0].Kind).To(Equal(notify.KindGcp))
+			Expect(notifier.events[0].Resources).To(Equal([]string{"vm-instance worker-1"}))
+			Expect(notifier.events[0].Snooze).To(ContainSubstring("kubectl patch gcps test-scaler"))
+			Expect(scaler.Status.LastWarning.Before.Duration).To(Equal(15 * time.Minute))
+			Expect(reconCtx.RequeueAfter).To(BeNumerically("~", time.Minute, 2*time.Second))
+		})
+
+		It("should not warn in dry-run mode", func() {
+			scaler.Spec.DryRun = true
+
+			Expect(periodHandler.Execute(reconCtx)).To(Succeed())
+
+			Expect(notifier.events).To(BeEmpty())
+			Expect(scaler.Status.LastWarning).To(BeNil())
+		})
+	})
+
 	Context("When handling finalizer deletion", func() {
 		BeforeEach(func() {
 			scaler.Spec.Config.RestoreOnDelete = true
@@ -0,0 +1,74 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp/service"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
+)
+
+// gcpScaler adapts a Gcp scaler to the period handling shared with the other scalers.
+type gcpScaler struct {
+	scaler *kubecloudscalerv1alpha3.Gcp
+}
+
+func (s gcpScaler) Object() client.Object { return s.scaler }
+
+func (s gcpScaler) Spec() utils.ScalerSpec {
+	return utils.ScalerSpec{
+		Periods:       s.scaler.Spec.Periods,
+		Override:      s.scaler.Spec.Override,
+		Suspend:       s.scaler.Spec.Suspend,
+		DryRun:        s.scaler.Spec.DryRun,
+		DisableEvents: s.scaler.Spec.Config.DisableEvents,
+		Notifications: s.scaler.Spec.Config.Notifications,
+	}
+}
+
+func (s gcpScaler) Status() *common.ScalerStatus { return &s.scaler.Status }
+
+func (s gcpScaler) ClearOverride() { s.scaler.Spec.Override = nil }
+
+func (s gcpScaler) Empty() utils.Scaler { return gcpScaler{scaler: &kubecloudscalerv1alpha3.Gcp{}} }
+
+func (s gcpScaler) DeepCopy() utils.Scaler { return gcpScaler{scaler: s.scaler.DeepCopy()} }
+
+// scalerReconciliation returns the state of ctx read and updated by the shared period handling.
+func scalerReconciliation(ctx *service.ReconciliationContext) *utils.ScalerReconciliation {
+	return &utils.ScalerReconciliation{
+		Ctx:            ctx.Ctx,
+		Client:         ctx.Client,
+		Logger:         ctx.Logger,
+		Events:         ctx.Events,
+		Notifier:       ctx.Notifier,
+		Scaler:         gcpScaler{scaler: ctx.Scaler},
+		Kind:           notify.KindGcp,
+		SnoozeResource: snoozeResource,
+		ShouldFinalize: ctx.ShouldFinalize,
+		RequeueAfter:   &ctx.RequeueAfter,
+	}
+}
+
+// patchStatus persists the in-memory status of the scaler of ctx.
+func patchStatus(ctx *service.ReconciliationContext) error {
+	return utils.PatchStatus(ctx.Ctx, ctx.Client, gcpScaler{scaler: ctx.Scaler})
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp/service"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/resources"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
 )
 
 // ScalingHandler scales GCP resources based on the current period.
@@ -32,6 +34,7 @@ import (
 //   - Validate and filter resource types
 //   - Scale each resource type according to period configuration
 //   - Collect success and failure results
+//   - Record a ScalingFailed event on the scaler for each failure
 //   - Continue chain even if individual resources fail (tracked in FailedResults)
 //
 // Error Handling:
@@ -56,31 +59,19 @@ func (h *ScalingHandler) Execute(ctx *service.ReconciliationContext) error {
 
 	resourceList := validResourceList(ctx.Scaler)
 
-	// Process each resource type and perform scaling operations
-	for _, resource := range resourceList {
-		// Create a resource handler for the specific resource type
-		curResource, err := resources.NewResource(ctx.Ctx, resource, ctx.ResourceConfig, ctx.Logger)
-		if err != nil {
-			ctx.Logger.Error().Err(err).Str("resource", resource).Msg("unable to get resource handler")
-			failedResults = append(failedResults, common.ScalerStatusFailed{
-				Kind:   resource,
-				Name:   resource,
-				Reason: fmt.Sprintf("unable to get resource handler: %v", err),
-			})
-			continue
+	// Collect the instances kept stopped to estimate savings
+	var released []string
+	if ctx.ResourceConfig.GCP != nil {
+		gcpConfig := *ctx.ResourceConfig.GCP
+		gcpConfig.OnRelease = func(machineType string) {
+			released = append(released, machineType)
 		}
+		ctx.ResourceConfig.GCP = &gcpConfig
+	}
 
-		// Execute the scaling operation for this resource type
-		success, failed, err := curResource.SetState(ctx.Ctx)
-		if err != nil {
-			ctx.Logger.Error().Err(err).Str("resource", resource).Msg("unable to set resource state")
-			failedResults = append(failedResults, common.ScalerStatusFailed{
-				Kind:   resource,
-				Name:   resource,
-				Reason: fmt.Sprintf("unable to set resource state: %v", err),
-			})
-			continue
-		}
+	// Process each resource type and perform scaling operations
+	for _, resource := range resourceList {
+		success, failed := h.scaleKind(ctx, resource)
 
 		// Collect results for status reporting
 		successResults = append(successResults, success...)
@@ -89,6 +80,8 @@ func (h *ScalingHandler) Execute(ctx *service.ReconciliationContext) error {
 
 	ctx.SuccessResults = successResults
 	ctx.FailedResults = failedResults
+	ctx.ReleasedMachineTypes = released
+	utils.RecordScalingFailures(utils.ScalerEvents(ctx.Events, ctx.Scaler.Spec.Config.DisableEvents), ctx.Scaler, failedResults)
 
 	ctx.Logger.Debug().
 		Int("success", len(successResults)).
@@ -102,6 +95,43 @@ func (h *ScalingHandler) Execute(ctx *service.ReconciliationContext) error {
 	return nil
 }
 
+// scaleKind scales all resources of a single type. Errors are reported as failed results.
+func (h *ScalingHandler) scaleKind(
+	ctx *service.ReconciliationContext, resource string,
+) (success []common.ScalerStatusSuccess, failed []common.ScalerStatusFailed) {
+	spanCtx, span := tracing.Start(ctx.Ctx, "gcp.ScaleKind", tracing.AttrResourceKind.String(resource))
+	defer func() {
+		span.SetAttributes(tracing.AttrSucceeded.Int(len(success)), tracing.AttrFailed.Int(len(failed)))
+		span.End()
+	}()
+
+	// Create a resource handler for the specific resource type
+	curResource, err := resources.NewResource(spanCtx, resource, ctx.ResourceConfig, ctx.Logger)
+	if err != nil {
+		tracing.SetError(span, err)
+		ctx.Logger.Error().Err(err).Str("resource", resource).Msg("unable to get resource handler")
+		return nil, []common.ScalerStatusFailed{{
+			Kind:   resource,
+			Name:   resource,
+			Reason: fmt.Sprintf("unable to get resource handler: %v", err),
+		}}
+	}
+
+	// Execute the scaling operation for this resource type
+	success, failed, err = curResource.SetState(spanCtx)
+	if err != nil {
+		tracing.SetError(span, err)
+		ctx.Logger.Error().Err(err).Str("resource", resource).Msg("unable to set resource state")
+		return nil, []common.ScalerStatusFailed{{
+			Kind:   resource,
+			Name:   resource,
+			Reason: fmt.Sprintf("unable to set resource state: %v", err),
+		}}
+	}
+
+	return success, failed
+}
+
 // SetNext sets the next handler in the chain.
 func (h *ScalingHandler) SetNext(next service.Handler) {
 	h.next = next
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +29,7 @@ import (
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp/service"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
 )
 
@@ -36,8 +38,8 @@ import (
 //
 // Responsibilities:
 //   - Handle finalizer cleanup if deletion is in progress
-//   - Update scaler status with scaling results
-//   - Persist status changes to Kubernetes
+//   - Update scaler status with scaling results, resource counts, conditions, history and estimated savings
+//   - Persist status changes to Kubernetes, then notify the transitions of the scaler
 //   - Set requeue behavior for next reconciliation cycle
 //
 // Error Handling:
@@ -84,9 +86,14 @@ func (h *StatusHandler) Execute(ctx *service.ReconciliationContext) error {
 	scaler.Status.CurrentPeriod.Successful = ctx.SuccessResults
 	scaler.Status.CurrentPeriod.Failed = ctx.FailedResults
 	scaler.Status.Comments = ptr.To("time period processed")
+	utils.SetReconciledConditions(&scaler.Status, scaler.Generation, scaler.Spec.Suspend)
+	now := metav1.Now()
+	utils.RecordHistory(&scaler.Status, now)
+	if ctx.Prices != nil {
+		utils.AccrueSavings(&scaler.Status, releasedRate(ctx), ctx.Prices.Currency, now)
+	}
 
-	desiredPeriod := scaler.Status.CurrentPeriod.DeepCopy()
-	desiredComments := scaler.Status.Comments
+	desiredStatus := scaler.Status.DeepCopy()
 
 	// Persist status updates to the cluster, retrying on conflict by re-fetching the latest version
 	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -94,8 +101,7 @@ func (h *StatusHandler) Execute(ctx *service.ReconciliationContext) error {
 			return err
 		}
 		// Restore desired status onto the freshly-fetched object (preserves resourceVersion).
-		scaler.Status.CurrentPeriod = desiredPeriod.DeepCopy()
-		scaler.Status.Comments = desiredComments
+		scaler.Status = *desiredStatus.DeepCopy()
 		return ctx.Client.Status().Update(ctx.Ctx, scaler)
 	}); err != nil {
 		ctx.Logger.Error().Err(err).Msg("unable to update scaler status")
@@ -103,6 +109,12 @@ func (h *StatusHandler) Execute(ctx *service.ReconciliationContext) error {
 		return service.NewRecoverableError(fmt.Errorf("update scaler status: %w", err))
 	}
 
+	// Notify the transitions of the scaler once they are persisted
+	if ctx.Notifier != nil {
+		ctx.Notifier.Notify(ctx.Ctx, scaler.Spec.Config.Notifications,
+			notify.Events(notify.KindGcp, scaler.Name, ctx.PreviousPeriod, &scaler.Status, now.Time)...)
+	}
+
 	logEvent := ctx.Logger.Info().
 		Str("name", scaler.Name).
 		Int("success", len(ctx.SuccessResults)).
@@ -124,6 +136,21 @@ func (h *StatusHandler) SetNext(next service.Handler) {
 	h.next = next
 }
 
+// releasedRate returns the hourly price of the instances kept stopped. Machine types missing from
+// the price table are left out.
+func releasedRate(ctx *service.ReconciliationContext) float64 {
+	var rate float64
+	for _, machineType := range ctx.ReleasedMachineTypes {
+		price, ok := ctx.Prices.MachineTypeRate(machineType)
+		if !ok {
+			ctx.Logger.Debug().Str("machineType", machineType).Msg("machine type missing from the price table")
+			continue
+		}
+		rate += price
+	}
+	return rate
+}
+
 // patchRemoveFinalizer removes ScalerFinalizer via an optimistic-locked merge patch, re-fetching
 // and retrying on 409 conflicts. Scoped to metadata.finalizers so neither spec nor status is
 // transmitted. No-op if the finalizer is already absent.
@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -38,6 +39,8 @@ import (
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp/service"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/gcp/service/handlers"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/pricing"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
 )
 
@@ -119,6 +122,77 @@ var _ = Describe("StatusHandler", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(reconCtx.RequeueAfter).To(Equal(utils.ReconcileSuccessDuration))
 		})
+
+		It("should persist the Ready and Degraded conditions", func() {
+			Expect(statusHandler.Execute(reconCtx)).To(Succeed())
+
+			persisted := &kubecloudscalerv1alpha3.Gcp{}
+			Expect(reconCtx.Client.Get(reconCtx.Ctx, reconCtx.Request.NamespacedName, persisted)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(persisted.Status.Conditions, common.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(persisted.Status.Conditions, common.ConditionDegraded)).To(BeTrue())
+			Expect(persisted.Status.Resources).To(Equal(&common.ScalerStatusResources{Managed: 1, Failed: 1}))
+		})
+
+		It("should notify the scaling failure to the endpoints of the scaler", func() {
+			notifier := &recordingNotifier{}
+			reconCtx.Notifier = notifier
+			reconCtx.PreviousPeriod = &common.ScalerStatusPeriod{}
+			scaler.Spec.Config.Notifications = []common.NotificationEndpoint{{Name: "pager", URL: "https://pager.example.com"}}
+
+			Expect(statusHandler.Execute(reconCtx)).To(Succeed())
+
+			Expect(notifier.endpoints).To(Equal(scaler.Spec.Config.Notifications))
+			Expect(notifier.events).To(HaveLen(1))
+			Expect(notifier.events[0].Type).To(Equal(common.NotificationScalingFailed))
+			Expect(notifier.events[0].Kind).To(Equal(notify.KindGcp))
+			Expect(notifier.events[0].Failed).To(Equal(1))
+		})
+	})
+
+	Context("When estimating savings", func() {
+		BeforeEach(func() {
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(scaler).
+				WithStatusSubresource(scaler).
+				Build()
+
+			reconCtx = &service.ReconciliationContext{
+				Ctx:     context.Background(),
+				Request: ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-scaler", Namespace: "default"}},
+				Client:  k8sClient,
+				Logger:  &logger,
+				Scaler:  scaler,
+				Prices: &pricing.Table{
+					Currency:     "USD",
+					MachineTypes: map[string]float64{"e2-standard-4": 0.15, "e2-small": 0.02},
+				},
+				ReleasedMachineTypes: []string{"e2-standard-4", "e2-standard-4", "e2-small", "n2-standard-8"},
+			}
+		})
+
+		It("should report the hourly rate of the known machine types stopped", func() {
+			Expect(statusHandler.Execute(reconCtx)).To(Succeed())
+
+			persisted := &kubecloudscalerv1alpha3.Gcp{}
+			Expect(reconCtx.Client.Get(reconCtx.Ctx, reconCtx.Request.NamespacedName, persisted)).To(Succeed())
+			Expect(persisted.Status.Savings).ToNot(BeNil())
+			Expect(persisted.Status.Savings.Currency).To(Equal("USD"))
+			Expect(persisted.Status.Savings.HourlyRate).To(Equal("0.3200"))
+		})
+
+		It("should start over when the currency changes", func() {
+			since := metav1.NewTime(time.Now().Add(-time.Hour))
+			scaler.Status.Savings = &common.ScalerStatusSavings{
+				Currency: "EUR", HourlyRate: "1.0000", Total: "5.0000", Since: since, LastUpdateTime: since,
+			}
+
+			Expect(statusHandler.Execute(reconCtx)).To(Succeed())
+
+			Expect(scaler.Status.Savings.Currency).To(Equal("USD"))
+			Expect(scaler.Status.Savings.Total).To(Equal("0.0000"))
+			Expect(scaler.Status.Savings.Since.After(since.Time)).To(BeTrue())
+		})
 	})
 
 	Context("When handling finalizer cleanup", func() {
@@ -402,3 +476,14 @@ var _ = Describe("StatusHandler", func() {
 		})
 	})
 })
+
+// recordingNotifier records the events notified by the handler.
+type recordingNotifier struct {
+	endpoints []common.NotificationEndpoint
+	events    []notify.Event
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, endpoints []common.NotificationEndpoint, events ...notify.Event) {
+	n.endpoints = endpoints
+	n.events = append(n.events, events...)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"github.com/kubecloudscaler/kubecloudscaler/internal/metrics"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
+)
+
+// tracedHandler wraps a handler in a span. Handlers call the next one from Execute, so the span
+// of a handler also covers the handlers after it.
+type tracedHandler struct {
+	name    string
+	handler Handler
+}
+
+// Traced returns handler wrapped in a span called name, which carries the scaler and the active
+// period. Spans started while handler runs are children of it.
+func Traced(name string, handler Handler) Handler {
+	return &tracedHandler{name: name, handler: handler}
+}
+
+// Execute runs the wrapped handler within the span.
+func (t *tracedHandler) Execute(ctx *ReconciliationContext) error {
+	parent := ctx.Ctx
+	spanCtx, span := tracing.Start(parent, t.name,
+		tracing.AttrController.String(metrics.ControllerGcpScaler),
+		tracing.AttrScaler.String(ctx.Request.Name),
+	)
+
+	ctx.Ctx = spanCtx
+	err := t.handler.Execute(ctx)
+	ctx.Ctx = parent
+
+	if ctx.Period != nil {
+		span.SetAttributes(
+			tracing.AttrPeriod.String(ctx.Period.Name),
+			tracing.AttrPeriodType.String(string(ctx.Period.Type)),
+		)
+	}
+	tracing.End(span, err)
+	return err
+}
+
+// SetNext sets the next handler of the wrapped handler.
+func (t *tracedHandler) SetNext(next Handler) {
+	t.handler.SetNext(next)
+}
@@ -24,17 +24,28 @@ import (
 
 	"github.com/rs/zerolog"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/config"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service/handlers"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/metrics"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/pricing"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
 )
 
+// DefaultMaxConcurrentReconciles is how many scalers are reconciled in parallel by default, so
+// that a scaler waiting for its workloads or hooks does not hold the others.
+const DefaultMaxConcurrentReconciles = 4
+
 // ScalerReconciler reconciles a Scaler object
 // It manages the lifecycle of K8s resources by scaling them up/down based on configured periods.
 //
@@ -43,9 +54,25 @@ import (
 // See: https://refactoring.guru/design-patterns/chain-of-responsibility/go/example
 type ScalerReconciler struct {
 	client.Client
-	Scheme    *runtime.Scheme
-	Logger    *zerolog.Logger
+	Scheme *runtime.Scheme
+	Logger *zerolog.Logger
+	// ScalingConcurrency bounds the resource kinds, namespaces and resources scaled in parallel
+	// for scalers that do not set one (0 uses the default).
+	ScalingConcurrency int
+	// Prices provides the price table used to estimate savings (nil disables the estimation).
+	Prices pricing.Source
+	// Notifier sends the transitions of scalers to their notification endpoints (nil disables
+	// notifications).
+	Notifier notify.Notifier
+	// ExecCommands lists the exec plugins the kubeconfigs of auth secrets may run (none when empty).
+	ExecCommands []string
+	// MaxConcurrentReconciles is how many scalers are reconciled in parallel (0 uses
+	// DefaultMaxConcurrentReconciles).
+	MaxConcurrentReconciles int
+
 	recorder  metrics.Recorder
+	events    events.EventRecorder
+	workloads *workloadWatch
 	chain     service.Handler
 	chainOnce sync.Once
 }
@@ -67,6 +94,8 @@ func NewScalerReconciler(c client.Client, scheme *runtime.Scheme, logger *zerolo
 // +kubebuilder:rbac:groups=kubecloudscaler.cloud,resources=k8s/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kubecloudscaler.cloud,resources=k8s/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -77,8 +106,9 @@ func NewScalerReconciler(c client.Client, scheme *runtime.Scheme, logger *zerolo
 // 2. FinalizerHandler: Manages finalizers for proper cleanup
 // 3. AuthHandler: Validates and processes authentication secrets
 // 4. PeriodHandler: Determines the current time period and validates it
-// 5. ScalingHandler: Scales resources according to the period configuration
-// 6. StatusHandler: Updates the status with results
+// 5. HookHandler: Runs the hooks of a transition before and after scaling
+// 6. ScalingHandler: Scales resources according to the period configuration
+// 7. StatusHandler: Updates the status with results
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.18.4/pkg/reconcile
@@ -92,12 +122,23 @@ func (r *ScalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	logger := r.Logger.With().Str("controller", "k8s").Str("name", req.Name).Logger()
 	logger.Debug().Msg("reconciling scaler")
 
+	ctx, span := tracing.Start(ctx, "k8s.Reconcile",
+		tracing.AttrController.String(metrics.ControllerK8sScaler), tracing.AttrScaler.String(req.Name))
+	defer span.End()
+
 	// Create reconciliation context with initial values
 	reconCtx := &service.ReconciliationContext{
 		Ctx:     ctx,
 		Request: req,
 		Client:  r.Client,
 		Logger:  &logger,
+		Events:  r.events,
+		// Scalers without a concurrency of their own use the operator-wide one
+		ScalingConcurrency: r.ScalingConcurrency,
+		Notifier:           r.Notifier,
+		OnDrift: func(kind string) {
+			rec.RecordDrift(metrics.ControllerK8sScaler, kind)
+		},
 	}
 
 	// Initialize chain lazily if not set (e.g., in tests without SetupWithManager)
@@ -107,9 +148,19 @@ func (r *ScalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		}
 	})
 
+	// Load the price table used to estimate savings
+	if r.Prices != nil {
+		prices, err := r.Prices.Table(ctx)
+		if err != nil {
+			logger.Warn().Err(err).Msg("unable to load the price table, savings are not estimated")
+		}
+		reconCtx.Prices = prices
+	}
+
 	// Execute the handler chain
 	err := r.chain.Execute(reconCtx)
 	duration := time.Since(start).Seconds()
+	tracing.SetError(span, err)
 
 	// Handle chain execution result
 	if err != nil {
@@ -139,6 +190,7 @@ func (r *ScalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 	metrics.RecordScalingFromResults(rec, metrics.ControllerK8sScaler,
 		toScalingResults(reconCtx.SuccessResults), toScalingResultsFailed(reconCtx.FailedResults))
+	recordScaler(rec, reconCtx.Scaler, reconCtx.ShouldFinalize)
 	rec.RecordReconcile(metrics.ControllerK8sScaler, metrics.ResultSuccess, duration)
 
 	// Successful reconciliation - use requeue from context or default
@@ -148,6 +200,38 @@ func (r *ScalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	return ctrl.Result{}, nil
 }
 
+// recordScaler reports the state and the savings estimated in the status of scaler, or forgets
+// them once the scaler is deleted.
+func recordScaler(rec metrics.Recorder, scaler *kubecloudscalerv1alpha3.K8s, deleted bool) {
+	if scaler == nil {
+		return
+	}
+	if deleted {
+		rec.ForgetScaler(metrics.ControllerK8sScaler, scaler.Name)
+		return
+	}
+
+	var state metrics.ScalerState
+	if cp := scaler.Status.CurrentPeriod; cp != nil {
+		state.PeriodType = cp.Type
+	}
+	if resources := scaler.Status.Resources; resources != nil {
+		state.Managed, state.Failed = int(resources.Managed), int(resources.Failed)
+		if state.PeriodType == string(common.PeriodTypeDown) {
+			state.ScaledDown = int(resources.Succeeded)
+		}
+	}
+	if next, ok := utils.NextTransition(scaler.Spec.Periods, scaler.Spec.Override, time.Now()); ok {
+		state.NextTransition = next
+	}
+	rec.RecordScalerState(metrics.ControllerK8sScaler, scaler.Name, state)
+
+	if savings := scaler.Status.Savings; savings != nil {
+		rec.RecordSavings(metrics.ControllerK8sScaler, scaler.Name, savings.Currency,
+			utils.ParseAmount(savings.HourlyRate), utils.ParseAmount(savings.Total))
+	}
+}
+
 func toScalingResults(s []common.ScalerStatusSuccess) []metrics.ScalingResult {
 	out := make([]metrics.ScalingResult, 0, len(s))
 	for _, r := range s {
@@ -170,21 +254,27 @@ func toScalingResultsFailed(s []common.ScalerStatusFailed) []metrics.ScalingResu
 //
 // Handler Order:
 // 1. FetchHandler → 2. FinalizerHandler → 3. AuthHandler →
-// 4. PeriodHandler → 5. ScalingHandler → 6. StatusHandler
+// 4. PeriodHandler → 5. HookHandler → 6. ScalingHandler → 7. StatusHandler
 func (r *ScalerReconciler) initializeChain() service.Handler {
 	// Create all handlers
-	fetchHandler := handlers.NewFetchHandler()
-	finalizerHandler := handlers.NewFinalizerHandler()
-	authHandler := handlers.NewAuthHandler(nil)
-	periodHandler := handlers.NewPeriodHandler()
-	scalingHandler := handlers.NewScalingHandler()
-	statusHandler := handlers.NewStatusHandler()
+	fetchHandler := service.Traced("k8s.FetchHandler", handlers.NewFetchHandler())
+	finalizerHandler := service.Traced("k8s.FinalizerHandler", handlers.NewFinalizerHandler())
+	authOpts := []handlers.AuthHandlerOption{handlers.WithExecCommands(r.ExecCommands)}
+	if r.workloads != nil {
+		authOpts = append(authOpts, handlers.WithWorkloadChangeHandler(r.workloads.notify))
+	}
+	authHandler := service.Traced("k8s.AuthHandler", handlers.NewAuthHandler(nil, authOpts...))
+	periodHandler := service.Traced("k8s.PeriodHandler", handlers.NewPeriodHandler())
+	hookHandler := service.Traced("k8s.HookHandler", handlers.NewHookHandler())
+	scalingHandler := service.Traced("k8s.ScalingHandler", handlers.NewScalingHandler())
+	statusHandler := service.Traced("k8s.StatusHandler", handlers.NewStatusHandler())
 
 	// Link handlers via SetNext() in fixed order
 	fetchHandler.SetNext(finalizerHandler)
 	finalizerHandler.SetNext(authHandler)
 	authHandler.SetNext(periodHandler)
-	periodHandler.SetNext(scalingHandler)
+	periodHandler.SetNext(hookHandler)
+	hookHandler.SetNext(scalingHandler)
 	scalingHandler.SetNext(statusHandler)
 	// statusHandler.SetNext(nil) - implicit, last handler
 
@@ -196,11 +286,25 @@ func (r *ScalerReconciler) initializeChain() service.Handler {
 // This method configures the controller to watch for K8s Scaler resources
 // and defines the reconciliation behavior.
 func (r *ScalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.workloads = newWorkloadWatch(mgr.GetCache(), config.DefaultNamespaceResolver().Resolve(), r.Logger)
 	r.chain = r.initializeChain()
+	r.events = mgr.GetEventRecorder(utils.EventRecorderName)
+
+	maxConcurrentReconciles := r.MaxConcurrentReconciles
+	if maxConcurrentReconciles <= 0 {
+		maxConcurrentReconciles = DefaultMaxConcurrentReconciles
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&kubecloudscalerv1alpha3.K8s{}).              // Watch for K8s Scaler resources
-		WithEventFilter(utils.IgnoreDeletionPredicate()). // Filter out deletion events
-		Named("k8sScaler").                               // Set controller name
-		Complete(r)                                       // Complete the controller setup
+		For(&kubecloudscalerv1alpha3.K8s{}). // Watch for K8s Scaler resources
+		WithEventFilter(predicate.Or(
+			utils.IgnoreDeletionPredicate(), // Filter out deletion events
+			utils.WakeOverridePredicate(),   // Reconcile when the activator wakes the scaler
+		)).
+		// Reconcile the scalers managing workloads created or changed in their clusters
+		WatchesRawSource(r.workloads).
+		Named("k8sScaler"). // Set controller name
+		// Readiness waits and hooks hold a worker for as long as they run
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
+		Complete(r) // Complete the controller setup
 }
@@ -24,11 +24,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/pricing"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/period"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/resources"
 )
@@ -40,9 +44,10 @@ import (
 //   - Initial: Request, Client, Logger set by controller
 //   - After Fetch: Scaler set
 //   - After Finalizer: ShouldFinalize may be set
-//   - After Auth: K8sClient, DynamicClient, Secret set
-//   - After Period: Period, ResourceConfig set
-//   - After Scaling: SuccessResults, FailedResults set
+//   - After Auth: K8sClient, DynamicClient, InformerCache, Secret, Clusters set
+//   - After Period: Period, PreviousPeriod, ResourceConfig set
+//   - After Hook: Status.Hooks updated; SkipRemaining set while a pre-hook blocks the transition
+//   - After Scaling: SuccessResults, FailedResults, ReleasedRequests set
 //   - After Status: Status updated in cluster
 //
 // Context Modification Rules:
@@ -69,7 +74,7 @@ type ReconciliationContext struct {
 
 	// K8sClient is the typed Kubernetes client for resource operations.
 	// Set by: AuthHandler
-	// Used by: PeriodHandler, ScalingHandler
+	// Used by: PeriodHandler, HookHandler, ScalingHandler
 	K8sClient kubernetes.Interface
 
 	// DynamicClient is the dynamic Kubernetes client for resource operations.
@@ -77,11 +82,48 @@ type ReconciliationContext struct {
 	// Used by: PeriodHandler, ScalingHandler
 	DynamicClient dynamic.Interface
 
+	// OnDrift records the resources brought back to the period's state after drifting from it.
+	// Set by: Controller (before chain execution; nil in tests)
+	// Used by: PeriodHandler
+	OnDrift func(kind string)
+
+	// Clusters are the clusters targeted by a scaler listing several (nil otherwise). K8sClient,
+	// DynamicClient and InformerCache then reach the first one that could be reached.
+	// Set by: AuthHandler
+	// Used by: ScalingHandler
+	Clusters []Cluster
+
+	// InformerCache serves the resource lists of the cluster reached by K8sClient.
+	// Set by: AuthHandler (nil when the chain runs without one, e.g. in tests)
+	// Used by: PeriodHandler
+	InformerCache *k8sUtils.InformerCache
+
 	// Logger is the structured logger for handler execution logging.
 	// Set by: Controller (before chain execution)
 	// Used by: All handlers
 	Logger *zerolog.Logger
 
+	// Events records Kubernetes events on the scaler and on scaled resources.
+	// Set by: Controller (before chain execution; nil in tests without a manager)
+	// Used by: PeriodHandler, ScalingHandler
+	Events events.EventRecorder
+
+	// ScalingConcurrency is the operator-wide bound on parallel scaling (0 uses the default).
+	// Set by: Controller (before chain execution)
+	// Used by: PeriodHandler
+	ScalingConcurrency int
+
+	// Prices is the price table used to estimate savings (nil disables the estimation).
+	// Set by: Controller (before chain execution)
+	// Used by: StatusHandler
+	Prices *pricing.Table
+
+	// Notifier sends the transitions of the scaler to its notification endpoints (nil disables
+	// notifications).
+	// Set by: Controller (before chain execution)
+	// Used by: StatusHandler
+	Notifier notify.Notifier
+
 	// Scaler is the K8s scaler resource being reconciled.
 	// Set by: FetchHandler
 	// Used by: All subsequent handlers
@@ -97,6 +139,11 @@ type ReconciliationContext struct {
 	// Used by: ScalingHandler, StatusHandler
 	Period *period.Period
 
+	// PreviousPeriod is the period status before the reconciliation (nil on the first one).
+	// Set by: PeriodHandler
+	// Used by: HookHandler, StatusHandler
+	PreviousPeriod *common.ScalerStatusPeriod
+
 	// ResourceConfig is the resource configuration for scaling operations.
 	// Set by: PeriodHandler
 	// Used by: ScalingHandler
@@ -112,6 +159,11 @@ type ReconciliationContext struct {
 	// Used by: StatusHandler
 	FailedResults []common.ScalerStatusFailed
 
+	// ReleasedRequests totals the resources requested by the pods removed by scaling down.
+	// Set by: ScalingHandler
+	// Used by: StatusHandler
+	ReleasedRequests corev1.ResourceList
+
 	// ShouldFinalize indicates finalizer cleanup is needed.
 	// Set by: FinalizerHandler (when deletion detected)
 	// Used by: StatusHandler
@@ -127,3 +179,20 @@ type ReconciliationContext struct {
 	// Used by: Controller (uses this value in ctrl.Result)
 	RequeueAfter time.Duration
 }
+
+// Cluster is a cluster targeted by a scaler listing several, with the clients reaching it.
+type Cluster struct {
+	// Name of the cluster, reported in status
+	Name string
+	// AuthSecret is the name of the secret reaching the cluster, empty for the operator's cluster
+	AuthSecret string
+	// Namespaces and ExcludeNamespaces override those of the scaler when set
+	Namespaces        []string
+	ExcludeNamespaces []string
+	// Clients reaching the cluster, nil when Err is set
+	K8sClient     kubernetes.Interface
+	DynamicClient dynamic.Interface
+	InformerCache *k8sUtils.InformerCache
+	// Err is why the cluster could not be reached
+	Err error
+}
@@ -21,63 +21,113 @@ package handlers
 
 import (
 	"sync"
+	"time"
 
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
+// clientIdleTimeout is how long a cached client pair may go unused before it is evicted and
+// the informers watching its cluster are stopped.
+const clientIdleTimeout = time.Hour
+
 // cacheKey identifies a cached K8s client pair. The zero value {"", ""} denotes the
 // in-cluster / default-credentials path (no secret). Namespace is included so that two
-// secrets with the same name in different namespaces never share a cache entry — today
-// the operator resolves a single namespace, but this defends against a future refactor
-// that allows per-scaler secret namespaces.
+// secrets with the same name in different namespaces, set with authSecretNamespace, never
+// share a cache entry.
 type cacheKey struct {
 	namespace string
 	name      string
 }
 
 // cachedClient pairs a typed + dynamic client with the Secret.ResourceVersion they were
-// built from. A rotation (RV change) invalidates the entry so revoked credentials cannot
-// linger past the next reconcile.
+// built from and the informer cache of the cluster they reach. A rotation (RV change)
+// invalidates the entry so revoked credentials cannot linger past the next reconcile.
 type cachedClient struct {
 	resourceVersion string
 	k8sClient       kubernetes.Interface
 	dynamicClient   dynamic.Interface
+	informers       *k8sUtils.InformerCache
+	lastUsed        time.Time
 }
 
 // k8sClientCache is a goroutine-safe cache of K8s client pairs keyed by (namespace, name).
 // All operations serialise through a single mutex; auth setup is infrequent compared to
-// the API calls downstream so per-key locking would be overkill. Unlike the GCP cache
-// there is no Close seam: kubernetes.Interface / dynamic.Interface do not expose one and
-// client-go's shared HTTP transport is torn down by GC + idle-conn timeouts.
+// the API calls downstream so per-key locking would be overkill. kubernetes.Interface /
+// dynamic.Interface expose no Close seam and client-go's shared HTTP transport is torn down
+// by GC + idle-conn timeouts, but the informers of an entry are stopped when it is replaced
+// on rotation or evicted after clientIdleTimeout without use.
+//
+// Concurrency caveat: as in the GCP cache, a reconcile still listing from an entry's
+// informers when they are stopped fails with ErrInformerCacheStopped and is retried.
 type k8sClientCache struct {
 	mu      sync.Mutex
 	entries map[cacheKey]*cachedClient
+	now     func() time.Time
+	// onChange receives the workload changes seen by the informers of every entry
+	onChange WorkloadChangeFunc
 }
 
 func newK8sClientCache() *k8sClientCache {
-	return &k8sClientCache{entries: make(map[cacheKey]*cachedClient)}
+	return &k8sClientCache{entries: make(map[cacheKey]*cachedClient), now: time.Now}
 }
 
-// GetOrBuild returns a cached client pair whose stored ResourceVersion equals rv, or calls
-// build to create one and stores it under key. Concurrent calls are serialised.
+// GetOrBuild returns a cached client pair whose stored ResourceVersion equals rv together
+// with its informer cache, or calls build to create one and stores it under key. Entries
+// left unused for clientIdleTimeout are evicted on the way. Concurrent calls are serialised.
 func (c *k8sClientCache) GetOrBuild(
 	key cacheKey,
 	rv string,
 	build func() (kube kubernetes.Interface, dyn dynamic.Interface, err error),
-) (kube kubernetes.Interface, dyn dynamic.Interface, err error) {
+) (kube kubernetes.Interface, dyn dynamic.Interface, informers *k8sUtils.InformerCache, err error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	now := c.now()
+	c.evictIdle(now)
+
 	if entry, ok := c.entries[key]; ok && entry.resourceVersion == rv {
-		return entry.k8sClient, entry.dynamicClient, nil
+		entry.lastUsed = now
+		return entry.k8sClient, entry.dynamicClient, entry.informers, nil
 	}
 
 	kube, dyn, err = build()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	c.entries[key] = &cachedClient{resourceVersion: rv, k8sClient: kube, dynamicClient: dyn}
-	return kube, dyn, nil
+	if prior, ok := c.entries[key]; ok {
+		prior.informers.Stop()
+	}
+	informers = k8sUtils.NewInformerCache(kube, dyn)
+	if onChange := c.onChange; onChange != nil {
+		informers.OnChange = func(gvr schema.GroupVersionResource, obj metaV1.Object) {
+			onChange(types.NamespacedName{Namespace: key.namespace, Name: key.name}, gvr, obj)
+		}
+	}
+	c.entries[key] = &cachedClient{
+		resourceVersion: rv,
+		k8sClient:       kube,
+		dynamicClient:   dyn,
+		informers:       informers,
+		lastUsed:        now,
+	}
+	return kube, dyn, informers, nil
+}
+
+// evictIdle stops the informers of the entries unused since clientIdleTimeout and drops them.
+// The caller must hold c.mu.
+func (c *k8sClientCache) evictIdle(now time.Time) {
+	for key, entry := range c.entries {
+		if now.Sub(entry.lastUsed) < clientIdleTimeout {
+			continue
+		}
+		entry.informers.Stop()
+		delete(c.entries, key)
+	}
 }
@@ -18,14 +18,19 @@ package handlers
 
 import (
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 
 	"github.com/kubecloudscaler/kubecloudscaler/internal/config"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 	k8sClient "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils/client"
 )
 
@@ -33,9 +38,12 @@ import (
 // Nil secret means use in-cluster / default credentials.
 type ClientFactory func(secret *corev1.Secret) (kube kubernetes.Interface, dyn dynamic.Interface, err error)
 
-// defaultClientFactory adapts the concrete k8sClient.GetClient to the ClientFactory signature.
-func defaultClientFactory(secret *corev1.Secret) (kube kubernetes.Interface, dyn dynamic.Interface, err error) {
-	return k8sClient.GetClient(secret)
+// defaultClientFactory adapts the concrete k8sClient.GetClient to the ClientFactory signature,
+// allowing the kubeconfigs of auth secrets to run the exec plugins execCommands.
+func defaultClientFactory(execCommands []string) ClientFactory {
+	return func(secret *corev1.Secret) (kube kubernetes.Interface, dyn dynamic.Interface, err error) {
+		return k8sClient.GetClient(secret, k8sClient.WithExecCommands(execCommands...))
+	}
 }
 
 // AuthHandlerOption configures an AuthHandler at construction time.
@@ -54,20 +62,51 @@ func WithClientFactory(factory ClientFactory) AuthHandlerOption {
 	}
 }
 
+// WithExecCommands allows the kubeconfigs of auth secrets to run the exec plugins listed in
+// commands. Kubeconfigs relying on other exec plugins are rejected. Ignored with WithClientFactory.
+func WithExecCommands(commands []string) AuthHandlerOption {
+	return func(h *AuthHandler) {
+		h.execCommands = commands
+	}
+}
+
+// WorkloadChangeFunc is called when a resource watched by an informer cache changes. authSecret
+// is the namespace and name of the auth secret of the cluster holding the resource, or the zero
+// value for the default credentials. It is called from the informer goroutines and should
+// return quickly.
+type WorkloadChangeFunc func(authSecret types.NamespacedName, gvr schema.GroupVersionResource, obj metaV1.Object)
+
+// WithWorkloadChangeHandler makes the informer caches of every cluster report the resources
+// they see changing to fn.
+func WithWorkloadChangeHandler(fn WorkloadChangeFunc) AuthHandlerOption {
+	return func(h *AuthHandler) {
+		h.clientCache.onChange = fn
+	}
+}
+
+// withClock overrides the clock used to evict idle cached clients. Test-only; exported
+// through export_test.go.
+func withClock(now func() time.Time) AuthHandlerOption {
+	return func(h *AuthHandler) {
+		h.clientCache.now = now
+	}
+}
+
 // AuthHandler sets up the K8s client with authentication.
 // This handler manages authentication secrets and initializes the K8s API clients.
 //
 // Responsibilities:
-//   - Fetch authentication secret if specified
+//   - Fetch authentication secret if specified, from its namespace or the operator's
 //   - Cache typed + dynamic clients keyed by (secret namespace, secret name) +
 //     ResourceVersion so a rotation invalidates stale credentials, and successive
-//     reconciliations reuse the same clients
-//   - Populate K8sClient, DynamicClient, and Secret in context
+//     reconciliations reuse the same clients and informer cache
+//   - Populate K8sClient, DynamicClient, InformerCache, Secret and Clusters in context
 type AuthHandler struct {
 	next              service.Handler
 	clientCache       *k8sClientCache
 	namespaceResolver config.NamespaceResolver
 	clientFactory     ClientFactory
+	execCommands      []string
 }
 
 // NewAuthHandler creates a new AuthHandler. If nsResolver is nil, uses config.DefaultNamespaceResolver().
@@ -79,11 +118,13 @@ func NewAuthHandler(nsResolver config.NamespaceResolver, opts ...AuthHandlerOpti
 	h := &AuthHandler{
 		namespaceResolver: nsResolver,
 		clientCache:       newK8sClientCache(),
-		clientFactory:     defaultClientFactory,
 	}
 	for _, opt := range opts {
 		opt(h)
 	}
+	if h.clientFactory == nil {
+		h.clientFactory = defaultClientFactory(h.execCommands)
+	}
 	return h
 }
 
@@ -92,45 +133,94 @@ func NewAuthHandler(nsResolver config.NamespaceResolver, opts ...AuthHandlerOpti
 // Behavior:
 //   - If AuthSecret specified: Fetches secret, creates K8s client with secret
 //   - If no AuthSecret: Creates K8s client with default credentials
-//   - On success: Sets ctx.K8sClient, ctx.DynamicClient, ctx.Secret
+//   - If Clusters specified: Creates the clients of each cluster; a cluster that cannot be
+//     reached is reported by the ScalingHandler without failing the others
+//   - On success: Sets ctx.K8sClient, ctx.DynamicClient, ctx.InformerCache, ctx.Secret, ctx.Clusters
 func (h *AuthHandler) Execute(ctx *service.ReconciliationContext) error {
+	if clusters := ctx.Scaler.Spec.Config.Clusters; len(clusters) > 0 {
+		h.setupClusters(ctx)
+		return h.callNext(ctx)
+	}
+
+	config := ctx.Scaler.Spec.Config
+	kube, dyn, informers, secret, err := h.clients(ctx, config.AuthSecret, config.AuthSecretNamespace)
+	if err != nil {
+		ctx.Logger.Error().Err(err).Msg("unable to set up the K8s client")
+		return service.NewCriticalError(err)
+	}
+
+	ctx.Secret = secret
+	ctx.Clusters = nil
+	ctx.K8sClient = kube
+	ctx.DynamicClient = dyn
+	ctx.InformerCache = informers
+
+	return h.callNext(ctx)
+}
+
+// setupClusters sets up the clients of each cluster of the scaler. The clients of the first
+// cluster reached are also those of the context.
+func (h *AuthHandler) setupClusters(ctx *service.ReconciliationContext) {
+	ctx.Secret = nil
+	ctx.K8sClient, ctx.DynamicClient, ctx.InformerCache = nil, nil, nil
+	ctx.Clusters = make([]service.Cluster, 0, len(ctx.Scaler.Spec.Config.Clusters))
+
+	for _, target := range ctx.Scaler.Spec.Config.Clusters {
+		cluster := service.Cluster{
+			Name:              target.Name,
+			AuthSecret:        ptr.Deref(target.AuthSecret, ""),
+			Namespaces:        target.Namespaces,
+			ExcludeNamespaces: target.ExcludeNamespaces,
+		}
+
+		cluster.K8sClient, cluster.DynamicClient, cluster.InformerCache, _, cluster.Err = h.clients(ctx, target.AuthSecret, target.AuthSecretNamespace)
+		if cluster.Err != nil {
+			ctx.Logger.Error().Err(cluster.Err).Str("cluster", target.Name).Msg("unable to set up the K8s client of the cluster")
+		} else if ctx.K8sClient == nil {
+			ctx.K8sClient, ctx.DynamicClient, ctx.InformerCache = cluster.K8sClient, cluster.DynamicClient, cluster.InformerCache
+		}
+
+		ctx.Clusters = append(ctx.Clusters, cluster)
+	}
+}
+
+// clients returns the cached clients of the cluster reached with the secret authSecret, or with
+// the default credentials when nil, with the secret. The secret is read in namespace, or in the
+// operator namespace when nil.
+func (h *AuthHandler) clients(
+	ctx *service.ReconciliationContext, authSecret, namespace *string,
+) (kubernetes.Interface, dynamic.Interface, *k8sUtils.InformerCache, *corev1.Secret, error) {
 	var secret *corev1.Secret
 	var key cacheKey // zero value = default in-cluster credentials
+	var secretRV string
 
-	if ctx.Scaler.Spec.Config.AuthSecret != nil {
-		// K8s CRD is cluster-scoped; the secret namespace comes from the operator resolver.
-		secretNamespace := h.namespaceResolver.Resolve()
+	if authSecret != nil {
+		// K8s CRD is cluster-scoped; the secret namespace defaults to the operator's.
+		secretNamespace := ptr.Deref(namespace, h.namespaceResolver.Resolve())
 		namespacedSecret := types.NamespacedName{
 			Namespace: secretNamespace,
-			Name:      *ctx.Scaler.Spec.Config.AuthSecret,
+			Name:      *authSecret,
 		}
 		secret = &corev1.Secret{}
 		if err := ctx.Client.Get(ctx.Ctx, namespacedSecret, secret); err != nil {
-			ctx.Logger.Error().Err(err).Msg("unable to fetch secret")
-			return service.NewCriticalError(fmt.Errorf("unable to fetch auth secret: %w", err))
+			return nil, nil, nil, nil, fmt.Errorf("unable to fetch auth secret: %w", err)
 		}
-		ctx.Secret = secret
-		key = cacheKey{namespace: secretNamespace, name: *ctx.Scaler.Spec.Config.AuthSecret}
-	} else {
-		ctx.Secret = nil
-	}
-
-	var secretRV string
-	if secret != nil {
+		key = cacheKey{namespace: secretNamespace, name: *authSecret}
 		secretRV = secret.ResourceVersion
 	}
 
-	kube, dyn, err := h.clientCache.GetOrBuild(key, secretRV, func() (kubernetes.Interface, dynamic.Interface, error) {
+	kube, dyn, informers, err := h.clientCache.GetOrBuild(key, secretRV, func() (kubernetes.Interface, dynamic.Interface, error) {
 		return h.clientFactory(secret)
 	})
 	if err != nil {
-		ctx.Logger.Error().Err(err).Msg("unable to create K8s client")
-		return service.NewCriticalError(fmt.Errorf("failed to create K8s client: %w", err))
+		return nil, nil, nil, nil, fmt.Errorf("failed to create K8s client: %w", err)
 	}
 
-	ctx.K8sClient = kube
-	ctx.DynamicClient = dyn
+	return kube, dyn, informers, secret, nil
+}
 
+// callNext calls the next handler in chain, if any.
+func (h *AuthHandler) callNext(ctx *service.ReconciliationContext) error {
 	if h.next != nil && !ctx.SkipRemaining {
 		return h.next.Execute(ctx)
 	}
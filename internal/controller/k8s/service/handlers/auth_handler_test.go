@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -27,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	dynfake "k8s.io/client-go/dynamic/fake"
@@ -40,8 +42,11 @@ import (
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service/handlers"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service/testutil"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
+var namespacesGVR = corev1.SchemeGroupVersion.WithResource("namespaces")
+
 // stubNamespaceResolver returns a fixed namespace — keeps tests independent of POD_NAMESPACE.
 type stubNamespaceResolver struct{ ns string }
 
@@ -187,6 +192,126 @@ var _ = Describe("AuthHandler", func() {
 			Expect(handler.Execute(reconCtx)).To(Succeed())
 			Expect(factory.invocations).To(HaveLen(2))
 		})
+
+		It("should share the informer cache between reconciliations", func() {
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			informers := reconCtx.InformerCache
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(informers).ToNot(BeNil())
+			Expect(reconCtx.InformerCache).To(BeIdenticalTo(informers))
+		})
+
+		It("should stop the informers of a rotated secret", func() {
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			informers := reconCtx.InformerCache
+
+			rotated := &corev1.Secret{}
+			secretKey := types.NamespacedName{Namespace: authSecret.Namespace, Name: authSecret.Name}
+			Expect(reconCtx.Client.Get(reconCtx.Ctx, secretKey, rotated)).To(Succeed())
+			rotated.Data["kubeconfig"] = []byte("rotated")
+			Expect(reconCtx.Client.Update(reconCtx.Ctx, rotated)).To(Succeed())
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(reconCtx.InformerCache).ToNot(BeIdenticalTo(informers))
+			_, err := informers.List(reconCtx.Ctx, namespacesGVR, "", metav1.ListOptions{})
+			Expect(err).To(MatchError(k8sUtils.ErrInformerCacheStopped))
+		})
+
+		It("should report workload changes with the auth secret of their cluster", func() {
+			changes := make(chan string, 1)
+			handler = handlers.NewAuthHandler(stubNamespaceResolver{ns: "default"},
+				handlers.WithClientFactory(factory.build),
+				handlers.WithWorkloadChangeHandler(func(authSecret types.NamespacedName, gvr schema.GroupVersionResource, obj metav1.Object) {
+					changes <- authSecret.String() + "/" + gvr.Resource + "/" + obj.GetName()
+				}))
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			_, err := reconCtx.InformerCache.List(reconCtx.Ctx, namespacesGVR, "", metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = factory.kubeClient.CoreV1().Namespaces().Create(reconCtx.Ctx,
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "created"}}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(changes).Should(Receive(Equal("default/k8s-secret/namespaces/created")))
+		})
+
+		It("should evict clients left unused and stop their informers", func() {
+			now := time.Now()
+			handler = handlers.NewAuthHandler(stubNamespaceResolver{ns: "default"},
+				handlers.WithClientFactory(factory.build),
+				handlers.WithClockForTest(func() time.Time { return now }))
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			informers := reconCtx.InformerCache
+
+			now = now.Add(2 * time.Hour)
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(factory.invocations).To(HaveLen(2))
+			Expect(reconCtx.InformerCache).ToNot(BeIdenticalTo(informers))
+			_, err := informers.List(reconCtx.Ctx, namespacesGVR, "", metav1.ListOptions{})
+			Expect(err).To(MatchError(k8sUtils.ErrInformerCacheStopped))
+		})
+	})
+
+	Context("When the AuthSecret is in another namespace", func() {
+		It("should fetch it from that namespace", func() {
+			scaler.Spec.Config.AuthSecret = ptr.To("k8s-secret")
+			scaler.Spec.Config.AuthSecretNamespace = ptr.To("platform")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8s-secret", Namespace: "platform", ResourceVersion: "1"},
+				Data:       map[string][]byte{"kubeconfig": []byte("fake")},
+			}
+			reconCtx.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaler, secret).Build()
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.Secret.Namespace).To(Equal("platform"))
+			Expect(factory.invocations).To(HaveLen(1))
+		})
+	})
+
+	Context("When clusters are specified", func() {
+		BeforeEach(func() {
+			scaler.Spec.Config.Clusters = []kubecloudscalerv1alpha3.K8sCluster{
+				{Name: "local"},
+				{Name: "eu", AuthSecret: ptr.To("eu"), Namespaces: []string{"production"}},
+				{Name: "us", AuthSecret: ptr.To("us")},
+			}
+			euSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "eu", Namespace: "default", ResourceVersion: "1"},
+				Data:       map[string][]byte{"kubeconfig": []byte("fake")},
+			}
+			reconCtx.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaler, euSecret).Build()
+		})
+
+		It("should build the clients of each cluster, keeping those it cannot reach for later", func() {
+			nextCalled := false
+			handler.SetNext(&testutil.MockHandler{
+				ExecuteFunc: func(ctx *service.ReconciliationContext) error {
+					nextCalled = true
+					return nil
+				},
+			})
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(nextCalled).To(BeTrue())
+			Expect(reconCtx.Secret).To(BeNil())
+			Expect(reconCtx.K8sClient).To(BeIdenticalTo(factory.kubeClient))
+			Expect(factory.invocations).To(HaveLen(2))
+			Expect(factory.invocations[0]).To(BeNil())
+			Expect(factory.invocations[1].Name).To(Equal("eu"))
+
+			Expect(reconCtx.Clusters).To(HaveLen(3))
+			Expect(reconCtx.Clusters[0].Err).NotTo(HaveOccurred())
+			Expect(reconCtx.Clusters[1].Err).NotTo(HaveOccurred())
+			Expect(reconCtx.Clusters[1].AuthSecret).To(Equal("eu"))
+			Expect(reconCtx.Clusters[1].Namespaces).To(Equal([]string{"production"}))
+			Expect(reconCtx.Clusters[1].InformerCache).NotTo(BeIdenticalTo(reconCtx.Clusters[0].InformerCache))
+			Expect(reconCtx.Clusters[2].Err).To(MatchError(ContainSubstring("unable to fetch auth secret")))
+		})
 	})
 
 	Context("When an AuthSecret is specified but does not exist", func() {
@@ -0,0 +1,27 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import "time"
+
+// Test-only exports compiled only into the test binary.
+
+// WithClockForTest exposes the unexported withClock option so tests can age cached clients
+// past the idle timeout deterministically.
+func WithClockForTest(now func() time.Time) AuthHandlerOption {
+	return withClock(now)
+}
@@ -0,0 +1,571 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
+)
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create;delete
+
+const (
+	// DefaultHookTimeout is the time allowed for a hook to complete when it sets no timeout.
+	DefaultHookTimeout = 5 * time.Minute
+	// HookPollInterval is how often running Job hooks are checked.
+	HookPollInterval = 10 * time.Second
+	// HTTPHookAttemptTimeout bounds each call of an HTTP hook, which holds the reconciliation.
+	HTTPHookAttemptTimeout = 30 * time.Second
+	// HTTPHookMaxAttempts is how many times a failing HTTP hook is called before it fails.
+	HTTPHookMaxAttempts = 5
+	// HookJobTTL is how long the Jobs of hooks are kept once finished, unless their spec sets it.
+	HookJobTTL = time.Hour
+)
+
+// Labels of the Jobs created for hooks.
+const (
+	LabelHookScaler = "kubecloudscaler.cloud/scaler"
+	LabelHook       = "kubecloudscaler.cloud/hook"
+)
+
+// hookJobNameMaxPrefix bounds the scaler and hook part of Job names, leaving room for the
+// timestamp suffix within the 63 characters of a label value.
+const hookJobNameMaxPrefix = 52
+
+// maxLabelValueLength is the maximum length of a label value.
+const maxLabelValueLength = 63
+
+// HookHandler is a handler that runs the hooks of the scaler around the scaling of a transition
+// to an up or down period.
+type HookHandler struct {
+	next   service.Handler
+	client *http.Client
+}
+
+// NewHookHandler creates a new HookHandler.
+func NewHookHandler() service.Handler {
+	return &HookHandler{client: &http.Client{}}
+}
+
+// hookRequest is the body of HTTP hook calls.
+type hookRequest struct {
+	Scaler     string    `json:"scaler"`
+	Hook       string    `json:"hook"`
+	Phase      string    `json:"phase"`
+	Period     string    `json:"period"`
+	PeriodType string    `json:"periodType"`
+	Time       time.Time `json:"time"`
+}
+
+// Execute runs the hooks of a transition to an up or down period, recording them in status.
+//
+// Behavior:
+//   - Checks the Job hooks still running
+//   - On a transition: runs the pre-hooks in order, then calls the next handlers once they all
+//     succeeded; a running pre-hook, or a failed one with the Fail policy, blocks the transition
+//     and leaves the active period of the status as it was
+//   - Once resources are scaled for a transition: starts the post-hooks
+//   - Does nothing while finalizing, suspended or in dry-run mode
+//
+// A failing HTTP hook is called again by the next reconciliations, with an exponential backoff,
+// up to HTTPHookMaxAttempts times within its timeout. A failed pre-hook is run again once the
+// spec of the scaler changes.
+func (h *HookHandler) Execute(ctx *service.ReconciliationContext) error {
+	scaler := ctx.Scaler
+	if len(scaler.Spec.Config.Hooks) == 0 || ctx.ShouldFinalize || scaler.Spec.Suspend != "" ||
+		scaler.Spec.DryRun || ctx.Period == nil {
+		return h.callNext(ctx)
+	}
+
+	now := time.Now()
+	target := ctx.Period
+	status := &scaler.Status
+
+	// The hooks of another period belong to a transition completed or abandoned
+	if status.Hooks != nil && (status.Hooks.Period != target.Name || status.Hooks.Type != string(target.Type)) {
+		status.Hooks = nil
+	}
+	h.poll(ctx, now)
+
+	transition := h.hooked(scaler, target.Type) &&
+		(ctx.PreviousPeriod == nil || ctx.PreviousPeriod.Type != string(target.Type))
+	if !transition {
+		if err := h.callNext(ctx); err != nil {
+			return err
+		}
+		h.requeueRunning(ctx)
+		return nil
+	}
+
+	if status.Hooks == nil {
+		status.Hooks = &common.ScalerStatusHooks{Period: target.Name, Type: string(target.Type)}
+	}
+	if blocking := h.runPreHooks(ctx, now); blocking != nil {
+		return h.block(ctx, blocking)
+	}
+
+	if err := h.callNext(ctx); err != nil || ctx.SkipRemaining {
+		return err
+	}
+
+	if h.runPostHooks(ctx, now) {
+		if err := patchStatus(ctx); err != nil {
+			ctx.Logger.Warn().Err(err).Msg("failed to persist the post-hooks")
+		}
+	}
+	h.requeueRunning(ctx)
+
+	return nil
+}
+
+// requeueRunning requeues the scaler within HookPollInterval while hooks are running.
+func (h *HookHandler) requeueRunning(ctx *service.ReconciliationContext) {
+	if h.running(ctx.Scaler.Status.Hooks) && (ctx.RequeueAfter == 0 || ctx.RequeueAfter > HookPollInterval) {
+		ctx.RequeueAfter = HookPollInterval
+	}
+}
+
+// SetNext establishes the next handler in the chain.
+func (h *HookHandler) SetNext(next service.Handler) {
+	h.next = next
+}
+
+// callNext calls the next handler in chain, if any.
+func (h *HookHandler) callNext(ctx *service.ReconciliationContext) error {
+	if h.next != nil && !ctx.SkipRemaining {
+		return h.next.Execute(ctx)
+	}
+	return nil
+}
+
+// hooked reports whether hooks run on transitions to periods of periodType.
+func (h *HookHandler) hooked(scaler *kubecloudscalerv1alpha3.K8s, periodType common.PeriodType) bool {
+	if periodType != common.PeriodTypeUp && periodType != common.PeriodTypeDown {
+		return false
+	}
+	for i := range scaler.Spec.Config.Hooks {
+		hook := &scaler.Spec.Config.Hooks[i]
+		if hook.Runs(kubecloudscalerv1alpha3.HookPhasePre, periodType) ||
+			hook.Runs(kubecloudscalerv1alpha3.HookPhasePost, periodType) {
+			return true
+		}
+	}
+	return false
+}
+
+// runPreHooks runs the pre-hooks of the transition in order, and returns the first one blocking
+// it, if any.
+func (h *HookHandler) runPreHooks(ctx *service.ReconciliationContext, now time.Time) *common.ScalerStatusHook {
+	scaler := ctx.Scaler
+	record := scaler.Status.Hooks
+	periodType := common.PeriodType(record.Type)
+
+	for i := range scaler.Spec.Config.Hooks {
+		hook := &scaler.Spec.Config.Hooks[i]
+		if !hook.Runs(kubecloudscalerv1alpha3.HookPhasePre, periodType) {
+			continue
+		}
+
+		index := hookIndex(record, hook.Name)
+		if index >= 0 && record.Hooks[index].State == common.HookStateFailed &&
+			record.Hooks[index].ObservedGeneration != scaler.Generation {
+			// Run again a hook failed with a previous spec
+			record.Hooks = append(record.Hooks[:index], record.Hooks[index+1:]...)
+			index = -1
+		}
+		if index < 0 {
+			record.Hooks = append(record.Hooks, h.start(ctx, hook, now))
+			index = len(record.Hooks) - 1
+		}
+
+		run := &record.Hooks[index]
+		switch {
+		case run.State == common.HookStateRunning:
+			return run
+		case run.State == common.HookStateFailed && hook.FailurePolicy != kubecloudscalerv1alpha3.HookFailurePolicyIgnore:
+			return run
+		}
+	}
+
+	return nil
+}
+
+// runPostHooks starts the post-hooks of the transition not run yet, and reports whether any was.
+func (h *HookHandler) runPostHooks(ctx *service.ReconciliationContext, now time.Time) bool {
+	scaler := ctx.Scaler
+	record := scaler.Status.Hooks
+	periodType := common.PeriodType(record.Type)
+
+	started := false
+	for i := range scaler.Spec.Config.Hooks {
+		hook := &scaler.Spec.Config.Hooks[i]
+		if !hook.Runs(kubecloudscalerv1alpha3.HookPhasePost, periodType) || hookIndex(record, hook.Name) >= 0 {
+			continue
+		}
+		record.Hooks = append(record.Hooks, h.start(ctx, hook, now))
+		started = true
+	}
+
+	return started
+}
+
+// block stops the chain until the pre-hook blocking the transition completes, keeping the
+// previous period in status so that the transition is carried on by the next reconciliations.
+func (h *HookHandler) block(ctx *service.ReconciliationContext, blocking *common.ScalerStatusHook) error {
+	scaler := ctx.Scaler
+	period := scaler.Status.Hooks.Period
+
+	comment := fmt.Sprintf("waiting for pre-hook %q before applying period %q", blocking.Name, period)
+	requeue := HookPollInterval
+	if blocking.State == common.HookStateFailed {
+		comment = fmt.Sprintf("pre-hook %q failed, period %q not applied: %s", blocking.Name, period, blocking.Message)
+		requeue = utils.ReconcileSuccessDuration
+	}
+	ctx.Logger.Info().Str("hook", blocking.Name).Str("period", period).Msg(comment)
+
+	scaler.Status.CurrentPeriod = ctx.PreviousPeriod
+	scaler.Status.Comments = ptr.To(comment)
+	if err := patchStatus(ctx); err != nil {
+		ctx.Logger.Warn().Err(err).Msg("failed to persist the pre-hooks")
+	}
+
+	ctx.SkipRemaining = true
+	if ctx.RequeueAfter == 0 || ctx.RequeueAfter > requeue {
+		ctx.RequeueAfter = requeue
+	}
+	return nil
+}
+
+// start runs hook, returning its run: completed for HTTP calls, running for Jobs unless they
+// could not be created.
+func (h *HookHandler) start(ctx *service.ReconciliationContext, hook *kubecloudscalerv1alpha3.K8sHook, now time.Time) common.ScalerStatusHook {
+	run := common.ScalerStatusHook{
+		Name:               hook.Name,
+		Phase:              string(hook.Phase),
+		State:              common.HookStateRunning,
+		ObservedGeneration: ctx.Scaler.Generation,
+		StartTime:          metav1.NewTime(now),
+	}
+	ctx.Logger.Info().Str("hook", hook.Name).Str("phase", run.Phase).Msg("running hook")
+
+	switch {
+	case hook.HTTP != nil:
+		h.attempt(ctx, hook, &run, now)
+	case hook.Job != nil:
+		job, err := h.createJob(ctx, hook, now)
+		if err != nil {
+			h.fail(ctx, &run, err.Error(), now)
+		} else {
+			run.Job = job.Namespace + "/" + job.Name
+		}
+	default:
+		h.fail(ctx, &run, "neither a job nor an http call", now)
+	}
+
+	return run
+}
+
+// attempt calls the HTTP hook of run, completing it on success. A failed call leaves run running
+// for the next reconciliations to call it again, unless it was the last attempt allowed.
+func (h *HookHandler) attempt(
+	ctx *service.ReconciliationContext, hook *kubecloudscalerv1alpha3.K8sHook, run *common.ScalerStatusHook, now time.Time,
+) {
+	run.Attempts++
+	err := h.call(ctx, hook, run.StartTime.Time)
+	if err == nil {
+		run.State, run.Message, run.CompletionTime = common.HookStateSucceeded, "", ptr.To(metav1.NewTime(time.Now()))
+		return
+	}
+
+	delay := httpHookRetryDelay(run.Attempts)
+	if run.Attempts >= HTTPHookMaxAttempts || delay > hookTimeout(hook) {
+		h.fail(ctx, run, fmt.Sprintf("giving up after attempt %d: %s", run.Attempts, err), now)
+		return
+	}
+
+	run.Message = fmt.Sprintf("attempt %d failed, retrying: %s", run.Attempts, err)
+	ctx.Logger.Info().Err(err).Str("hook", run.Name).Int32("attempts", run.Attempts).
+		Time("retry", run.StartTime.Add(delay)).Msg("hook call failed, retrying")
+}
+
+// httpHookRetryDelay returns the time after its start at which an HTTP hook is called again
+// after attempts calls, doubling the delay between two calls from HookPollInterval.
+func httpHookRetryDelay(attempts int32) time.Duration {
+	return HookPollInterval * time.Duration(1<<attempts-1)
+}
+
+// call posts the transition to the URL of an HTTP hook, expecting a 2xx status.
+func (h *HookHandler) call(ctx *service.ReconciliationContext, hook *kubecloudscalerv1alpha3.K8sHook, now time.Time) error {
+	body, err := json.Marshal(hookRequest{
+		Scaler:     ctx.Scaler.Name,
+		Hook:       hook.Name,
+		Phase:      string(hook.Phase),
+		Period:     ctx.Scaler.Status.Hooks.Period,
+		PeriodType: ctx.Scaler.Status.Hooks.Type,
+		Time:       now,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding the hook request: %w", err)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx.Ctx, min(hookTimeout(hook), HTTPHookAttemptTimeout))
+	defer cancel()
+
+	method := hook.HTTP.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(callCtx, method, hook.HTTP.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating the hook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling %s: %w", hook.HTTP.URL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s answered %s", hook.HTTP.URL, resp.Status)
+	}
+	return nil
+}
+
+// createJob creates the Job of a hook, bounded by the timeout of the hook. With several
+// clusters, the Job runs in the first cluster reached.
+func (h *HookHandler) createJob(ctx *service.ReconciliationContext, hook *kubecloudscalerv1alpha3.K8sHook, now time.Time) (*batchv1.Job, error) {
+	if ctx.K8sClient == nil {
+		return nil, fmt.Errorf("no reachable cluster to run job in %s", hook.Job.Namespace)
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hookJobName(ctx.Scaler.Name, hook.Name, now),
+			Namespace: hook.Job.Namespace,
+			Labels: map[string]string{
+				LabelHookScaler: labelValue(ctx.Scaler.Name),
+				LabelHook:       hook.Name,
+			},
+		},
+		Spec: *hook.Job.Spec.DeepCopy(),
+	}
+
+	deadline := int64(hookTimeout(hook).Seconds())
+	if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds > deadline {
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	if job.Spec.Template.Spec.RestartPolicy == "" {
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	if job.Spec.TTLSecondsAfterFinished == nil {
+		job.Spec.TTLSecondsAfterFinished = ptr.To(int32(HookJobTTL.Seconds()))
+	}
+
+	created, err := ctx.K8sClient.BatchV1().Jobs(job.Namespace).Create(ctx.Ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error creating job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	return created, nil
+}
+
+// poll updates the Job hooks of the transition still running, failing those past their timeout,
+// and calls again the HTTP hooks whose retry is due.
+func (h *HookHandler) poll(ctx *service.ReconciliationContext, now time.Time) {
+	record := ctx.Scaler.Status.Hooks
+	if record == nil {
+		return
+	}
+
+	for i := range record.Hooks {
+		run := &record.Hooks[i]
+		if run.State != common.HookStateRunning {
+			continue
+		}
+		if run.Job == "" {
+			h.retry(ctx, run, now)
+			continue
+		}
+		if ctx.K8sClient == nil {
+			continue
+		}
+
+		namespace, name, _ := strings.Cut(run.Job, "/")
+		job, err := ctx.K8sClient.BatchV1().Jobs(namespace).Get(ctx.Ctx, name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			h.fail(ctx, run, "job not found", now)
+			continue
+		case err != nil:
+			ctx.Logger.Warn().Err(err).Str("hook", run.Name).Msg("unable to get the job of the hook")
+			continue
+		}
+
+		if failed, message := jobFailed(job); failed {
+			h.fail(ctx, run, message, now)
+			continue
+		}
+		if jobComplete(job) {
+			run.State, run.CompletionTime = common.HookStateSucceeded, ptr.To(metav1.NewTime(now))
+			ctx.Logger.Info().Str("hook", run.Name).Str("job", run.Job).Msg("hook completed")
+			continue
+		}
+
+		timeout := DefaultHookTimeout
+		if hook := h.hook(ctx.Scaler, run.Name); hook != nil {
+			timeout = hookTimeout(hook)
+		}
+		if now.Sub(run.StartTime.Time) > timeout {
+			h.fail(ctx, run, fmt.Sprintf("timed out after %s", timeout), now)
+			err := ctx.K8sClient.BatchV1().Jobs(namespace).Delete(ctx.Ctx, name, metav1.DeleteOptions{
+				PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+			})
+			if err != nil && !apierrors.IsNotFound(err) {
+				ctx.Logger.Warn().Err(err).Str("job", run.Job).Msg("unable to delete the job of the hook")
+			}
+		}
+	}
+}
+
+// retry calls again the HTTP hook of run once its retry is due.
+func (h *HookHandler) retry(ctx *service.ReconciliationContext, run *common.ScalerStatusHook, now time.Time) {
+	hook := h.hook(ctx.Scaler, run.Name)
+	if hook == nil || hook.HTTP == nil {
+		h.fail(ctx, run, "hook removed from the scaler", now)
+		return
+	}
+
+	if now.Before(run.StartTime.Add(httpHookRetryDelay(run.Attempts))) {
+		return
+	}
+	ctx.Logger.Info().Str("hook", run.Name).Str("phase", run.Phase).Msg("calling hook again")
+	h.attempt(ctx, hook, run, now)
+}
+
+// fail marks run as failed, recording a HookFailed event on the scaler.
+func (h *HookHandler) fail(ctx *service.ReconciliationContext, run *common.ScalerStatusHook, message string, now time.Time) {
+	run.State, run.Message, run.CompletionTime = common.HookStateFailed, message, ptr.To(metav1.NewTime(now))
+	ctx.Logger.Warn().Str("hook", run.Name).Str("phase", run.Phase).Str("reason", message).Msg("hook failed")
+
+	blocking := false
+	if hook := h.hook(ctx.Scaler, run.Name); hook != nil {
+		blocking = hook.Phase == kubecloudscalerv1alpha3.HookPhasePre &&
+			hook.FailurePolicy != kubecloudscalerv1alpha3.HookFailurePolicyIgnore
+	}
+	utils.RecordHookFailure(utils.ScalerEvents(ctx.Events, ctx.Scaler.Spec.Config.DisableEvents),
+		ctx.Scaler, run, ctx.Scaler.Status.Hooks.Period, blocking)
+}
+
+// running reports whether hooks of record are still running.
+func (h *HookHandler) running(record *common.ScalerStatusHooks) bool {
+	if record == nil {
+		return false
+	}
+	for _, run := range record.Hooks {
+		if run.State == common.HookStateRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// hook returns the hook of the scaler named name, if any.
+func (h *HookHandler) hook(scaler *kubecloudscalerv1alpha3.K8s, name string) *kubecloudscalerv1alpha3.K8sHook {
+	for i := range scaler.Spec.Config.Hooks {
+		if scaler.Spec.Config.Hooks[i].Name == name {
+			return &scaler.Spec.Config.Hooks[i]
+		}
+	}
+	return nil
+}
+
+// hookIndex returns the index of the run of the hook named name in record, or -1.
+func hookIndex(record *common.ScalerStatusHooks, name string) int {
+	for i := range record.Hooks {
+		if record.Hooks[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// hookTimeout returns the time allowed for hook to complete.
+func hookTimeout(hook *kubecloudscalerv1alpha3.K8sHook) time.Duration {
+	if hook.Timeout != nil && hook.Timeout.Duration > 0 {
+		return hook.Timeout.Duration
+	}
+	return DefaultHookTimeout
+}
+
+// hookJobName returns the name of the Job of a hook started at now.
+func hookJobName(scaler, hook string, now time.Time) string {
+	prefix := scaler + "-" + hook
+	if len(prefix) > hookJobNameMaxPrefix {
+		prefix = strings.TrimRight(prefix[:hookJobNameMaxPrefix], "-.")
+	}
+	return fmt.Sprintf("%s-%d", prefix, now.Unix())
+}
+
+// labelValue returns value if it fits in a label value, or else its truncation followed by a
+// short SHA-256 suffix of value, so that it stays valid and tied to value.
+func labelValue(value string) string {
+	if len(value) <= maxLabelValueLength {
+		return value
+	}
+	sum := sha256.Sum256([]byte(value))
+	suffix := "-" + hex.EncodeToString(sum[:5]) // 10 hex chars
+	return value[:maxLabelValueLength-len(suffix)] + suffix
+}
+
+// jobComplete reports whether job completed successfully.
+func jobComplete(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobComplete && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// jobFailed reports whether job failed, and why.
+func jobFailed(job *batchv1.Job) (bool, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true, fmt.Sprintf("job failed: %s", condition.Message)
+		}
+	}
+	return false, ""
+}
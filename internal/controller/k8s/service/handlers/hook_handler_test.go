@@ -0,0 +1,336 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service/handlers"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service/testutil"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+)
+
+var _ = Describe("HookHandler", func() {
+	var (
+		handler   service.Handler
+		reconCtx  *service.ReconciliationContext
+		logger    zerolog.Logger
+		scaler    *kubecloudscalerv1alpha3.K8s
+		k8sClient *fake.Clientset
+		recorder  *events.FakeRecorder
+		scaled    int
+		server    *httptest.Server
+		status    atomic.Int32
+		calls     atomic.Int32
+		received  atomic.Value
+		previous  *common.ScalerStatusPeriod
+	)
+
+	// reconcile runs the handler for a transition from previous to the night down period.
+	reconcile := func() {
+		reconCtx.SkipRemaining = false
+		reconCtx.RequeueAfter = 0
+		reconCtx.PreviousPeriod = previous
+		scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "night", Type: string(common.PeriodTypeDown)}
+		Expect(handler.Execute(reconCtx)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		logger = zerolog.Nop()
+		scheme := runtime.NewScheme()
+		Expect(kubecloudscalerv1alpha3.AddToScheme(scheme)).To(Succeed())
+
+		status.Store(http.StatusOK)
+		calls.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			received.Store(body)
+			w.WriteHeader(int(status.Load()))
+		}))
+		DeferCleanup(server.Close)
+
+		scaler = &kubecloudscalerv1alpha3.K8s{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-scaler", Generation: 1},
+			Spec: kubecloudscalerv1alpha3.K8sSpec{
+				Config: kubecloudscalerv1alpha3.K8sConfig{
+					Hooks: []kubecloudscalerv1alpha3.K8sHook{{
+						Name:  "backup",
+						Phase: kubecloudscalerv1alpha3.HookPhasePre,
+						On:    []common.PeriodType{common.PeriodTypeDown},
+						HTTP:  &kubecloudscalerv1alpha3.K8sHookHTTP{URL: server.URL, Method: http.MethodPost},
+					}},
+				},
+			},
+		}
+		previous = &common.ScalerStatusPeriod{Name: "day", Type: string(common.PeriodTypeUp)}
+
+		scaled = 0
+		next := &testutil.MockHandler{ExecuteFunc: func(*service.ReconciliationContext) error {
+			scaled++
+			return nil
+		}}
+		handler = handlers.NewHookHandler()
+		handler.SetNext(next)
+
+		k8sClient = fake.NewSimpleClientset()
+		recorder = events.NewFakeRecorder(10)
+		reconCtx = &service.ReconciliationContext{
+			Ctx:     context.Background(),
+			Request: ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-scaler"}},
+			Client: fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(scaler).
+				WithStatusSubresource(scaler).Build(),
+			K8sClient: k8sClient,
+			Logger:    &logger,
+			Events:    recorder,
+			Scaler:    scaler,
+			Period:    &period.Period{Name: "night", Type: common.PeriodTypeDown},
+		}
+	})
+
+	Context("When the scaler has no hook for the transition", func() {
+		It("should scale without calling any hook", func() {
+			previous = &common.ScalerStatusPeriod{Name: "night", Type: string(common.PeriodTypeDown)}
+			reconcile()
+
+			Expect(scaled).To(Equal(1))
+			Expect(calls.Load()).To(BeZero())
+			Expect(scaler.Status.Hooks).To(BeNil())
+		})
+	})
+
+	Context("When an HTTP pre-hook is configured", func() {
+		It("should call it with the transition, then scale", func() {
+			reconcile()
+
+			Expect(calls.Load()).To(Equal(int32(1)))
+			body, ok := received.Load().(map[string]any)
+			Expect(ok).To(BeTrue())
+			Expect(body).To(HaveKeyWithValue("scaler", "test-scaler"))
+			Expect(body).To(HaveKeyWithValue("phase", "pre"))
+			Expect(body).To(HaveKeyWithValue("periodType", "down"))
+			Expect(scaled).To(Equal(1))
+			Expect(scaler.Status.Hooks.Period).To(Equal("night"))
+			Expect(scaler.Status.Hooks.Hooks).To(HaveLen(1))
+			Expect(scaler.Status.Hooks.Hooks[0].State).To(Equal(common.HookStateSucceeded))
+		})
+
+		It("should call it again with a backoff while it fails", func() {
+			status.Store(http.StatusServiceUnavailable)
+
+			reconcile()
+
+			Expect(scaled).To(BeZero())
+			Expect(calls.Load()).To(Equal(int32(1)))
+			Expect(reconCtx.RequeueAfter).To(Equal(handlers.HookPollInterval))
+			Expect(scaler.Status.Hooks.Hooks[0].State).To(Equal(common.HookStateRunning))
+			Expect(scaler.Status.Hooks.Hooks[0].Attempts).To(Equal(int32(1)))
+			Expect(scaler.Status.Hooks.Hooks[0].Message).To(ContainSubstring("attempt 1 failed, retrying"))
+
+			By("waiting for the backoff")
+			reconcile()
+			Expect(calls.Load()).To(Equal(int32(1)))
+
+			By("calling it again once due")
+			status.Store(http.StatusOK)
+			scaler.Status.Hooks.Hooks[0].StartTime = metav1.NewTime(time.Now().Add(-handlers.HookPollInterval))
+			reconcile()
+			Expect(calls.Load()).To(Equal(int32(2)))
+			Expect(scaler.Status.Hooks.Hooks[0].State).To(Equal(common.HookStateSucceeded))
+			Expect(scaler.Status.Hooks.Hooks[0].Attempts).To(Equal(int32(2)))
+			Expect(scaled).To(Equal(1))
+		})
+
+		It("should give up after the last attempt", func() {
+			status.Store(http.StatusServiceUnavailable)
+
+			reconcile()
+			for range handlers.HTTPHookMaxAttempts - 1 {
+				scaler.Status.Hooks.Hooks[0].StartTime = metav1.NewTime(time.Now().Add(-time.Hour))
+				reconcile()
+			}
+
+			Expect(calls.Load()).To(Equal(int32(handlers.HTTPHookMaxAttempts)))
+			Expect(scaler.Status.Hooks.Hooks[0].State).To(Equal(common.HookStateFailed))
+			Expect(scaler.Status.Hooks.Hooks[0].Message).To(ContainSubstring("giving up after attempt 5"))
+			Expect(scaled).To(BeZero())
+		})
+
+		It("should block the transition when it fails", func() {
+			status.Store(http.StatusInternalServerError)
+			// Too short for a second attempt
+			scaler.Spec.Config.Hooks[0].Timeout = &metav1.Duration{Duration: 5 * time.Second}
+
+			reconcile()
+
+			Expect(scaled).To(BeZero())
+			Expect(reconCtx.SkipRemaining).To(BeTrue())
+			Expect(reconCtx.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(scaler.Status.CurrentPeriod).To(Equal(previous))
+			Expect(*scaler.Status.Comments).To(ContainSubstring(`pre-hook "backup" failed`))
+			Expect(scaler.Status.Hooks.Hooks[0].State).To(Equal(common.HookStateFailed))
+			Expect(recorder.Events).To(Receive(ContainSubstring(`Warning HookFailed pre-hook "backup" failed`)))
+
+			By("keeping it blocked without calling the hook again")
+			reconcile()
+			Expect(scaled).To(BeZero())
+			Expect(calls.Load()).To(Equal(int32(1)))
+
+			By("running it again once the scaler changes")
+			status.Store(http.StatusOK)
+			scaler.Generation = 2
+			reconcile()
+			Expect(calls.Load()).To(Equal(int32(2)))
+			Expect(scaled).To(Equal(1))
+		})
+
+		It("should scale despite a failure with the Ignore policy", func() {
+			status.Store(http.StatusServiceUnavailable)
+			scaler.Spec.Config.Hooks[0].FailurePolicy = kubecloudscalerv1alpha3.HookFailurePolicyIgnore
+			scaler.Spec.Config.Hooks[0].Timeout = &metav1.Duration{Duration: 5 * time.Second}
+
+			reconcile()
+
+			Expect(scaled).To(Equal(1))
+			Expect(scaler.Status.Hooks.Hooks[0].State).To(Equal(common.HookStateFailed))
+		})
+
+		It("should not run while the scaler is suspended", func() {
+			scaler.Spec.Suspend = common.SuspendRestore
+
+			reconcile()
+
+			Expect(calls.Load()).To(BeZero())
+			Expect(scaled).To(Equal(1))
+		})
+	})
+
+	Context("When a Job pre-hook is configured", func() {
+		BeforeEach(func() {
+			scaler.Spec.Config.Hooks[0].HTTP = nil
+			scaler.Spec.Config.Hooks[0].Job = &kubecloudscalerv1alpha3.K8sHookJob{
+				Namespace: "databases",
+				Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "backup", Image: "backup:latest"}},
+				}}},
+			}
+		})
+
+		It("should block the transition until the Job completes", func() {
+			reconcile()
+
+			Expect(scaled).To(BeZero())
+			Expect(reconCtx.RequeueAfter).To(Equal(handlers.HookPollInterval))
+			jobs, err := k8sClient.BatchV1().Jobs("databases").List(context.Background(), metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs.Items).To(HaveLen(1))
+			job := &jobs.Items[0]
+			Expect(job.Labels).To(HaveKeyWithValue(handlers.LabelHook, "backup"))
+			Expect(*job.Spec.ActiveDeadlineSeconds).To(Equal(int64(handlers.DefaultHookTimeout.Seconds())))
+			Expect(job.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			Expect(*job.Spec.TTLSecondsAfterFinished).To(Equal(int32(handlers.HookJobTTL.Seconds())))
+			Expect(scaler.Status.Hooks.Hooks[0].Job).To(Equal("databases/" + job.Name))
+
+			reconcile()
+			Expect(scaled).To(BeZero())
+
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+			_, err = k8sClient.BatchV1().Jobs("databases").UpdateStatus(context.Background(), job, metav1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			reconcile()
+			Expect(scaled).To(Equal(1))
+			Expect(scaler.Status.Hooks.Hooks[0].State).To(Equal(common.HookStateSucceeded))
+		})
+
+		It("should fail the hook and delete the Job once timed out", func() {
+			scaler.Spec.Config.Hooks[0].Timeout = &metav1.Duration{Duration: time.Minute}
+			reconcile()
+			scaler.Status.Hooks.Hooks[0].StartTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+
+			reconcile()
+
+			Expect(scaled).To(BeZero())
+			Expect(scaler.Status.Hooks.Hooks[0].State).To(Equal(common.HookStateFailed))
+			Expect(scaler.Status.Hooks.Hooks[0].Message).To(Equal("timed out after 1m0s"))
+			jobs, err := k8sClient.BatchV1().Jobs("databases").List(context.Background(), metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs.Items).To(BeEmpty())
+		})
+
+		It("should keep the scaler label of the Job within the limits of label values", func() {
+			scaler.Name = strings.Repeat("preview-environment-", 5)
+
+			reconcile()
+
+			jobs, err := k8sClient.BatchV1().Jobs("databases").List(context.Background(), metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs.Items).To(HaveLen(1))
+			value := jobs.Items[0].Labels[handlers.LabelHookScaler]
+			Expect(value).To(HaveLen(63))
+			Expect(value).To(HavePrefix("preview-environment-"))
+			Expect(validation.IsValidLabelValue(value)).To(BeEmpty())
+		})
+	})
+
+	Context("When an HTTP post-hook is configured", func() {
+		BeforeEach(func() {
+			scaler.Spec.Config.Hooks[0].Phase = kubecloudscalerv1alpha3.HookPhasePost
+		})
+
+		It("should call it once resources are scaled, only on the transition", func() {
+			reconcile()
+
+			Expect(scaled).To(Equal(1))
+			Expect(calls.Load()).To(Equal(int32(1)))
+			body, ok := received.Load().(map[string]any)
+			Expect(ok).To(BeTrue())
+			Expect(body).To(HaveKeyWithValue("phase", "post"))
+
+			previous = &common.ScalerStatusPeriod{Name: "night", Type: string(common.PeriodTypeDown)}
+			reconcile()
+
+			Expect(scaled).To(Equal(2))
+			Expect(calls.Load()).To(Equal(int32(1)))
+			Expect(scaler.Status.Hooks.Hooks).To(HaveLen(1))
+		})
+	})
+})
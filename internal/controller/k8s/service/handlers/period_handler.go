@@ -20,13 +20,10 @@ import (
 	"errors"
 	"time"
 
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/client-go/util/retry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
-	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
 	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
@@ -41,6 +38,9 @@ const RequeueDelaySeconds = 5
 // (e.g. a run-once period that has already ended). Prevents hot-looping on immediate requeue.
 const MinRequeueAfter = 30 * time.Second
 
+// snoozeResource is the kubectl resource type of K8s scalers, used in snooze commands.
+const snoozeResource = "k8s"
+
 // PeriodHandler is a handler that validates and determines the current time period for scaling operations.
 type PeriodHandler struct {
 	next service.Handler
@@ -54,24 +54,48 @@ func NewPeriodHandler() service.Handler {
 // Execute validates and determines the current time period and adds it to the reconciliation context.
 //
 // Behavior:
+//   - If suspended in freeze mode (and not finalizing): Stops chain, leaving resources and period as is
 //   - Configures resource management settings
+//   - Records a PeriodChanged event when the active period differs from the last observed one
 //   - Validates time periods and determines current period
 //   - If run-once period (and not finalizing): Sets RequeueAfter, stops chain
-//   - If "noaction" period matches current status (and not finalizing): Sets SkipRemaining, stops chain
+//   - If "noaction" period matches current status (and not finalizing, nor re-checking readiness
+//     failures): Sets SkipRemaining, stops chain
+//   - If suspended in restore mode: Restores resources like RestoreOnDelete, then skips as "noaction"
+//   - If a manual override is active (and not finalizing nor suspended): Applies it instead of the
+//     periods until it expires, then removes it from the scaler
+//   - If woken up by the activator (and not finalizing, suspended nor overridden): Applies an "up"
+//     override until the wake annotation expires
 //   - During deletion (ShouldFinalize), never skips: StatusHandler must run to remove the finalizer
 func (h *PeriodHandler) Execute(ctx *service.ReconciliationContext) error {
+	if !ctx.ShouldFinalize && ctx.Scaler.Spec.Suspend == common.SuspendFreeze {
+		ctx.Logger.Debug().Msg("scaler suspended, leaving resources in their current state")
+		if ctx.Scaler.Status.ObservedGeneration != ctx.Scaler.Generation {
+			utils.SetReconciledConditions(&ctx.Scaler.Status, ctx.Scaler.Generation, ctx.Scaler.Spec.Suspend)
+			if err := patchStatus(ctx); err != nil {
+				ctx.Logger.Warn().Err(err).Msg("failed to persist the suspended status")
+			}
+		}
+		ctx.SkipRemaining = true
+		return nil
+	}
+
 	h.configureResourceSettings(ctx)
+	utils.WarnScaleDown(scalerReconciliation(ctx))
 
-	prevPeriodType := previousPeriodType(ctx.Scaler.Status.CurrentPeriod)
+	prevPeriod := ctx.Scaler.Status.CurrentPeriod
 
 	period, err := h.resolveActivePeriod(ctx)
 	if err != nil {
 		return err
 	}
 	ctx.Period = period
+	ctx.PreviousPeriod = prevPeriod
 	ctx.ResourceConfig.K8s.Period = period
 
-	if h.shouldSkipNoaction(ctx, prevPeriodType) {
+	utils.RecordPeriodChange(utils.ScalerEvents(ctx.Events, ctx.Scaler.Spec.Config.DisableEvents), ctx.Scaler, prevPeriod, period)
+
+	if h.shouldSkipNoaction(ctx, prevPeriod) {
 		ctx.Logger.Debug().Str("period", periodPkg.NoactionPeriodName).Msg("no action period, skipping")
 		ctx.SkipRemaining = true
 		if ctx.RequeueAfter == 0 {
@@ -89,6 +113,13 @@ func (h *PeriodHandler) Execute(ctx *service.ReconciliationContext) error {
 }
 
 func (h *PeriodHandler) configureResourceSettings(ctx *service.ReconciliationContext) {
+	// Events on scaled resources go through the manager's recorder, which writes to the local
+	// cluster: skip them when the scaler targets a remote cluster.
+	recorder := utils.ScalerEvents(ctx.Events, ctx.Scaler.Spec.Config.DisableEvents)
+	if ctx.Scaler.Spec.Config.AuthSecret != nil {
+		recorder = nil
+	}
+
 	ctx.ResourceConfig = resources.Config{
 		K8s: &k8sUtils.Config{
 			Client:                       ctx.K8sClient,
@@ -96,12 +127,52 @@ func (h *PeriodHandler) configureResourceSettings(ctx *service.ReconciliationCon
 			Names:                        ctx.Scaler.Spec.Resources.Names,
 			Namespaces:                   ctx.Scaler.Spec.Config.Namespaces,
 			ExcludeNamespaces:            ctx.Scaler.Spec.Config.ExcludeNamespaces,
+			NamespaceSelector:            ctx.Scaler.Spec.Config.NamespaceSelector,
 			LabelSelector:                ctx.Scaler.Spec.Resources.LabelSelector,
 			ForceExcludeSystemNamespaces: ctx.Scaler.Spec.Config.ForceExcludeSystemNamespaces,
+			SleepingService:              ctx.Scaler.Spec.Config.SleepingService,
+			DryRun:                       ctx.Scaler.Spec.DryRun,
+			DeploymentTimeAnnotation:     ctx.Scaler.Spec.Config.DeploymentTimeAnnotation,
+			DeploymentGracePeriod:        deploymentGracePeriod(ctx.Scaler.Spec.Config.DeploymentGracePeriod),
+			Recorder:                     recorder,
+			Scaler:                       ctx.Scaler,
+			Concurrency:                  scalingConcurrency(ctx),
+			Cache:                        ctx.InformerCache,
+			OnDrift:                      ctx.OnDrift,
+			ReadinessTimeout:             readinessTimeout(ctx.Scaler.Spec.Config.ReadinessTimeout),
 		},
 	}
 }
 
+// deploymentGracePeriod returns the configured grace period, or zero to use the default.
+func deploymentGracePeriod(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
+
+// readinessTimeout returns the configured readiness timeout, at most MaxReadinessTimeout, or zero
+// to skip verification.
+func readinessTimeout(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return min(d.Duration, k8sUtils.MaxReadinessTimeout)
+}
+
+// scalingConcurrency returns the concurrency set on the scaler, or else the operator-wide one,
+// or else the default.
+func scalingConcurrency(ctx *service.ReconciliationContext) int {
+	if c := ctx.Scaler.Spec.Config.Concurrency; c != nil {
+		return int(*c)
+	}
+	if ctx.ScalingConcurrency > 0 {
+		return ctx.ScalingConcurrency
+	}
+	return k8sUtils.DefaultConcurrency
+}
+
 // previousPeriodType returns the Type of the last observed period. Using Type (not Name)
 // avoids false matches when a user creates a custom period literally named "noaction".
 func previousPeriodType(cp *common.ScalerStatusPeriod) string {
@@ -119,11 +190,17 @@ func (h *PeriodHandler) resolveActivePeriod(ctx *service.ReconciliationContext)
 		periods[i] = &ctx.Scaler.Spec.Periods[i]
 	}
 
+	override := utils.ApplyOverride(scalerReconciliation(ctx))
+	if override == nil {
+		override = h.applyWakeOverride(ctx)
+	}
 	period, err := utils.SetActivePeriod(
 		ctx.Logger,
 		periods,
 		&ctx.Scaler.Status,
-		ctx.Scaler.Spec.Config.RestoreOnDelete && ctx.ShouldFinalize,
+		override,
+		(ctx.Scaler.Spec.Config.RestoreOnDelete && ctx.ShouldFinalize) ||
+			ctx.Scaler.Spec.Suspend == common.SuspendRestore,
 	)
 	if err != nil {
 		if errors.Is(err, utils.ErrRunOncePeriod) && !ctx.ShouldFinalize {
@@ -141,50 +218,66 @@ func (h *PeriodHandler) resolveActivePeriod(ctx *service.ReconciliationContext)
 		}
 
 		ctx.Logger.Error().Err(err).Msg("unable to validate period")
-		comments := ptr.To(err.Error())
-		ctx.Scaler.Status.Comments = comments
-		// Best-effort persist of Comments so the user sees why reconciliation failed. A
-		// CriticalError stops the chain before StatusHandler runs, so without this the
+		ctx.Scaler.Status.Comments = ptr.To(err.Error())
+		utils.SetInvalidPeriodConditions(&ctx.Scaler.Status, ctx.Scaler.Generation, err)
+		// Best-effort persist of Comments and conditions so the user sees why reconciliation
+		// failed. A CriticalError stops the chain before StatusHandler runs, so without this the
 		// in-memory mutation would never reach the cluster. Patch failure is only logged —
 		// the original validation error is still surfaced to the controller.
-		if patchErr := patchStatusComments(ctx, comments); patchErr != nil {
-			ctx.Logger.Warn().Err(patchErr).Msg("failed to persist status.comments")
+		if patchErr := patchStatus(ctx); patchErr != nil {
+			ctx.Logger.Warn().Err(patchErr).Msg("failed to persist status")
 		}
 		return nil, service.NewCriticalError(err)
 	}
 	return period, nil
 }
 
-// patchStatusComments persists only status.comments via a status-subresource patch with
-// optimistic locking + retry on conflict. Scoped tightly so spec is never transmitted.
-// NotFound from the inner Get is treated as a no-op (the scaler was deleted between
-// FetchHandler and here; there is nothing to patch).
-func patchStatusComments(ctx *service.ReconciliationContext, comments *string) error {
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		latest := &kubecloudscalerv1alpha3.K8s{}
-		if err := ctx.Client.Get(ctx.Ctx, ctx.Request.NamespacedName, latest); err != nil {
-			return err
-		}
-		patch := client.MergeFromWithOptions(latest.DeepCopy(), client.MergeFromWithOptimisticLock{})
-		latest.Status.Comments = comments
-		return ctx.Client.Status().Patch(ctx.Ctx, latest, patch)
-	})
-	if apierrors.IsNotFound(err) {
+// applyWakeOverride returns the "up" override in effect while the activator keeps the scaler
+// awake, applied like a manual one. The scaler is requeued for the moment it expires so it drops
+// back to its scheduled period.
+func (h *PeriodHandler) applyWakeOverride(ctx *service.ReconciliationContext) *common.ScalerStatusOverride {
+	if ctx.ShouldFinalize || ctx.Scaler.Spec.Suspend != "" {
 		return nil
 	}
-	return err
+
+	now := time.Now()
+	until, awake := utils.WakeUntil(ctx.Scaler, now)
+	if !awake {
+		return nil
+	}
+
+	ctx.Logger.Info().Time("until", until).Msg("scaler woken up by the activator")
+	if ctx.RequeueAfter == 0 {
+		ctx.RequeueAfter = until.Sub(now)
+	}
+
+	return utils.ActiveOverride(utils.WakeOverride(ctx.Scaler.Spec.Periods, until), ctx.Scaler.Status.CurrentPeriod, now)
 }
 
 // shouldSkipNoaction returns true when we can safely skip the rest of the chain
 // because the period is still "noaction" (steady state). During deletion this
 // must always return false so that StatusHandler can remove the finalizer.
 // Comparison is on Type rather than Name so a user-defined period literally named
-// "noaction" is not mistaken for the system fallback.
-func (h *PeriodHandler) shouldSkipNoaction(ctx *service.ReconciliationContext, prevPeriodType string) bool {
+// "noaction" is not mistaken for the system fallback. It does not skip either while
+// readiness is verified and the last reconciliation reported failures, nor when the spec
+// changed or a restore override starts or ends, so the status reflects it.
+func (h *PeriodHandler) shouldSkipNoaction(ctx *service.ReconciliationContext, prevPeriod *common.ScalerStatusPeriod) bool {
 	if ctx.ShouldFinalize {
 		return false
 	}
-	return prevPeriodType == periodPkg.NoactionPeriodName && string(ctx.Period.Type) == periodPkg.NoactionPeriodName
+	// Keep re-checking the restored workloads reported as not ready
+	if ctx.Scaler.Spec.Config.ReadinessTimeout != nil && prevPeriod != nil && len(prevPeriod.Failed) > 0 {
+		return false
+	}
+	// Persist the status of a spec changed in the meantime
+	if ctx.Scaler.Status.ObservedGeneration != ctx.Scaler.Generation {
+		return false
+	}
+	// Persist the status when a restore override starts or ends
+	if (prevPeriod != nil && prevPeriod.Override != nil) != (ctx.Scaler.Status.CurrentPeriod.Override != nil) {
+		return false
+	}
+	return previousPeriodType(prevPeriod) == periodPkg.NoactionPeriodName && string(ctx.Period.Type) == periodPkg.NoactionPeriodName
 }
 
 // SetNext establishes the next handler in the chain.
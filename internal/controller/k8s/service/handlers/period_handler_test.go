@@ -18,14 +18,17 @@ package handlers_test
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -35,6 +38,8 @@ import (
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service/handlers"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service/testutil"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
 var _ = Describe("PeriodHandler", func() {
@@ -93,6 +98,17 @@ var _ = Describe("PeriodHandler", func() {
 	})
 
 	Context("When an always-active period is configured", func() {
+		It("should keep the time at which the period became active", func() {
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			since := scaler.Status.CurrentPeriod.LastTransitionTime
+			Expect(since).ToNot(BeNil())
+			*since = metav1.NewTime(since.Add(-time.Hour))
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(scaler.Status.CurrentPeriod.LastTransitionTime).To(Equal(since))
+		})
+
 		It("should resolve the period and chain to the next handler", func() {
 			nextCalled := false
 			handler.SetNext(&testutil.MockHandler{
@@ -169,6 +185,36 @@ var _ = Describe("PeriodHandler", func() {
 			Expect(reconCtx.SkipRemaining).To(BeFalse())
 			Expect(nextCalled).To(BeTrue())
 		})
+
+		It("should NOT skip remaining when the spec changed since the last reconciliation", func() {
+			scaler.Generation = 2
+			scaler.Status.ObservedGeneration = 1
+
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconCtx.SkipRemaining).To(BeFalse())
+		})
+
+		It("should re-check restored workloads reported as not ready", func() {
+			scaler.Spec.Config.ReadinessTimeout = &metav1.Duration{Duration: time.Minute}
+			scaler.Status.CurrentPeriod.Failed = []common.ScalerStatusFailed{
+				{Kind: "deployment", Name: "web", Reason: "not ready: 0/1 replicas ready"},
+			}
+
+			nextCalled := false
+			handler.SetNext(&testutil.MockHandler{
+				ExecuteFunc: func(ctx *service.ReconciliationContext) error {
+					nextCalled = true
+					return nil
+				},
+			})
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(reconCtx.SkipRemaining).To(BeFalse())
+			Expect(nextCalled).To(BeTrue())
+			Expect(reconCtx.ResourceConfig.K8s.ReadinessTimeout).To(Equal(time.Minute))
+		})
 	})
 
 	Context("When period validation fails (invalid period spec)", func() {
@@ -204,6 +250,9 @@ var _ = Describe("PeriodHandler", func() {
 			Expect(persisted.Status.Comments).ToNot(BeNil(),
 				"status.comments must be persisted before CriticalError short-circuits the chain")
 			Expect(*persisted.Status.Comments).ToNot(BeEmpty())
+			Expect(meta.IsStatusConditionFalse(persisted.Status.Conditions, common.ConditionReady)).To(BeTrue())
+			Expect(meta.FindStatusCondition(persisted.Status.Conditions, common.ConditionDegraded).Reason).
+				To(Equal(utils.ReasonInvalidPeriod))
 		})
 	})
 
@@ -245,4 +294,346 @@ var _ = Describe("PeriodHandler", func() {
 			Expect(nextCalled).To(BeTrue())
 		})
 	})
+
+	Context("When the activator woke the scaler up", func() {
+		BeforeEach(func() {
+			scaler.Spec.Periods[0].Type = common.PeriodTypeDown
+		})
+
+		It("should scale resources up and requeue when the override expires", func() {
+			until := time.Now().Add(30 * time.Minute)
+			scaler.Annotations = map[string]string{utils.WakeAnnotation: until.Format(time.RFC3339)}
+
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconCtx.Period.Name).To(Equal(utils.OverridePeriodName))
+			Expect(reconCtx.Period.Type).To(Equal(common.PeriodTypeUp))
+			Expect(reconCtx.RequeueAfter).To(BeNumerically("~", time.Until(until), time.Minute))
+			Expect(scaler.Status.CurrentPeriod.Override).ToNot(BeNil())
+			Expect(scaler.Status.CurrentPeriod.Override.Reason).To(Equal(utils.WakeReason))
+		})
+
+		It("should apply the replicas of the first up period", func() {
+			scaler.Spec.Periods = append(scaler.Spec.Periods, common.ScalerPeriod{
+				Type:        common.PeriodTypeUp,
+				Name:        "business-hours",
+				Time:        scaler.Spec.Periods[0].Time,
+				MinReplicas: ptr.To(int32(2)),
+				MaxReplicas: ptr.To(int32(5)),
+			})
+			scaler.Annotations = map[string]string{
+				utils.WakeAnnotation: time.Now().Add(30 * time.Minute).Format(time.RFC3339),
+			}
+
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconCtx.Period.Type).To(Equal(common.PeriodTypeUp))
+			Expect(reconCtx.Period.MinReplicas).To(Equal(int32(2)))
+			Expect(reconCtx.Period.MaxReplicas).To(Equal(int32(5)))
+		})
+
+		It("should ignore an expired override", func() {
+			scaler.Annotations = map[string]string{
+				utils.WakeAnnotation: time.Now().Add(-time.Minute).Format(time.RFC3339),
+			}
+
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconCtx.Period.Type).To(Equal(common.PeriodTypeDown))
+			Expect(reconCtx.RequeueAfter).To(BeZero())
+		})
+	})
+
+	Context("When a manual override is set", func() {
+		var until time.Time
+
+		BeforeEach(func() {
+			until = time.Now().Add(30 * time.Minute).Truncate(time.Second)
+			scaler.Spec.Override = &common.ScalerOverride{
+				Type:        common.OverrideTypeDown,
+				Until:       metav1.NewTime(until),
+				MinReplicas: ptr.To(int32(0)),
+				Reason:      "INC-42",
+			}
+		})
+
+		It("should apply it over the periods and requeue when it expires", func() {
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconCtx.Period.Name).To(Equal(utils.OverridePeriodName))
+			Expect(reconCtx.Period.Type).To(Equal(common.PeriodTypeDown))
+			Expect(reconCtx.Period.MinReplicas).To(Equal(int32(0)))
+			Expect(reconCtx.RequeueAfter).To(BeNumerically("~", time.Until(until), time.Minute))
+
+			override := scaler.Status.CurrentPeriod.Override
+			Expect(override).ToNot(BeNil())
+			Expect(override.Reason).To(Equal("INC-42"))
+			Expect(override.Since.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("should keep the same start while unchanged", func() {
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			first := scaler.Status.CurrentPeriod.DeepCopy()
+			first.Override.Since = metav1.NewTime(first.Override.Since.Add(-time.Hour))
+			scaler.Status.CurrentPeriod = first
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(scaler.Status.CurrentPeriod.Override.Since).To(Equal(first.Override.Since))
+			Expect(reconCtx.Period.StartTime).To(BeTemporally("==", first.Override.Since.Time))
+		})
+
+		It("should take precedence over the activator", func() {
+			scaler.Annotations = map[string]string{
+				utils.WakeAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+			}
+
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconCtx.Period.Type).To(Equal(common.PeriodTypeDown))
+			Expect(reconCtx.RequeueAfter).To(BeNumerically("~", time.Until(until), time.Minute))
+		})
+
+		It("should restore resources for a restore override", func() {
+			scaler.Spec.Override = &common.ScalerOverride{Type: common.OverrideTypeRestore, Until: metav1.NewTime(until)}
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "noaction", Type: "noaction"}
+
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(reconCtx.Period.Type)).To(Equal("noaction"))
+			Expect(reconCtx.SkipRemaining).To(BeFalse(), "the status must show the override")
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(reconCtx.SkipRemaining).To(BeTrue())
+		})
+
+		It("should remove it from the scaler once expired", func() {
+			scaler.Spec.Override.Until = metav1.NewTime(time.Now().Add(-time.Minute))
+			reconCtx.Client = fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(scaler).Build()
+
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconCtx.Period.Name).To(Equal("test-period"))
+			Expect(scaler.Status.CurrentPeriod.Override).To(BeNil())
+
+			persisted := &kubecloudscalerv1alpha3.K8s{}
+			Expect(reconCtx.Client.Get(reconCtx.Ctx, reconCtx.Request.NamespacedName, persisted)).To(Succeed())
+			Expect(persisted.Spec.Override).To(BeNil())
+		})
+	})
+
+	Context("When the scaler is suspended", func() {
+		var nextCalled bool
+
+		BeforeEach(func() {
+			nextCalled = false
+			handler.SetNext(&testutil.MockHandler{
+				ExecuteFunc: func(ctx *service.ReconciliationContext) error {
+					nextCalled = true
+					return nil
+				},
+			})
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "test-period", Type: "up", SpecSHA: "run-once-sha"}
+		})
+
+		It("should leave resources and period untouched in freeze mode", func() {
+			scaler.Spec.Suspend = common.SuspendFreeze
+
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reconCtx.SkipRemaining).To(BeTrue())
+			Expect(nextCalled).To(BeFalse())
+			Expect(scaler.Status.CurrentPeriod.SpecSHA).To(Equal("run-once-sha"))
+		})
+
+		It("should report the scaler as suspended in freeze mode", func() {
+			scaler.Generation = 2
+			scaler.Spec.Suspend = common.SuspendFreeze
+			reconCtx.Client = fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(scaler).WithStatusSubresource(scaler).Build()
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			persisted := &kubecloudscalerv1alpha3.K8s{}
+			Expect(reconCtx.Client.Get(reconCtx.Ctx, reconCtx.Request.NamespacedName, persisted)).To(Succeed())
+			Expect(persisted.Status.ObservedGeneration).To(Equal(int64(2)))
+			Expect(meta.IsStatusConditionTrue(persisted.Status.Conditions, common.ConditionSuspended)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(persisted.Status.Conditions, common.ConditionScaling)).To(BeTrue())
+			Expect(persisted.Status.CurrentPeriod.SpecSHA).To(Equal("run-once-sha"))
+		})
+
+		It("should still finalize a frozen scaler being deleted", func() {
+			scaler.Spec.Suspend = common.SuspendFreeze
+			reconCtx.ShouldFinalize = true
+
+			err := handler.Execute(reconCtx)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextCalled).To(BeTrue())
+		})
+
+		It("should restore resources once, then stop in restore mode", func() {
+			scaler.Spec.Suspend = common.SuspendRestore
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(string(reconCtx.Period.Type)).To(Equal("noaction"))
+			Expect(nextCalled).To(BeTrue())
+
+			nextCalled = false
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(reconCtx.SkipRemaining).To(BeTrue())
+			Expect(nextCalled).To(BeFalse())
+		})
+
+		It("should ignore a manual override", func() {
+			scaler.Spec.Suspend = common.SuspendRestore
+			scaler.Spec.Override = &common.ScalerOverride{
+				Type:  common.OverrideTypeDown,
+				Until: metav1.NewTime(time.Now().Add(time.Hour)),
+			}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(string(reconCtx.Period.Type)).To(Equal("noaction"))
+			Expect(scaler.Status.CurrentPeriod.Override).To(BeNil())
+		})
+	})
+
+	Context("When events are enabled", func() {
+		var recorder *events.FakeRecorder
+
+		BeforeEach(func() {
+			recorder = events.NewFakeRecorder(10)
+			reconCtx.Events = recorder
+		})
+
+		It("should record a PeriodChanged event on transition", func() {
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "noaction", Type: "noaction"}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(recorder.Events).To(Receive(Equal(
+				`Normal PeriodChanged Period changed from "noaction" (noaction) to "test-period" (up)`)))
+			Expect(reconCtx.ResourceConfig.K8s.Recorder).To(Equal(recorder))
+			Expect(reconCtx.PreviousPeriod.Name).To(Equal("noaction"))
+		})
+
+		It("should not record an event when the period is unchanged", func() {
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "test-period", Type: "up"}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(recorder.Events).ToNot(Receive())
+		})
+
+		It("should not record events when disableEvents is set", func() {
+			scaler.Spec.Config.DisableEvents = true
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(recorder.Events).ToNot(Receive())
+			Expect(reconCtx.ResourceConfig.K8s.Recorder).To(BeNil())
+		})
+
+		It("should not record events on resources of a remote cluster", func() {
+			scaler.Spec.Config.AuthSecret = ptr.To("remote-kubeconfig")
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.ResourceConfig.K8s.Recorder).To(BeNil())
+		})
+	})
+
+	Context("When a down period with warnings is about to start", func() {
+		var (
+			recorder *events.FakeRecorder
+			notifier *recordingNotifier
+			start    time.Time
+		)
+
+		BeforeEach(func() {
+			recorder = events.NewFakeRecorder(10)
+			notifier = &recordingNotifier{}
+			reconCtx.Events = recorder
+			reconCtx.Notifier = notifier
+
+			start = time.Now().UTC().Add(10 * time.Minute).Truncate(time.Second)
+			scaler.Spec.Periods = append([]common.ScalerPeriod{{
+				Name: "maintenance",
+				Type: common.PeriodTypeDown,
+				Time: common.TimePeriod{
+					Fixed: &common.FixedPeriod{
+						StartTime: start.Format(time.DateTime),
+						EndTime:   start.Add(time.Hour).Format(time.DateTime),
+						Timezone:  ptr.To("UTC"),
+					},
+				},
+				Warnings: []metav1.Duration{{Duration: 30 * time.Minute}, {Duration: 5 * time.Minute}},
+			}}, scaler.Spec.Periods...)
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{
+				Name:       "test-period",
+				Type:       "up",
+				Successful: []common.ScalerStatusSuccess{{Kind: "deployment", Name: "api"}},
+			}
+		})
+
+		It("should warn once with an event and a notification", func() {
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring("Normal ScaleDownWarning")))
+			Expect(notifier.events).To(HaveLen(1))
+			Expect(notifier.events[0].Type).To(Equal(common.NotificationScaleDownWarning))
+			Expect(notifier.events[0].Resources).To(Equal([]string{"deployment api"}))
+			Expect(notifier.events[0].Snooze).To(ContainSubstring("kubectl patch k8s test-scaler"))
+			Expect(scaler.Status.LastWarning).ToNot(BeNil())
+			Expect(scaler.Status.LastWarning.Period).To(Equal("maintenance"))
+			Expect(scaler.Status.LastWarning.ScaleDownTime.Time).To(BeTemporally("==", start))
+			Expect(scaler.Status.LastWarning.Before.Duration).To(Equal(30 * time.Minute))
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(recorder.Events).ToNot(Receive(ContainSubstring("ScaleDownWarning")))
+			Expect(notifier.events).To(HaveLen(1))
+		})
+
+		It("should not warn while the scaler is suspended", func() {
+			scaler.Spec.Suspend = common.SuspendRestore
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(notifier.events).To(BeEmpty())
+			Expect(scaler.Status.LastWarning).To(BeNil())
+		})
+	})
+
+	Context("When resolving the scaling concurrency", func() {
+		It("should use the default when nothing is configured", func() {
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.ResourceConfig.K8s.Concurrency).To(Equal(k8sUtils.DefaultConcurrency))
+		})
+
+		It("should use the operator-wide concurrency", func() {
+			reconCtx.ScalingConcurrency = 10
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.ResourceConfig.K8s.Concurrency).To(Equal(10))
+		})
+
+		It("should prefer the concurrency set on the scaler", func() {
+			reconCtx.ScalingConcurrency = 10
+			scaler.Spec.Config.Concurrency = ptr.To(int32(2))
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.ResourceConfig.K8s.Concurrency).To(Equal(2))
+		})
+	})
 })
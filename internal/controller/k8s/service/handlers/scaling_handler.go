@@ -17,12 +17,23 @@ limitations under the License.
 package handlers
 
 import (
+	"fmt"
 	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/resources"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
 )
 
 // ScalingHandler is a handler that scales K8s resources based on the determined period.
@@ -39,8 +50,13 @@ func NewScalingHandler() service.Handler {
 //
 // Behavior:
 //   - Validates and filters resource list
-//   - Processes each resource type for scaling
+//   - Splits the resources into the scaling groups of the scaler, ordered by period type
+//   - Processes each group in turn, and the resource types of a group in parallel; scaling up a
+//     group waits until its resources are ready
+//   - With clusters, scales the resources of each cluster in parallel and tags the results with
+//     the cluster
 //   - Collects success and failure results
+//   - Records a ScalingFailed event on the scaler for each failure (unless events are disabled)
 //   - Always continues to next handler (errors are collected, not returned)
 func (h *ScalingHandler) Execute(ctx *service.ReconciliationContext) error {
 	var (
@@ -59,6 +75,7 @@ func (h *ScalingHandler) Execute(ctx *service.ReconciliationContext) error {
 		})
 		ctx.SuccessResults = recSuccess
 		ctx.FailedResults = recFailed
+		h.recordFailures(ctx)
 		// Continue to next handler despite validation error
 		if h.next != nil && !ctx.SkipRemaining {
 			return h.next.Execute(ctx)
@@ -66,36 +83,25 @@ func (h *ScalingHandler) Execute(ctx *service.ReconciliationContext) error {
 		return nil
 	}
 
-	// Process each resource type and perform scaling operations
-	for _, resource := range resourceList {
-		curResource, err := resources.NewResource(ctx.Ctx, resource, ctx.ResourceConfig, ctx.Logger)
-		if err != nil {
-			ctx.Logger.Error().Err(err).Str("resource", resource).Msg("unable to get resource handler")
-			recFailed = append(recFailed, common.ScalerStatusFailed{
-				Kind:   resource,
-				Name:   "N/A",
-				Reason: err.Error(),
-			})
-			continue
-		}
-
-		success, failed, err := curResource.SetState(ctx.Ctx)
-		if err != nil {
-			ctx.Logger.Error().Err(err).Str("resource", resource).Msg("unable to set resource state")
-			recFailed = append(recFailed, common.ScalerStatusFailed{
-				Kind:   resource,
-				Name:   "N/A",
-				Reason: err.Error(),
-			})
-			continue
-		}
+	// Total the resources freed by the scale-down to estimate savings
+	released := &releaseTally{requests: corev1.ResourceList{}}
+	if ctx.ResourceConfig.K8s != nil {
+		k8sConfig := *ctx.ResourceConfig.K8s
+		k8sConfig.OnRelease = released.add
+		ctx.ResourceConfig.K8s = &k8sConfig
+	}
 
-		recSuccess = append(recSuccess, success...)
-		recFailed = append(recFailed, failed...)
+	if len(ctx.Clusters) > 0 {
+		recSuccess, recFailed = h.scaleClusters(ctx, resourceList)
+	} else {
+		outcome := h.scaleCluster(ctx, ctx.ResourceConfig, resourceList)
+		recSuccess, recFailed = outcome.success, outcome.failed
 	}
 
 	ctx.SuccessResults = recSuccess
 	ctx.FailedResults = recFailed
+	ctx.ReleasedRequests = released.requests
+	h.recordFailures(ctx)
 
 	ctx.Logger.Debug().
 		Int("success", len(recSuccess)).
@@ -110,11 +116,282 @@ func (h *ScalingHandler) Execute(ctx *service.ReconciliationContext) error {
 	return nil
 }
 
+// scaleCluster scales the resources of a cluster, group after group, with config.
+func (h *ScalingHandler) scaleCluster(ctx *service.ReconciliationContext, config resources.Config, resourceList []string) kindOutcome {
+	var outcome kindOutcome
+
+	groups, err := h.scalingGroups(ctx, config, resourceList)
+	if err != nil {
+		ctx.Logger.Error().Err(err).Msg("unable to get scaling groups")
+		outcome.failed = append(outcome.failed, common.ScalerStatusFailed{
+			Kind:   "N/A",
+			Name:   "N/A",
+			Reason: err.Error(),
+		})
+	}
+
+	for _, group := range groups {
+		if group.name != "" {
+			ctx.Logger.Debug().Str("group", group.name).Strs("resources", group.kinds).Msg("scaling group")
+		}
+		for _, kind := range h.scaleGroup(ctx, config, group) {
+			outcome.success = append(outcome.success, kind.success...)
+			outcome.failed = append(outcome.failed, kind.failed...)
+		}
+	}
+
+	return outcome
+}
+
+// scaleClusters scales the resources of every cluster of the scaler in parallel, each with its
+// own clients and namespaces, and tags the results with the cluster. A cluster that could not be
+// reached is reported as a failed result.
+func (h *ScalingHandler) scaleClusters(
+	ctx *service.ReconciliationContext, resourceList []string,
+) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed) {
+	outcomes := make([]kindOutcome, len(ctx.Clusters))
+	var g errgroup.Group
+	for i, cluster := range ctx.Clusters {
+		g.Go(func() error {
+			if cluster.Err != nil {
+				outcomes[i] = kindOutcome{failed: []common.ScalerStatusFailed{{
+					Kind:   "N/A",
+					Name:   "N/A",
+					Reason: cluster.Err.Error(),
+				}}}
+			} else {
+				outcomes[i] = h.scaleCluster(ctx, clusterConfig(ctx.ResourceConfig, cluster), resourceList)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait() // scaleCluster reports errors as failed results
+
+	var (
+		recSuccess []common.ScalerStatusSuccess
+		recFailed  []common.ScalerStatusFailed
+	)
+	for i, outcome := range outcomes {
+		for _, success := range outcome.success {
+			success.Cluster = ctx.Clusters[i].Name
+			recSuccess = append(recSuccess, success)
+		}
+		for _, failed := range outcome.failed {
+			failed.Cluster = ctx.Clusters[i].Name
+			recFailed = append(recFailed, failed)
+		}
+	}
+
+	return recSuccess, recFailed
+}
+
+// clusterConfig returns config targeting cluster. The namespaces of the cluster, when set,
+// replace those of the scaler. Events on the scaled resources are only recorded in the local
+// cluster.
+func clusterConfig(config resources.Config, cluster service.Cluster) resources.Config {
+	if config.K8s == nil {
+		return config
+	}
+
+	k8sConfig := *config.K8s
+	k8sConfig.Client = cluster.K8sClient
+	k8sConfig.DynamicClient = cluster.DynamicClient
+	k8sConfig.Cache = cluster.InformerCache
+	if len(cluster.Namespaces) > 0 {
+		k8sConfig.Namespaces = cluster.Namespaces
+	}
+	if len(cluster.ExcludeNamespaces) > 0 {
+		k8sConfig.ExcludeNamespaces = cluster.ExcludeNamespaces
+	}
+	if cluster.AuthSecret != "" {
+		k8sConfig.Recorder = nil
+	}
+	config.K8s = &k8sConfig
+
+	return config
+}
+
+// releaseTally totals the resources released by the resources scaled concurrently.
+type releaseTally struct {
+	mu       sync.Mutex
+	requests corev1.ResourceList
+}
+
+// add adds the requests released by a resource to the total.
+func (t *releaseTally) add(_ string, requests corev1.ResourceList) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for name, quantity := range requests {
+		total := t.requests[name]
+		total.Add(quantity)
+		t.requests[name] = total
+	}
+}
+
+// scalingGroup is a set of resources scaled together, before or after the resources of the other
+// groups.
+type scalingGroup struct {
+	// name is empty for the resources outside of every configured group
+	name  string
+	kinds []string
+	// filter selects the group's resources among those of a kind; nil selects them all
+	filter func(kind string) func(metaV1.Object) bool
+	// readinessTimeout is how long the group's resources are awaited until ready; zero does not wait
+	readinessTimeout time.Duration
+}
+
+// scalingGroups returns the groups of resources to scale in turn: the configured groups in
+// order, followed by the resources outside of every group, or the other way around when scaling
+// down. A resource belongs to the first group selecting it.
+func (h *ScalingHandler) scalingGroups(
+	ctx *service.ReconciliationContext, config resources.Config, resourceList []string,
+) ([]scalingGroup, error) {
+	configured := ctx.Scaler.Spec.Config.ScalingGroups
+	if len(configured) == 0 || config.K8s == nil {
+		return []scalingGroup{{kinds: resourceList}}, nil
+	}
+
+	type selection struct {
+		kinds    []string
+		selector labels.Selector
+	}
+	selections := make([]selection, 0, len(configured)+1)
+	groups := make([]scalingGroup, 0, len(configured)+1)
+	for _, group := range configured {
+		selector := labels.Everything()
+		if group.LabelSelector != nil {
+			var err error
+			selector, err = metaV1.LabelSelectorAsSelector(group.LabelSelector)
+			if err != nil {
+				return nil, fmt.Errorf("scaling group %q: invalid label selector: %w", group.Name, err)
+			}
+		}
+
+		kinds := resourceList
+		if len(group.Types) > 0 {
+			kinds = slices.DeleteFunc(slices.Clone(resourceList), func(kind string) bool {
+				return !slices.Contains(group.Types, common.ResourceKind(kind))
+			})
+		}
+
+		timeout := k8sUtils.DefaultReadinessTimeout
+		if group.ReadinessTimeout != nil {
+			timeout = min(group.ReadinessTimeout.Duration, k8sUtils.MaxReadinessTimeout)
+		}
+
+		selections = append(selections, selection{kinds: kinds, selector: selector})
+		groups = append(groups, scalingGroup{name: group.Name, kinds: kinds, readinessTimeout: timeout})
+	}
+	selections = append(selections, selection{kinds: resourceList, selector: labels.Everything()})
+	groups = append(groups, scalingGroup{kinds: resourceList, readinessTimeout: config.K8s.ReadinessTimeout})
+
+	for i := range groups {
+		groups[i].filter = func(kind string) func(metaV1.Object) bool {
+			return func(obj metaV1.Object) bool {
+				set := labels.Set(obj.GetLabels())
+				for _, previous := range selections[:i] {
+					if slices.Contains(previous.kinds, kind) && previous.selector.Matches(set) {
+						return false
+					}
+				}
+				return selections[i].selector.Matches(set)
+			}
+		}
+	}
+
+	if ctx.Period != nil && ctx.Period.Type == common.PeriodTypeDown {
+		slices.Reverse(groups)
+	}
+
+	return groups, nil
+}
+
+// scaleGroup scales the resource types of group in parallel with config. The types share a
+// single bound on the lists and scalings in flight, so that the concurrency applies to the
+// whole group rather than to each type. Each type writes its own outcome so that the results
+// keep the order of the resource list.
+func (h *ScalingHandler) scaleGroup(ctx *service.ReconciliationContext, config resources.Config, group scalingGroup) []kindOutcome {
+	limit := 1
+	var calls *semaphore.Weighted
+	if config.K8s != nil {
+		limit = max(config.K8s.Concurrency, 1)
+		calls = semaphore.NewWeighted(int64(limit))
+	}
+
+	outcomes := make([]kindOutcome, len(group.kinds))
+	var g errgroup.Group
+	g.SetLimit(limit)
+	for i, resource := range group.kinds {
+		g.Go(func() error {
+			kindConfig := config
+			if config.K8s != nil {
+				k8sConfig := *config.K8s
+				k8sConfig.Calls = calls
+				if group.filter != nil {
+					k8sConfig.Filter = group.filter(resource)
+					k8sConfig.ReadinessTimeout = group.readinessTimeout
+				}
+				kindConfig.K8s = &k8sConfig
+			}
+			outcomes[i] = h.scaleKind(ctx, resource, kindConfig)
+			return nil
+		})
+	}
+	_ = g.Wait() // scaleKind reports errors as failed results
+
+	return outcomes
+}
+
+// kindOutcome holds the results of scaling a single resource type.
+type kindOutcome struct {
+	success []common.ScalerStatusSuccess
+	failed  []common.ScalerStatusFailed
+}
+
+// scaleKind scales all resources of a single type. Errors are reported as failed results.
+func (h *ScalingHandler) scaleKind(ctx *service.ReconciliationContext, resource string, config resources.Config) (outcome kindOutcome) {
+	spanCtx, span := tracing.Start(ctx.Ctx, "k8s.ScaleKind", tracing.AttrResourceKind.String(resource))
+	defer func() {
+		span.SetAttributes(tracing.AttrSucceeded.Int(len(outcome.success)), tracing.AttrFailed.Int(len(outcome.failed)))
+		span.End()
+	}()
+
+	curResource, err := resources.NewResource(spanCtx, resource, config, ctx.Logger)
+	if err != nil {
+		tracing.SetError(span, err)
+		ctx.Logger.Error().Err(err).Str("resource", resource).Msg("unable to get resource handler")
+		return kindOutcome{failed: []common.ScalerStatusFailed{{
+			Kind:   resource,
+			Name:   "N/A",
+			Reason: err.Error(),
+		}}}
+	}
+
+	success, failed, err := curResource.SetState(spanCtx)
+	if err != nil {
+		tracing.SetError(span, err)
+		ctx.Logger.Error().Err(err).Str("resource", resource).Msg("unable to set resource state")
+		return kindOutcome{failed: []common.ScalerStatusFailed{{
+			Kind:   resource,
+			Name:   "N/A",
+			Reason: err.Error(),
+		}}}
+	}
+
+	return kindOutcome{success: success, failed: failed}
+}
+
 // SetNext establishes the next handler in the chain.
 func (h *ScalingHandler) SetNext(next service.Handler) {
 	h.next = next
 }
 
+// recordFailures records the failed results as warning events on the scaler.
+func (h *ScalingHandler) recordFailures(ctx *service.ReconciliationContext) {
+	utils.RecordScalingFailures(utils.ScalerEvents(ctx.Events, ctx.Scaler.Spec.Config.DisableEvents), ctx.Scaler, ctx.FailedResults)
+}
+
 // validResourceList validates and filters the list of resources to be scaled.
 // It ensures that only valid resource types are included and prevents mixing
 // of application resources (deployments, statefulsets) with HPA resources.
@@ -18,14 +18,21 @@ package handlers_test
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog"
+	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -147,4 +154,205 @@ var _ = Describe("ScalingHandler", func() {
 			Expect(nextCalled).To(BeTrue())
 		})
 	})
+
+	Context("When several resource types are scaled in parallel", func() {
+		It("should report results in the order of the resource types", func() {
+			scaler.Spec.Resources.Types = []common.ResourceKind{
+				common.ResourceStatefulSets, common.ResourceDeployments, common.ResourceCronJobs,
+			}
+			mockK8sClient := fake.NewSimpleClientset(
+				&appsV1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+					Spec:       appsV1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+				},
+				&appsV1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+					Spec:       appsV1.StatefulSetSpec{Replicas: ptr.To(int32(2))},
+				},
+				&batchV1.CronJob{
+					ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+				},
+			)
+			reconCtx.K8sClient = mockK8sClient
+			reconCtx.Period = &period.Period{Name: "down", Type: common.PeriodTypeDown}
+			reconCtx.ResourceConfig = resources.Config{
+				K8s: &k8sUtils.Config{
+					Client:      mockK8sClient,
+					Namespaces:  []string{"default"},
+					Period:      reconCtx.Period,
+					Concurrency: 3,
+				},
+			}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.FailedResults).To(BeEmpty())
+			kinds := make([]string, 0, len(reconCtx.SuccessResults))
+			for _, result := range reconCtx.SuccessResults {
+				kinds = append(kinds, result.Kind)
+			}
+			Expect(kinds).To(Equal([]string{"statefulset", "deployment", "cronjob"}))
+		})
+	})
+	Context("When scaling down workloads", func() {
+		It("should total the resources requested by the pods removed", func() {
+			template := corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				}},
+			}}}}
+			scaler.Spec.Resources.Types = []common.ResourceKind{common.ResourceDeployments, common.ResourceStatefulSets}
+			mockK8sClient := fake.NewSimpleClientset(
+				&appsV1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+					Spec:       appsV1.DeploymentSpec{Replicas: ptr.To(int32(3)), Template: template},
+				},
+				&appsV1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+					Spec:       appsV1.StatefulSetSpec{Replicas: ptr.To(int32(1)), Template: template},
+				},
+			)
+			down := &period.Period{Name: "down", Type: common.PeriodTypeDown}
+			reconCtx.K8sClient = mockK8sClient
+			reconCtx.Period = down
+			reconCtx.ResourceConfig = resources.Config{
+				K8s: &k8sUtils.Config{Client: mockK8sClient, Namespaces: []string{"default"}, Period: down},
+			}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.FailedResults).To(BeEmpty())
+			Expect(reconCtx.ReleasedRequests.Cpu().String()).To(Equal("2"))
+			Expect(reconCtx.ReleasedRequests.Memory().String()).To(Equal("4Gi"))
+		})
+	})
+
+	Context("When scaling groups are configured", func() {
+		var readyReplicas int32
+
+		names := func(results []common.ScalerStatusSuccess) []string {
+			out := make([]string, 0, len(results))
+			for _, result := range results {
+				out = append(out, result.Kind+"/"+result.Name)
+			}
+			return out
+		}
+
+		setup := func(p *period.Period) {
+			scaler.Spec.Resources.Types = []common.ResourceKind{common.ResourceDeployments, common.ResourceStatefulSets}
+			scaler.Spec.Config.ScalingGroups = []kubecloudscalerv1alpha3.K8sScalingGroup{{
+				Name:             "databases",
+				Types:            []common.ResourceKind{common.ResourceStatefulSets},
+				LabelSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "db"}},
+				ReadinessTimeout: &metav1.Duration{Duration: 50 * time.Millisecond},
+			}}
+			mockK8sClient := fake.NewSimpleClientset(
+				&appsV1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+					Spec:       appsV1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+				},
+				&appsV1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "default"},
+					Spec:       appsV1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+				},
+				&appsV1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"tier": "db"}},
+					Spec:       appsV1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+					Status:     appsV1.StatefulSetStatus{ReadyReplicas: readyReplicas},
+				},
+			)
+			reconCtx.K8sClient = mockK8sClient
+			reconCtx.Period = p
+			reconCtx.ResourceConfig = resources.Config{
+				K8s: &k8sUtils.Config{
+					Client:      mockK8sClient,
+					Namespaces:  []string{"default"},
+					Period:      p,
+					Concurrency: 2,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			readyReplicas = 2
+		})
+
+		It("should scale the groups up first", func() {
+			setup(&period.Period{Name: "up", Type: common.PeriodTypeUp, MaxReplicas: 2})
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.FailedResults).To(BeEmpty())
+			Expect(names(reconCtx.SuccessResults)).To(Equal([]string{
+				"statefulset/db", "deployment/web", "statefulset/cache",
+			}))
+		})
+
+		It("should scale the groups down last", func() {
+			setup(&period.Period{Name: "down", Type: common.PeriodTypeDown})
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.FailedResults).To(BeEmpty())
+			Expect(names(reconCtx.SuccessResults)).To(Equal([]string{
+				"deployment/web", "statefulset/cache", "statefulset/db",
+			}))
+		})
+
+		It("should report the group resources not ready and scale the next group", func() {
+			readyReplicas = 0
+			setup(&period.Period{Name: "up", Type: common.PeriodTypeUp, MaxReplicas: 2})
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(reconCtx.FailedResults).To(ConsistOf(common.ScalerStatusFailed{
+				Kind:   "statefulset",
+				Name:   "db",
+				Reason: "not ready after 50ms: 0/2 replicas ready",
+			}))
+			Expect(names(reconCtx.SuccessResults)).To(Equal([]string{"deployment/web", "statefulset/cache"}))
+		})
+	})
+
+	Context("When the scaler targets several clusters", func() {
+		It("should scale each cluster with its own namespaces and tag the results", func() {
+			local := fake.NewSimpleClientset(&appsV1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       appsV1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+			})
+			remote := fake.NewSimpleClientset(&appsV1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "production"},
+				Spec:       appsV1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+			})
+			down := &period.Period{Name: "down", Type: common.PeriodTypeDown}
+			reconCtx.Period = down
+			reconCtx.ResourceConfig = resources.Config{
+				K8s: &k8sUtils.Config{Client: local, Namespaces: []string{"default"}, Period: down},
+			}
+			reconCtx.Clusters = []service.Cluster{
+				{Name: "local", K8sClient: local},
+				{Name: "eu", AuthSecret: "eu", K8sClient: remote, Namespaces: []string{"production"}},
+				{Name: "us", AuthSecret: "us", Err: errors.New("unable to fetch auth secret")},
+			}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			scaled := make([]string, 0, len(reconCtx.SuccessResults))
+			for _, result := range reconCtx.SuccessResults {
+				scaled = append(scaled, result.Cluster+"/"+result.Name)
+			}
+			Expect(scaled).To(Equal([]string{"local/web", "eu/api"}))
+			Expect(reconCtx.FailedResults).To(ConsistOf(common.ScalerStatusFailed{
+				Kind:    "N/A",
+				Name:    "N/A",
+				Cluster: "us",
+				Reason:  "unable to fetch auth secret",
+			}))
+			deployment, err := remote.AppsV1().Deployments("production").Get(reconCtx.Ctx, "api", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*deployment.Spec.Replicas).To(BeZero())
+		})
+	})
 })
@@ -18,6 +18,7 @@ package handlers
 
 import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,6 +27,7 @@ import (
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
 )
 
@@ -44,7 +46,8 @@ func NewStatusHandler() service.Handler {
 //
 // Behavior:
 //   - If ShouldFinalize: Removes finalizer, returns without requeue
-//   - Otherwise: Updates status with success/failure results, sets requeue
+//   - Otherwise: Updates status with success/failure results, resource counts, conditions, history and
+//     estimated savings, notifies the transitions of the scaler, sets requeue
 func (h *StatusHandler) Execute(ctx *service.ReconciliationContext) error {
 	// Handle finalizer cleanup if the object is being deleted
 	if ctx.ShouldFinalize {
@@ -74,9 +77,14 @@ func (h *StatusHandler) Execute(ctx *service.ReconciliationContext) error {
 	ctx.Scaler.Status.CurrentPeriod.Successful = ctx.SuccessResults
 	ctx.Scaler.Status.CurrentPeriod.Failed = ctx.FailedResults
 	ctx.Scaler.Status.Comments = ptr.To("time period processed")
+	utils.SetReconciledConditions(&ctx.Scaler.Status, ctx.Scaler.Generation, ctx.Scaler.Spec.Suspend)
+	now := metav1.Now()
+	utils.RecordHistory(&ctx.Scaler.Status, now)
+	if ctx.Prices != nil {
+		utils.AccrueSavings(&ctx.Scaler.Status, ctx.Prices.RequestsRate(ctx.ReleasedRequests), ctx.Prices.Currency, now)
+	}
 
-	desiredPeriod := ctx.Scaler.Status.CurrentPeriod.DeepCopy()
-	desiredComments := ctx.Scaler.Status.Comments
+	desiredStatus := ctx.Scaler.Status.DeepCopy()
 
 	// Persist status via optimistic-locked merge patch, scoped to the status subresource.
 	// Patching (not Update) transmits only the fields we changed and respects
@@ -88,8 +96,7 @@ func (h *StatusHandler) Execute(ctx *service.ReconciliationContext) error {
 			return err
 		}
 		patch := client.MergeFromWithOptions(latest.DeepCopy(), client.MergeFromWithOptimisticLock{})
-		latest.Status.CurrentPeriod = desiredPeriod.DeepCopy()
-		latest.Status.Comments = desiredComments
+		latest.Status = *desiredStatus.DeepCopy()
 		return ctx.Client.Status().Patch(ctx.Ctx, latest, patch)
 	}); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -102,6 +109,12 @@ func (h *StatusHandler) Execute(ctx *service.ReconciliationContext) error {
 		return service.NewRecoverableError(err)
 	}
 
+	// Notify the transitions of the scaler once they are persisted
+	if ctx.Notifier != nil {
+		ctx.Notifier.Notify(ctx.Ctx, ctx.Scaler.Spec.Config.Notifications,
+			notify.Events(notify.KindK8s, ctx.Scaler.Name, ctx.PreviousPeriod, &ctx.Scaler.Status, now.Time)...)
+	}
+
 	// Single summary log per successful reconciliation (period + counts)
 	logEvent := ctx.Logger.Info().
 		Str("name", ctx.Scaler.Name).
@@ -19,11 +19,15 @@ package handlers_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -38,6 +42,8 @@ import (
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service/handlers"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/pricing"
 	"github.com/kubecloudscaler/kubecloudscaler/internal/utils"
 )
 
@@ -95,6 +101,25 @@ var _ = Describe("StatusHandler", func() {
 			Expect(reconCtx.RequeueAfter).To(Equal(utils.ReconcileSuccessDuration))
 		})
 
+		It("should persist the conditions, counts and observed generation", func() {
+			scaler.Generation = 3
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "nights", Type: string(common.PeriodTypeDown)}
+			reconCtx.SuccessResults = []common.ScalerStatusSuccess{
+				{Kind: "deployment", Name: "test-deployment-1", Comment: "scaled down"},
+			}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			persisted := &kubecloudscalerv1alpha3.K8s{}
+			Expect(reconCtx.Client.Get(reconCtx.Ctx, reconCtx.Request.NamespacedName, persisted)).To(Succeed())
+			Expect(persisted.Status.ObservedGeneration).To(Equal(int64(3)))
+			Expect(persisted.Status.Resources).To(Equal(&common.ScalerStatusResources{Managed: 1, Succeeded: 1}))
+			Expect(meta.IsStatusConditionTrue(persisted.Status.Conditions, common.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(persisted.Status.Conditions, common.ConditionScaling)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(persisted.Status.Conditions, common.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(persisted.Status.Conditions, common.ConditionSuspended)).To(BeTrue())
+			Expect(meta.FindStatusCondition(persisted.Status.Conditions, common.ConditionReady).ObservedGeneration).To(Equal(int64(3)))
+		})
 	})
 
 	Context("When updating status with failed results", func() {
@@ -108,6 +133,160 @@ var _ = Describe("StatusHandler", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(reconCtx.Scaler.Status.CurrentPeriod.Failed).To(Equal(reconCtx.FailedResults))
 		})
+
+		It("should report the scaler as degraded and not ready", func() {
+			reconCtx.FailedResults = []common.ScalerStatusFailed{
+				{Kind: "deployment", Name: "test-deployment-2", Reason: "API error"},
+			}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			ready := meta.FindStatusCondition(reconCtx.Scaler.Status.Conditions, common.ConditionReady)
+			Expect(ready).ToNot(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(utils.ReasonScalingFailed))
+			Expect(ready.Message).To(Equal("1 of 1 resources failed to scale"))
+			Expect(meta.IsStatusConditionTrue(reconCtx.Scaler.Status.Conditions, common.ConditionDegraded)).To(BeTrue())
+			Expect(reconCtx.Scaler.Status.Resources.Failed).To(Equal(int32(1)))
+		})
+	})
+
+	Context("When recording the history of the scaler", func() {
+		BeforeEach(func() {
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "nights", Type: string(common.PeriodTypeDown)}
+			reconCtx.SuccessResults = []common.ScalerStatusSuccess{{Kind: "deployment", Name: "api"}}
+		})
+
+		It("should add an entry only when the period or the failed resources change", func() {
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(scaler.Status.History).To(HaveLen(1))
+			Expect(scaler.Status.History[0].Name).To(Equal("nights"))
+			Expect(scaler.Status.History[0].Succeeded).To(Equal(int32(1)))
+
+			reconCtx.FailedResults = []common.ScalerStatusFailed{{Kind: "deployment", Name: "web", Reason: "API error"}}
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			reconCtx.FailedResults[0].Reason = "another API error"
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(scaler.Status.History).To(HaveLen(2))
+			Expect(scaler.Status.History[0].Failed).To(Equal(int32(1)))
+			Expect(scaler.Status.History[0].FailedItems).To(ConsistOf(HaveField("Name", "web")))
+
+			persisted := &kubecloudscalerv1alpha3.K8s{}
+			Expect(reconCtx.Client.Get(reconCtx.Ctx, reconCtx.Request.NamespacedName, persisted)).To(Succeed())
+			Expect(persisted.Status.History).To(HaveLen(2))
+		})
+
+		It("should keep only the most recent entries", func() {
+			for i := range utils.HistoryLimit + 2 {
+				scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: fmt.Sprintf("period-%d", i), Type: "up"}
+				Expect(handler.Execute(reconCtx)).To(Succeed())
+			}
+
+			Expect(scaler.Status.History).To(HaveLen(utils.HistoryLimit))
+			Expect(scaler.Status.History[0].Name).To(Equal(fmt.Sprintf("period-%d", utils.HistoryLimit+1)))
+		})
+	})
+
+	Context("When estimating savings", func() {
+		BeforeEach(func() {
+			reconCtx.Prices = &pricing.Table{Currency: "EUR", CPU: 0.04, Memory: 0.005}
+			reconCtx.ReleasedRequests = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}
+		})
+
+		It("should report the hourly rate of the released resources", func() {
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			persisted := &kubecloudscalerv1alpha3.K8s{}
+			Expect(reconCtx.Client.Get(reconCtx.Ctx, reconCtx.Request.NamespacedName, persisted)).To(Succeed())
+			Expect(persisted.Status.Savings).ToNot(BeNil())
+			Expect(persisted.Status.Savings.Currency).To(Equal("EUR"))
+			Expect(persisted.Status.Savings.HourlyRate).To(Equal("0.1000"))
+			Expect(persisted.Status.Savings.Total).To(Equal("0.0000"))
+		})
+
+		It("should accrue the savings at the previous rate", func() {
+			since := metav1.NewTime(metav1.Now().Add(-2 * time.Hour))
+			scaler.Status.Savings = &common.ScalerStatusSavings{
+				Currency: "EUR", HourlyRate: "0.5000", Total: "1.0000", Since: since, LastUpdateTime: since,
+			}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(scaler.Status.Savings.HourlyRate).To(Equal("0.1000"))
+			Expect(utils.ParseAmount(scaler.Status.Savings.Total)).To(BeNumerically("~", 2.0, 0.001))
+			Expect(scaler.Status.Savings.Since).To(Equal(since))
+		})
+
+		It("should not estimate savings without a price table", func() {
+			reconCtx.Prices = nil
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(scaler.Status.Savings).To(BeNil())
+		})
+	})
+
+	Context("When notifying the transitions of the scaler", func() {
+		var notifier *recordingNotifier
+
+		BeforeEach(func() {
+			notifier = &recordingNotifier{}
+			reconCtx.Notifier = notifier
+			scaler.Spec.Config.Notifications = []common.NotificationEndpoint{{Name: "slack", URL: "https://hooks.example.com"}}
+		})
+
+		It("should notify a period change and scaling failures to the endpoints of the scaler", func() {
+			reconCtx.PreviousPeriod = &common.ScalerStatusPeriod{Name: "nights", Type: string(common.PeriodTypeDown)}
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "days", Type: string(common.PeriodTypeUp)}
+			reconCtx.FailedResults = []common.ScalerStatusFailed{
+				{Kind: "deployment", Name: "test-deployment-1", Reason: "quota exceeded"},
+			}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+
+			Expect(notifier.endpoints).To(Equal(scaler.Spec.Config.Notifications))
+			Expect(notifier.events).To(HaveLen(2))
+			Expect(notifier.events[0].Type).To(Equal(common.NotificationPeriodChanged))
+			Expect(notifier.events[0].Kind).To(Equal(notify.KindK8s))
+			Expect(notifier.events[0].PreviousPeriod).To(Equal("nights"))
+			Expect(notifier.events[1].Type).To(Equal(common.NotificationScalingFailed))
+			Expect(notifier.events[1].Failures).To(Equal(reconCtx.FailedResults))
+		})
+
+		It("should not notify anything while the period and failures are unchanged", func() {
+			reconCtx.PreviousPeriod = &common.ScalerStatusPeriod{Name: "nights", Type: string(common.PeriodTypeDown)}
+			scaler.Status.CurrentPeriod = &common.ScalerStatusPeriod{Name: "nights", Type: string(common.PeriodTypeDown)}
+
+			Expect(handler.Execute(reconCtx)).To(Succeed())
+			Expect(notifier.events).To(BeEmpty())
+		})
+
+		It("should not notify transitions that could not be persisted", func() {
+			reconCtx.Client = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(scaler).
+				WithStatusSubresource(scaler).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourcePatch: func(
+						_ context.Context,
+						_ client.Client,
+						_ string,
+						_ client.Object,
+						_ client.Patch,
+						_ ...client.SubResourcePatchOption,
+					) error {
+						return fmt.Errorf("status patch failure")
+					},
+				}).
+				Build()
+
+			Expect(handler.Execute(reconCtx)).ToNot(Succeed())
+			Expect(notifier.events).To(BeEmpty())
+		})
 	})
 
 	Context("When finalizer cleanup is requested", func() {
@@ -308,3 +487,14 @@ var _ = Describe("StatusHandler", func() {
 		})
 	})
 })
+
+// recordingNotifier records the events notified by the handler.
+type recordingNotifier struct {
+	endpoints []common.NotificationEndpoint
+	events    []notify.Event
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, endpoints []common.NotificationEndpoint, events ...notify.Event) {
+	n.endpoints = endpoints
+	n.events = append(n.events, events...)
+}
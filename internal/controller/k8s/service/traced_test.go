@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/controller/k8s/service/testutil"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
+)
+
+var _ = Describe("Traced", func() {
+	var (
+		logger   zerolog.Logger
+		recorder *tracetest.SpanRecorder
+		previous trace.TracerProvider
+		reconCtx *service.ReconciliationContext
+	)
+
+	BeforeEach(func() {
+		logger = zerolog.Nop()
+		recorder = tracetest.NewSpanRecorder()
+		previous = otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		DeferCleanup(func() { otel.SetTracerProvider(previous) })
+
+		reconCtx = &service.ReconciliationContext{
+			Ctx:     context.Background(),
+			Request: ctrl.Request{NamespacedName: types.NamespacedName{Name: "nightly"}},
+			Logger:  &logger,
+		}
+	})
+
+	It("should nest the spans of the handlers and carry the scaler and period", func() {
+		var seen []context.Context
+		periodHandler := service.Traced("k8s.PeriodHandler", &testutil.MockHandler{
+			ExecuteFunc: func(ctx *service.ReconciliationContext) error {
+				seen = append(seen, ctx.Ctx)
+				ctx.Period = &period.Period{Name: "night", Type: common.PeriodTypeDown}
+				return nil
+			},
+		})
+		status := service.Traced("k8s.StatusHandler", &testutil.MockHandler{
+			ExecuteFunc: func(ctx *service.ReconciliationContext) error {
+				seen = append(seen, ctx.Ctx)
+				return nil
+			},
+		})
+		periodHandler.SetNext(status)
+
+		Expect(periodHandler.Execute(reconCtx)).To(Succeed())
+		Expect(reconCtx.Ctx).To(Equal(context.Background()))
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(2))
+		statusSpan, periodSpan := spans[0], spans[1]
+		Expect(periodSpan.Name()).To(Equal("k8s.PeriodHandler"))
+		Expect(statusSpan.Name()).To(Equal("k8s.StatusHandler"))
+		Expect(statusSpan.Parent().SpanID()).To(Equal(periodSpan.SpanContext().SpanID()))
+		Expect(trace.SpanContextFromContext(seen[1]).SpanID()).To(Equal(statusSpan.SpanContext().SpanID()))
+		Expect(periodSpan.Attributes()).To(ContainElements(
+			tracing.AttrScaler.String("nightly"),
+			tracing.AttrPeriod.String("night"),
+			tracing.AttrPeriodType.String("down"),
+		))
+	})
+
+	It("should record the error of the handler", func() {
+		failure := errors.New("boom")
+		handler := service.Traced("k8s.FetchHandler", &testutil.MockHandler{
+			ExecuteFunc: func(*service.ReconciliationContext) error { return failure },
+		})
+
+		Expect(handler.Execute(reconCtx)).To(MatchError(failure))
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(spans[0].Status().Description).To(Equal("boom"))
+	})
+})
@@ -0,0 +1,205 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/rs/zerolog"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/resources"
+)
+
+// workloadResourceKinds maps the resources watched by the informer caches to the scaler
+// resource kinds managing them.
+var workloadResourceKinds = map[schema.GroupResource]common.ResourceKind{
+	{Group: "apps", Resource: "deployments"}:                         common.ResourceDeployments,
+	{Group: "apps", Resource: "statefulsets"}:                        common.ResourceStatefulSets,
+	{Group: "batch", Resource: "cronjobs"}:                           common.ResourceCronJobs,
+	{Group: "autoscaling", Resource: "horizontalpodautoscalers"}:     common.ResourceHPA,
+	{Group: "actions.github.com", Resource: "autoscalingrunnersets"}: common.ResourceGithubARS,
+	{Group: "keda.sh", Resource: "scaledobjects"}:                    common.ResourceScaledObjects,
+	{Group: "postgresql.cnpg.io", Resource: "clusters"}:              common.ResourceCNPGClusters,
+	{Group: "", Resource: "services"}:                                common.ResourceServices,
+	{Group: "networking.k8s.io", Resource: "ingresses"}:              common.ResourceIngresses,
+	{Group: "gateway.networking.k8s.io", Resource: "httproutes"}:     common.ResourceHTTPRoutes,
+}
+
+// workloadWatch turns the changes of managed workloads into reconciliations of the scalers
+// managing them, so that new or drifted workloads are brought to the period's state without
+// waiting for the next periodic reconciliation.
+type workloadWatch struct {
+	// scalers reads the scalers from the manager cache
+	scalers client.Reader
+	// namespace is the operator namespace, where auth secrets are read by default
+	namespace string
+	logger    *zerolog.Logger
+
+	mu sync.RWMutex
+	// queue is the work queue of the controller, set once the watch is started
+	queue workqueue.TypedRateLimitingInterface[reconcile.Request]
+}
+
+func newWorkloadWatch(scalers client.Reader, namespace string, logger *zerolog.Logger) *workloadWatch {
+	return &workloadWatch{
+		scalers:   scalers,
+		namespace: namespace,
+		logger:    logger,
+	}
+}
+
+// Start implements source.Source, keeping the work queue of the controller to enqueue scalers
+// into. The queue deduplicates the requests, so a burst of changes reconciles a scaler once
+// without ever blocking the informers reporting them.
+func (w *workloadWatch) Start(_ context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.queue = queue
+	return nil
+}
+
+// notify enqueues the scalers managing obj, a resource of gvr in the cluster reached with
+// authSecret. Changes seen before the controller started are dropped, its first
+// reconciliations covering them.
+func (w *workloadWatch) notify(authSecret types.NamespacedName, gvr schema.GroupVersionResource, obj metaV1.Object) {
+	kind, ok := workloadResourceKinds[gvr.GroupResource()]
+	if !ok {
+		return
+	}
+
+	w.mu.RLock()
+	queue := w.queue
+	w.mu.RUnlock()
+	if queue == nil {
+		return
+	}
+
+	// The scalers are only read, so the cached objects are listed without copying them
+	scalers := &kubecloudscalerv1alpha3.K8sList{}
+	if err := w.scalers.List(context.Background(), scalers, client.UnsafeDisableDeepCopy); err != nil {
+		w.logger.Error().Err(err).Msg("unable to list scalers for a workload change")
+		return
+	}
+
+	for i := range scalers.Items {
+		scaler := &scalers.Items[i]
+		if !scalerManages(scaler, w.namespace, authSecret, kind, obj) {
+			continue
+		}
+
+		w.logger.Debug().
+			Str("scaler", scaler.Name).
+			Str("kind", string(kind)).
+			Str("namespace", obj.GetNamespace()).
+			Str("name", obj.GetName()).
+			Msg("workload changed, reconciling scaler")
+		queue.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(scaler)})
+	}
+}
+
+// scalerManages reports whether scaler selects obj, a resource of kind in the cluster reached
+// with authSecret, auth secrets being read in namespace unless the scaler sets another. It
+// mirrors the selection made when scaling, except for the operator's own namespace and the
+// labels of the namespace, which are not checked against the namespace selector: reconciling a
+// scaler for a resource it skips is harmless.
+func scalerManages(
+	scaler *kubecloudscalerv1alpha3.K8s, namespace string, authSecret types.NamespacedName,
+	kind common.ResourceKind, obj metaV1.Object,
+) bool {
+	if !scaler.DeletionTimestamp.IsZero() {
+		return false
+	}
+
+	config := scaler.Spec.Config
+	namespaces, excludeNamespaces := config.Namespaces, config.ExcludeNamespaces
+	if len(config.Clusters) > 0 {
+		i := slices.IndexFunc(config.Clusters, func(cluster kubecloudscalerv1alpha3.K8sCluster) bool {
+			return secretName(cluster.AuthSecret, cluster.AuthSecretNamespace, namespace) == authSecret
+		})
+		if i < 0 {
+			return false
+		}
+		cluster := config.Clusters[i]
+		if len(cluster.Namespaces) > 0 {
+			namespaces = cluster.Namespaces
+		}
+		if len(cluster.ExcludeNamespaces) > 0 {
+			excludeNamespaces = cluster.ExcludeNamespaces
+		}
+	} else if secretName(config.AuthSecret, config.AuthSecretNamespace, namespace) != authSecret {
+		return false
+	}
+
+	kinds := scaler.Spec.Resources.Types
+	if len(kinds) == 0 {
+		kinds = []common.ResourceKind{resources.DefaultK8SResourceType}
+	}
+	if !slices.Contains(kinds, kind) {
+		return false
+	}
+
+	objNamespace := obj.GetNamespace()
+	switch {
+	case len(namespaces) > 0:
+		if !slices.Contains(namespaces, objNamespace) {
+			return false
+		}
+	case slices.Contains(excludeNamespaces, objNamespace):
+		return false
+	}
+	if config.ForceExcludeSystemNamespaces && slices.Contains(k8sUtils.DefaultExcludeNamespaces, objNamespace) {
+		return false
+	}
+
+	if names := scaler.Spec.Resources.Names; len(names) > 0 && !slices.Contains(names, obj.GetName()) {
+		return false
+	}
+
+	if _, ignored := obj.GetLabels()[k8sUtils.AnnotationsPrefix+"/"+k8sUtils.AnnotationIgnore]; ignored {
+		return false
+	}
+	if scaler.Spec.Resources.LabelSelector != nil {
+		selector, err := metaV1.LabelSelectorAsSelector(scaler.Spec.Resources.LabelSelector)
+		if err != nil || !selector.Matches(labels.Set(obj.GetLabels())) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// secretName returns the namespace and name of the auth secret name, read in secretNamespace or
+// else in namespace, or the zero value for the default credentials when name is nil.
+func secretName(name, secretNamespace *string, namespace string) types.NamespacedName {
+	if name == nil {
+		return types.NamespacedName{}
+	}
+	return types.NamespacedName{Namespace: ptr.Deref(secretNamespace, namespace), Name: *name}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+	appsV1 "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+)
+
+var _ = Describe("Workload watch", func() {
+	var (
+		deploymentsGVR = appsV1.SchemeGroupVersion.WithResource("deployments")
+		deployment     *appsV1.Deployment
+		local          = types.NamespacedName{}
+	)
+
+	const operatorNamespace = "kubecloudscaler-system"
+	secret := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: operatorNamespace, Name: name}
+	}
+
+	newScaler := func(name string, mutate func(*kubecloudscalerv1alpha3.K8s)) *kubecloudscalerv1alpha3.K8s {
+		scaler := &kubecloudscalerv1alpha3.K8s{
+			ObjectMeta: metaV1.ObjectMeta{Name: name},
+			Spec: kubecloudscalerv1alpha3.K8sSpec{
+				Config: kubecloudscalerv1alpha3.K8sConfig{ForceExcludeSystemNamespaces: true},
+			},
+		}
+		if mutate != nil {
+			mutate(scaler)
+		}
+		return scaler
+	}
+
+	BeforeEach(func() {
+		deployment = &appsV1.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      "web",
+				Namespace: "staging",
+				Labels:    map[string]string{"tier": "front"},
+			},
+		}
+	})
+
+	Context("scalerManages", func() {
+		It("should match the default resource kind in any namespace", func() {
+			Expect(scalerManages(newScaler("s", nil), operatorNamespace, local, common.ResourceDeployments, deployment)).To(BeTrue())
+		})
+
+		It("should not match another cluster", func() {
+			scaler := newScaler("s", func(s *kubecloudscalerv1alpha3.K8s) {
+				s.Spec.Config.AuthSecret = ptr.To("remote")
+			})
+
+			Expect(scalerManages(scaler, operatorNamespace, local, common.ResourceDeployments, deployment)).To(BeFalse())
+			Expect(scalerManages(scaler, operatorNamespace, secret("remote"), common.ResourceDeployments, deployment)).To(BeTrue())
+		})
+
+		It("should not match a secret of the same name in another namespace", func() {
+			scaler := newScaler("s", func(s *kubecloudscalerv1alpha3.K8s) {
+				s.Spec.Config.Clusters = []kubecloudscalerv1alpha3.K8sCluster{
+					{Name: "eu", AuthSecret: ptr.To("remote"), AuthSecretNamespace: ptr.To("platform")},
+				}
+			})
+			platform := types.NamespacedName{Namespace: "platform", Name: "remote"}
+
+			Expect(scalerManages(scaler, operatorNamespace, secret("remote"), common.ResourceDeployments, deployment)).To(BeFalse())
+			Expect(scalerManages(scaler, operatorNamespace, platform, common.ResourceDeployments, deployment)).To(BeTrue())
+			scaler.Spec.Config.Clusters = nil
+			scaler.Spec.Config.AuthSecret = ptr.To("remote")
+			Expect(scalerManages(scaler, operatorNamespace, platform, common.ResourceDeployments, deployment)).To(BeFalse())
+		})
+
+		It("should match each of its clusters with their own namespaces", func() {
+			scaler := newScaler("s", func(s *kubecloudscalerv1alpha3.K8s) {
+				s.Spec.Config.Namespaces = []string{"staging"}
+				s.Spec.Config.Clusters = []kubecloudscalerv1alpha3.K8sCluster{
+					{Name: "local"},
+					{Name: "eu", AuthSecret: ptr.To("eu"), Namespaces: []string{"production"}},
+				}
+			})
+
+			Expect(scalerManages(scaler, operatorNamespace, local, common.ResourceDeployments, deployment)).To(BeTrue())
+			Expect(scalerManages(scaler, operatorNamespace, secret("eu"), common.ResourceDeployments, deployment)).To(BeFalse())
+			Expect(scalerManages(scaler, operatorNamespace, secret("us"), common.ResourceDeployments, deployment)).To(BeFalse())
+			deployment.Namespace = "production"
+			Expect(scalerManages(scaler, operatorNamespace, secret("eu"), common.ResourceDeployments, deployment)).To(BeTrue())
+		})
+
+		It("should not match resource kinds the scaler does not manage", func() {
+			scaler := newScaler("s", func(s *kubecloudscalerv1alpha3.K8s) {
+				s.Spec.Resources.Types = []common.ResourceKind{common.ResourceStatefulSets}
+			})
+
+			Expect(scalerManages(scaler, operatorNamespace, local, common.ResourceDeployments, deployment)).To(BeFalse())
+		})
+
+		It("should honour the namespace selection", func() {
+			included := newScaler("s", func(s *kubecloudscalerv1alpha3.K8s) {
+				s.Spec.Config.Namespaces = []string{"production"}
+			})
+			excluded := newScaler("s", func(s *kubecloudscalerv1alpha3.K8s) {
+				s.Spec.Config.ExcludeNamespaces = []string{"staging"}
+			})
+			deployment.Namespace = "kube-system"
+			system := deployment.DeepCopy()
+			deployment.Namespace = "staging"
+
+			Expect(scalerManages(included, operatorNamespace, local, common.ResourceDeployments, deployment)).To(BeFalse())
+			Expect(scalerManages(excluded, operatorNamespace, local, common.ResourceDeployments, deployment)).To(BeFalse())
+			Expect(scalerManages(newScaler("s", nil), operatorNamespace, local, common.ResourceDeployments, system)).To(BeFalse())
+		})
+
+		It("should honour resource names and label selectors", func() {
+			named := newScaler("s", func(s *kubecloudscalerv1alpha3.K8s) {
+				s.Spec.Resources.Names = []string{"api"}
+			})
+			selected := newScaler("s", func(s *kubecloudscalerv1alpha3.K8s) {
+				s.Spec.Resources.LabelSelector = &metaV1.LabelSelector{MatchLabels: map[string]string{"tier": "back"}}
+			})
+
+			Expect(scalerManages(named, operatorNamespace, local, common.ResourceDeployments, deployment)).To(BeFalse())
+			Expect(scalerManages(selected, operatorNamespace, local, common.ResourceDeployments, deployment)).To(BeFalse())
+		})
+
+		It("should not match ignored resources", func() {
+			deployment.Labels["kubecloudscaler.cloud/ignore"] = "true"
+
+			Expect(scalerManages(newScaler("s", nil), operatorNamespace, local, common.ResourceDeployments, deployment)).To(BeFalse())
+		})
+	})
+
+	Context("notify", func() {
+		var queue workqueue.TypedRateLimitingInterface[reconcile.Request]
+
+		BeforeEach(func() {
+			queue = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			DeferCleanup(queue.ShutDown)
+		})
+
+		It("should enqueue the scalers managing the changed workload once", func() {
+			scheme := runtime.NewScheme()
+			Expect(kubecloudscalerv1alpha3.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				newScaler("managing", nil),
+				newScaler("other-kind", func(s *kubecloudscalerv1alpha3.K8s) {
+					s.Spec.Resources.Types = []common.ResourceKind{common.ResourceCronJobs}
+				}),
+			).Build()
+			logger := zerolog.Nop()
+			watch := newWorkloadWatch(c, operatorNamespace, &logger)
+			Expect(watch.Start(context.Background(), queue)).To(Succeed())
+
+			watch.notify(local, deploymentsGVR, deployment)
+			watch.notify(local, deploymentsGVR, deployment)
+
+			Expect(queue.Len()).To(Equal(1))
+			request, _ := queue.Get()
+			Expect(request.Name).To(Equal("managing"))
+		})
+
+		It("should drop changes seen before the controller started", func() {
+			scheme := runtime.NewScheme()
+			Expect(kubecloudscalerv1alpha3.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newScaler("managing", nil)).Build()
+			logger := zerolog.Nop()
+			watch := newWorkloadWatch(c, operatorNamespace, &logger)
+
+			Expect(func() { watch.notify(local, deploymentsGVR, deployment) }).NotTo(Panic())
+			Expect(queue.Len()).To(BeZero())
+		})
+
+		It("should ignore resources no scaler kind maps to", func() {
+			logger := zerolog.Nop()
+			watch := newWorkloadWatch(fake.NewClientBuilder().Build(), operatorNamespace, &logger)
+			Expect(watch.Start(context.Background(), queue)).To(Succeed())
+
+			watch.notify(local, schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, deployment)
+
+			Expect(queue.Len()).To(BeZero())
+		})
+	})
+})
@@ -19,6 +19,7 @@ package metrics
 
 import (
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -60,6 +61,25 @@ type Recorder interface {
 	RecordReconcile(controller, result string, durationSeconds float64)
 	RecordScaling(controller, resourceKind, result string, count int)
 	RecordPeriodActive(controller, periodType string)
+	RecordDrift(controller, resourceKind string)
+	RecordSavings(controller, scaler, currency string, hourlyRate, total float64)
+	RecordScalerState(controller, scaler string, state ScalerState)
+	RecordGCPCall(operation string, durationSeconds float64, failed bool)
+	ForgetScaler(controller, scaler string)
+}
+
+// ScalerState is the state of a scaler after a successful reconciliation.
+type ScalerState struct {
+	// PeriodType is the type of the active period (up, down, noaction)
+	PeriodType string
+	// Managed is the number of resources managed by the scaler
+	Managed int
+	// ScaledDown is the number of resources currently scaled down
+	ScaledDown int
+	// Failed is the number of resources that failed to scale
+	Failed int
+	// NextTransition is when the active period may change next; zero when unknown
+	NextTransition time.Time
 }
 
 // Init registers custom metrics with the controller-runtime metrics registry,
@@ -76,6 +96,17 @@ func registerMetrics() {
 		reconcileDurationSeconds,
 		scalingOperationsTotal,
 		periodActivationsTotal,
+		workloadDriftTotal,
+		savingsHourlyRate,
+		savingsAccumulated,
+		scalerPeriodType,
+		scalerManagedResources,
+		scalerScaledDownResources,
+		scalerFailedResources,
+		scalerNextTransitionTimestamp,
+		lastSuccess,
+		gcpAPIRequestDurationSeconds,
+		gcpAPIErrorsTotal,
 	)
 	DefaultRecorder = newPromRecorder()
 }
@@ -117,8 +148,154 @@ var (
 		},
 		[]string{"controller", "period_type"},
 	)
+
+	workloadDriftTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "workload_drift_total",
+			Help:      "Total number of resources brought back to the active period's state after drifting from it, by controller and resource kind.",
+		},
+		[]string{"controller", "resource_kind"},
+	)
+
+	savingsHourlyRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Name:      "savings_hourly_rate",
+			Help:      "Estimated cost saved per hour by the resources a scaler currently keeps scaled down, by controller, scaler, and currency.",
+		},
+		[]string{"controller", "scaler", "currency"},
+	)
+
+	savingsAccumulated = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Name:      "savings_accumulated",
+			Help:      "Estimated cost saved by a scaler since the estimation started, as reported in its status, by controller, scaler, and currency.",
+		},
+		[]string{"controller", "scaler", "currency"},
+	)
+
+	scalerPeriodType = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Name:      "scaler_period_type",
+			Help:      "Type of the period a scaler currently applies, set to 1 for the active type (up, down, noaction) and 0 for the others, by controller and scaler.",
+		},
+		[]string{"controller", "scaler", "period_type"},
+	)
+
+	scalerManagedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Name:      "scaler_managed_resources",
+			Help:      "Number of resources managed by a scaler, by controller and scaler.",
+		},
+		[]string{"controller", "scaler"},
+	)
+
+	scalerScaledDownResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Name:      "scaler_scaled_down_resources",
+			Help:      "Number of resources a scaler currently keeps scaled down, by controller and scaler.",
+		},
+		[]string{"controller", "scaler"},
+	)
+
+	scalerFailedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Name:      "scaler_failed_resources",
+			Help:      "Number of resources that failed to scale during the last reconciliation of a scaler, by controller and scaler.",
+		},
+		[]string{"controller", "scaler"},
+	)
+
+	scalerNextTransitionTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Name:      "scaler_next_transition_timestamp_seconds",
+			Help:      "Unix time at which the active period of a scaler may change next, by controller and scaler.",
+		},
+		[]string{"controller", "scaler"},
+	)
+
+	lastSuccess = newLastSuccessCollector(prometheus.NewDesc(
+		prometheus.BuildFQName(metricNamespace, "", "scaler_seconds_since_last_success"),
+		"Seconds elapsed since the last successful reconciliation of a scaler, by controller and scaler.",
+		[]string{"controller", "scaler"}, nil,
+	))
+
+	gcpAPIRequestDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricNamespace,
+			Name:      "gcp_api_request_duration_seconds",
+			Help:      "Duration of GCP API calls in seconds by operation.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2.5, 10),
+		},
+		[]string{"operation"},
+	)
+
+	gcpAPIErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "gcp_api_errors_total",
+			Help:      "Total number of failed GCP API calls by operation.",
+		},
+		[]string{"operation"},
+	)
 )
 
+// periodTypes are the values of the period_type label.
+var periodTypes = []string{"up", "down", "noaction"}
+
+// lastSuccessCollector reports the time elapsed since the last successful reconciliation of each
+// scaler, computed when scraped so that it keeps growing while reconciliations fail.
+type lastSuccessCollector struct {
+	desc *prometheus.Desc
+	now  func() time.Time
+
+	mu    sync.Mutex
+	times map[[2]string]time.Time
+}
+
+func newLastSuccessCollector(desc *prometheus.Desc) *lastSuccessCollector {
+	return &lastSuccessCollector{
+		desc:  desc,
+		now:   time.Now,
+		times: map[[2]string]time.Time{},
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *lastSuccessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *lastSuccessCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, at := range c.times {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, now.Sub(at).Seconds(), key[0], key[1])
+	}
+}
+
+func (c *lastSuccessCollector) set(controller, scaler string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.times[[2]string{controller, scaler}] = c.now()
+}
+
+func (c *lastSuccessCollector) forget(controller, scaler string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.times, [2]string{controller, scaler})
+}
+
 type promRecorder struct{}
 
 func newPromRecorder() *promRecorder {
@@ -141,6 +318,64 @@ func (p *promRecorder) RecordPeriodActive(controller, periodType string) {
 	periodActivationsTotal.WithLabelValues(controller, periodType).Inc()
 }
 
+func (p *promRecorder) RecordDrift(controller, resourceKind string) {
+	workloadDriftTotal.WithLabelValues(controller, resourceKind).Inc()
+}
+
+func (p *promRecorder) RecordSavings(controller, scaler, currency string, hourlyRate, total float64) {
+	// Drop the series of a previous currency
+	forgetSavings(prometheus.Labels{"controller": controller, "scaler": scaler})
+	savingsHourlyRate.WithLabelValues(controller, scaler, currency).Set(hourlyRate)
+	savingsAccumulated.WithLabelValues(controller, scaler, currency).Set(total)
+}
+
+func (p *promRecorder) RecordScalerState(controller, scaler string, state ScalerState) {
+	active := NormalizePeriodType(state.PeriodType)
+	for _, periodType := range periodTypes {
+		value := 0.0
+		if periodType == active {
+			value = 1
+		}
+		scalerPeriodType.WithLabelValues(controller, scaler, periodType).Set(value)
+	}
+
+	scalerManagedResources.WithLabelValues(controller, scaler).Set(float64(state.Managed))
+	scalerScaledDownResources.WithLabelValues(controller, scaler).Set(float64(state.ScaledDown))
+	scalerFailedResources.WithLabelValues(controller, scaler).Set(float64(state.Failed))
+
+	if state.NextTransition.IsZero() {
+		scalerNextTransitionTimestamp.DeleteLabelValues(controller, scaler)
+	} else {
+		scalerNextTransitionTimestamp.WithLabelValues(controller, scaler).Set(float64(state.NextTransition.Unix()))
+	}
+
+	lastSuccess.set(controller, scaler)
+}
+
+func (p *promRecorder) RecordGCPCall(operation string, durationSeconds float64, failed bool) {
+	gcpAPIRequestDurationSeconds.WithLabelValues(operation).Observe(durationSeconds)
+	if failed {
+		gcpAPIErrorsTotal.WithLabelValues(operation).Inc()
+	}
+}
+
+func (p *promRecorder) ForgetScaler(controller, scaler string) {
+	labels := prometheus.Labels{"controller": controller, "scaler": scaler}
+	forgetSavings(labels)
+	scalerPeriodType.DeletePartialMatch(labels)
+	scalerManagedResources.DeletePartialMatch(labels)
+	scalerScaledDownResources.DeletePartialMatch(labels)
+	scalerFailedResources.DeletePartialMatch(labels)
+	scalerNextTransitionTimestamp.DeletePartialMatch(labels)
+	lastSuccess.forget(controller, scaler)
+}
+
+// forgetSavings drops the savings series matching labels.
+func forgetSavings(labels prometheus.Labels) {
+	savingsHourlyRate.DeletePartialMatch(labels)
+	savingsAccumulated.DeletePartialMatch(labels)
+}
+
 // noopRecorder is used when metrics are disabled or in tests.
 type noopRecorder struct{}
 
@@ -150,6 +385,16 @@ func (noopRecorder) RecordScaling(_, _, _ string, _ int) {}
 
 func (noopRecorder) RecordPeriodActive(_, _ string) {}
 
+func (noopRecorder) RecordDrift(_, _ string) {}
+
+func (noopRecorder) RecordSavings(_, _, _ string, _, _ float64) {}
+
+func (noopRecorder) RecordScalerState(_, _ string, _ ScalerState) {}
+
+func (noopRecorder) RecordGCPCall(_ string, _ float64, _ bool) {}
+
+func (noopRecorder) ForgetScaler(_, _ string) {}
+
 // GetRecorder returns the default recorder. After Init(), it returns the Prometheus recorder.
 func GetRecorder() Recorder {
 	return DefaultRecorder
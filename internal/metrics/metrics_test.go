@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromRecorder_ScalerState(t *testing.T) {
+	rec := newPromRecorder()
+	next := time.Date(2025, 1, 15, 18, 0, 59, 0, time.UTC)
+
+	rec.RecordScalerState(ControllerK8sScaler, "nightly", ScalerState{
+		PeriodType:     "down",
+		Managed:        4,
+		ScaledDown:     3,
+		Failed:         1,
+		NextTransition: next,
+	})
+
+	assert.InDelta(t, 1, testutil.ToFloat64(scalerPeriodType.WithLabelValues(ControllerK8sScaler, "nightly", "down")), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(scalerPeriodType.WithLabelValues(ControllerK8sScaler, "nightly", "up")), 0)
+	assert.InDelta(t, 4, testutil.ToFloat64(scalerManagedResources.WithLabelValues(ControllerK8sScaler, "nightly")), 0)
+	assert.InDelta(t, 3, testutil.ToFloat64(scalerScaledDownResources.WithLabelValues(ControllerK8sScaler, "nightly")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(scalerFailedResources.WithLabelValues(ControllerK8sScaler, "nightly")), 0)
+	assert.InDelta(t, float64(next.Unix()),
+		testutil.ToFloat64(scalerNextTransitionTimestamp.WithLabelValues(ControllerK8sScaler, "nightly")), 0)
+
+	// An unknown next transition drops its series
+	rec.RecordScalerState(ControllerK8sScaler, "nightly", ScalerState{})
+	assert.Equal(t, 0, testutil.CollectAndCount(scalerNextTransitionTimestamp))
+	assert.InDelta(t, 1, testutil.ToFloat64(scalerPeriodType.WithLabelValues(ControllerK8sScaler, "nightly", "noaction")), 0)
+
+	rec.ForgetScaler(ControllerK8sScaler, "nightly")
+	for _, collector := range []prometheus.Collector{
+		scalerPeriodType, scalerManagedResources, scalerScaledDownResources, scalerFailedResources, lastSuccess,
+	} {
+		assert.Equal(t, 0, testutil.CollectAndCount(collector))
+	}
+}
+
+func TestLastSuccessCollector(t *testing.T) {
+	collector := newLastSuccessCollector(prometheus.NewDesc("test_seconds_since_last_success", "test",
+		[]string{"controller", "scaler"}, nil))
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	collector.now = func() time.Time { return now }
+
+	collector.set(ControllerGcpScaler, "vms")
+	now = now.Add(90 * time.Second)
+
+	assert.InDelta(t, 90, testutil.ToFloat64(collector), 1e-9)
+
+	collector.forget(ControllerGcpScaler, "vms")
+	assert.Equal(t, 0, testutil.CollectAndCount(collector))
+}
+
+func TestPromRecorder_RecordGCPCall(t *testing.T) {
+	rec := newPromRecorder()
+
+	rec.RecordGCPCall("instances.stop", 0.2, false)
+	rec.RecordGCPCall("instances.stop", 0.4, true)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(gcpAPIErrorsTotal.WithLabelValues("instances.stop")), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(gcpAPIRequestDurationSeconds))
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"time"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+)
+
+// Kinds of the scalers notifications are sent for.
+const (
+	KindK8s = "K8s"
+	KindGcp = "Gcp"
+)
+
+// Event describes a transition of a scaler. It is the data of CloudEvents and of message templates.
+type Event struct {
+	// Type of the event
+	Type common.NotificationEvent `json:"type"`
+	// Kind of the scaler, K8s or Gcp
+	Kind string `json:"kind"`
+	// Scaler is the name of the scaler
+	Scaler string `json:"scaler"`
+	// Period is the name of the active period
+	Period string `json:"period"`
+	// PeriodType is the type of the active period: up, down or noaction
+	PeriodType string `json:"periodType"`
+	// PreviousPeriod is the name of the period active before a PeriodChanged event, if any
+	PreviousPeriod string `json:"previousPeriod,omitempty"`
+	// PreviousPeriodType is the type of PreviousPeriod
+	PreviousPeriodType string `json:"previousPeriodType,omitempty"`
+	// Succeeded is the number of resources in the desired state
+	Succeeded int `json:"succeeded"`
+	// Failed is the number of resources that failed to scale
+	Failed int `json:"failed"`
+	// Postponed is the number of resources whose scale-down is postponed
+	Postponed int `json:"postponed,omitempty"`
+	// Failures lists the resources that failed to scale
+	Failures []common.ScalerStatusFailed `json:"failures,omitempty"`
+	// ScaleDownTime is the time at which the period scales resources down, for ScaleDownWarning events
+	ScaleDownTime time.Time `json:"scaleDownTime,omitzero"`
+	// Resources lists the resources about to be scaled down, for ScaleDownWarning events
+	Resources []string `json:"resources,omitempty"`
+	// Snooze is the command keeping the resources up for a while, for ScaleDownWarning events
+	Snooze string `json:"snooze,omitempty"`
+	// Time of the event
+	Time time.Time `json:"time"`
+}
+
+// Events returns the events of a reconciliation of the scaler kind/name that set status, given
+// the period status prev captured before the reconciliation:
+//   - PeriodChanged when the active period differs from prev
+//   - ScalingFailed when resources fail to scale while none failed in prev
+//   - ScalingRecovered when no resource fails to scale anymore
+func Events(kind, name string, prev *common.ScalerStatusPeriod, status *common.ScalerStatus, now time.Time) []Event {
+	current := status.CurrentPeriod
+	if current == nil {
+		return nil
+	}
+
+	succeeded, postponed := current.Outcomes()
+	event := Event{
+		Kind:       kind,
+		Scaler:     name,
+		Period:     current.Name,
+		PeriodType: current.Type,
+		Succeeded:  int(succeeded),
+		Failed:     len(current.Failed),
+		Postponed:  int(postponed),
+		Failures:   current.Failed,
+		Time:       now,
+	}
+
+	var events []Event
+	if prev == nil || prev.Name != current.Name || prev.Type != current.Type {
+		changed := event
+		changed.Type = common.NotificationPeriodChanged
+		if prev != nil {
+			changed.PreviousPeriod, changed.PreviousPeriodType = prev.Name, prev.Type
+		}
+		events = append(events, changed)
+	}
+
+	wasFailing := prev != nil && len(prev.Failed) > 0
+	switch {
+	case len(current.Failed) > 0 && !wasFailing:
+		failed := event
+		failed.Type = common.NotificationScalingFailed
+		events = append(events, failed)
+	case len(current.Failed) == 0 && wasFailing:
+		recovered := event
+		recovered.Type = common.NotificationScalingRecovered
+		events = append(events, recovered)
+	}
+
+	return events
+}
+
+// WarningEvent returns the ScaleDownWarning event of the scaler kind/name about warning, listing
+// the resources about to be scaled down and the command snoozing the scale-down.
+func WarningEvent(kind, name string, warning *common.ScalerStatusWarning, resources []string, snooze string) Event {
+	return Event{
+		Type:          common.NotificationScaleDownWarning,
+		Kind:          kind,
+		Scaler:        name,
+		Period:        warning.Period,
+		PeriodType:    string(common.PeriodTypeDown),
+		ScaleDownTime: warning.ScaleDownTime.Time,
+		Resources:     resources,
+		Snooze:        snooze,
+		Time:          warning.Time.Time,
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notify posts the transitions of scalers, such as period changes and scaling failures,
+// to HTTP endpoints as CloudEvents or Slack and Teams messages.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+)
+
+// Keys of the Secret of a notification endpoint.
+const (
+	// SecretKeyURL holds the URL of the endpoint, overriding the one of the endpoint.
+	SecretKeyURL = "url"
+	// SecretKeySigningKey holds the key signing the payloads.
+	SecretKeySigningKey = "signingKey"
+)
+
+// SignatureHeader holds the HMAC-SHA256 of the payload, as sha256=<hex>, when the endpoint has a
+// signing key.
+const SignatureHeader = "X-Kubecloudscaler-Signature"
+
+// Delivery settings.
+const (
+	// DefaultAttempts is how many times a notification is posted before giving up.
+	DefaultAttempts = 3
+	// DefaultBackoff is the delay before the first retry, doubled after each attempt.
+	DefaultBackoff = 2 * time.Second
+	// DefaultTimeout bounds each attempt.
+	DefaultTimeout = 10 * time.Second
+)
+
+// Notifier sends the events of scalers to notification endpoints.
+type Notifier interface {
+	// Notify sends events to the endpoints subscribing to them, among the operator-wide
+	// endpoints and endpoints. Deliveries are asynchronous: failures are only logged.
+	Notify(ctx context.Context, endpoints []common.NotificationEndpoint, events ...Event)
+}
+
+// Dispatcher is the Notifier posting events over HTTP, retrying on network errors, 429 and 5xx
+// responses.
+type Dispatcher struct {
+	reader    client.Reader
+	namespace string
+	global    EndpointSource
+	logger    *zerolog.Logger
+	client    *http.Client
+	attempts  int
+	backoff   time.Duration
+
+	pending sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher reading the secrets of endpoints in namespace with reader,
+// and sending events to the endpoints of global as well, if not nil.
+func NewDispatcher(reader client.Reader, namespace string, global EndpointSource, logger *zerolog.Logger) *Dispatcher {
+	return &Dispatcher{
+		reader:    reader,
+		namespace: namespace,
+		global:    global,
+		logger:    logger,
+		client:    &http.Client{Timeout: DefaultTimeout},
+		attempts:  DefaultAttempts,
+		backoff:   DefaultBackoff,
+	}
+}
+
+// target is where the notifications of an endpoint are posted.
+type target struct {
+	url        string
+	signingKey []byte
+}
+
+// Notify implements Notifier.
+func (d *Dispatcher) Notify(ctx context.Context, endpoints []common.NotificationEndpoint, events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	if d.global != nil {
+		global, err := d.global.Endpoints(ctx)
+		if err != nil {
+			d.logger.Warn().Err(err).Msg("unable to load the operator-wide notification endpoints")
+		}
+		// The source may share its slice between calls: never append to it
+		endpoints = slices.Concat(global, endpoints)
+	}
+
+	// Deliveries outlive the reconciliation, but not the values of its context
+	deliveryCtx := context.WithoutCancel(ctx)
+	for _, endpoint := range endpoints {
+		var tgt *target
+		for _, event := range events {
+			if !endpoint.Subscribes(event.Type) {
+				continue
+			}
+
+			logger := d.logger.With().Str("endpoint", endpoint.Name).Str("event", string(event.Type)).
+				Str("scaler", event.Scaler).Logger()
+
+			if tgt == nil {
+				var err error
+				if tgt, err = d.resolve(ctx, endpoint); err != nil {
+					logger.Error().Err(err).Msg("unable to send notification")
+					break
+				}
+			}
+
+			body, contentType, err := payload(endpoint, event)
+			if err != nil {
+				logger.Error().Err(err).Msg("unable to send notification")
+				continue
+			}
+
+			d.pending.Add(1)
+			go func() {
+				defer d.pending.Done()
+				if err := d.deliver(deliveryCtx, tgt, body, contentType); err != nil {
+					logger.Error().Err(err).Msg("unable to send notification")
+					return
+				}
+				logger.Debug().Msg("notification sent")
+			}()
+		}
+	}
+}
+
+// wait blocks until the pending deliveries are over.
+func (d *Dispatcher) wait() {
+	d.pending.Wait()
+}
+
+// resolve returns the target of endpoint, reading its secret if any.
+func (d *Dispatcher) resolve(ctx context.Context, endpoint common.NotificationEndpoint) (*target, error) {
+	tgt := &target{url: endpoint.URL}
+	if endpoint.Secret != nil {
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: d.namespace, Name: *endpoint.Secret}
+		if err := d.reader.Get(ctx, key, secret); err != nil {
+			return nil, fmt.Errorf("error reading notification secret %s: %w", key, err)
+		}
+		if url, ok := secret.Data[SecretKeyURL]; ok {
+			tgt.url = string(url)
+		}
+		tgt.signingKey = secret.Data[SecretKeySigningKey]
+	}
+
+	if tgt.url == "" {
+		return nil, fmt.Errorf("no url for notification endpoint %s", endpoint.Name)
+	}
+
+	return tgt, nil
+}
+
+// deliver posts body to tgt, retrying with an exponential backoff.
+func (d *Dispatcher) deliver(ctx context.Context, tgt *target, body []byte, contentType string) error {
+	backoff := d.backoff
+	var err error
+	for attempt := 1; attempt <= d.attempts; attempt++ {
+		var retryable bool
+		if retryable, err = d.post(ctx, tgt, body, contentType); err == nil || !retryable {
+			return err
+		}
+		if attempt == d.attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", d.attempts, err)
+}
+
+// post makes a single attempt at posting body to tgt, and returns whether a failure is worth
+// retrying.
+func (d *Dispatcher) post(ctx context.Context, tgt *target, body []byte, contentType string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tgt.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("error creating notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "kubecloudscaler")
+	if len(tgt.signingKey) > 0 {
+		req.Header.Set(SignatureHeader, Sign(tgt.signingKey, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("error posting notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retryable, fmt.Errorf("notification rejected with status %d", resp.StatusCode)
+	}
+
+	return false, nil
+}
+
+// Sign returns the signature of body with key, as sent in SignatureHeader.
+func Sign(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,406 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+)
+
+const testNamespace = "kubecloudscaler-system"
+
+// request is a notification received by a test server.
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+// newServer starts a server answering with statuses in turn, then 200, and records the
+// notifications it receives.
+func newServer(t *testing.T, statuses ...int) (*httptest.Server, func() []request) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var received []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, request{header: r.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(received) <= len(statuses) {
+			status = statuses[len(received)-1]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []request {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]request(nil), received...)
+	}
+}
+
+// newTestDispatcher creates a dispatcher without retry delays, reading secrets among objects.
+func newTestDispatcher(global EndpointSource, secrets ...*corev1.Secret) *Dispatcher {
+	builder := fake.NewClientBuilder()
+	for _, secret := range secrets {
+		builder = builder.WithObjects(secret)
+	}
+	logger := zerolog.Nop()
+
+	dispatcher := NewDispatcher(builder.Build(), testNamespace, global, &logger)
+	dispatcher.backoff = time.Millisecond
+	return dispatcher
+}
+
+// staticSource provides fixed operator-wide endpoints.
+type staticSource []common.NotificationEndpoint
+
+func (s staticSource) Endpoints(context.Context) ([]common.NotificationEndpoint, error) {
+	return s, nil
+}
+
+func testEvent(eventType common.NotificationEvent) Event {
+	return Event{
+		Type:       eventType,
+		Kind:       KindK8s,
+		Scaler:     "staging",
+		Period:     "night",
+		PeriodType: "down",
+		Succeeded:  12,
+		Time:       time.Date(2025, 3, 10, 19, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 3, 10, 19, 0, 0, 0, time.UTC)
+	failed := []common.ScalerStatusFailed{{Kind: "deployments", Name: "default/api", Reason: "quota exceeded"}}
+	successful := []common.ScalerStatusSuccess{{Kind: "deployments", Name: "default/web"}}
+
+	tests := []struct {
+		name    string
+		prev    *common.ScalerStatusPeriod
+		current *common.ScalerStatusPeriod
+		expect  []common.NotificationEvent
+	}{
+		{
+			name:    "first reconciliation",
+			current: &common.ScalerStatusPeriod{Name: "night", Type: "down", Successful: successful},
+			expect:  []common.NotificationEvent{common.NotificationPeriodChanged},
+		},
+		{
+			name:    "same period",
+			prev:    &common.ScalerStatusPeriod{Name: "night", Type: "down", Successful: successful},
+			current: &common.ScalerStatusPeriod{Name: "night", Type: "down", Successful: successful},
+		},
+		{
+			name:    "period changed with failures",
+			prev:    &common.ScalerStatusPeriod{Name: "night", Type: "down", Successful: successful},
+			current: &common.ScalerStatusPeriod{Name: "day", Type: "up", Failed: failed},
+			expect:  []common.NotificationEvent{common.NotificationPeriodChanged, common.NotificationScalingFailed},
+		},
+		{
+			name:    "still failing",
+			prev:    &common.ScalerStatusPeriod{Name: "day", Type: "up", Failed: failed},
+			current: &common.ScalerStatusPeriod{Name: "day", Type: "up", Failed: failed},
+		},
+		{
+			name:    "recovered",
+			prev:    &common.ScalerStatusPeriod{Name: "day", Type: "up", Failed: failed},
+			current: &common.ScalerStatusPeriod{Name: "day", Type: "up", Successful: successful},
+			expect:  []common.NotificationEvent{common.NotificationScalingRecovered},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			events := Events(KindK8s, "staging", tt.prev, &common.ScalerStatus{CurrentPeriod: tt.current}, now)
+
+			var types []common.NotificationEvent
+			for _, event := range events {
+				types = append(types, event.Type)
+				assert.Equal(t, "staging", event.Scaler)
+				assert.Equal(t, tt.current.Name, event.Period)
+				assert.Equal(t, len(tt.current.Failed), event.Failed)
+				assert.Equal(t, now, event.Time)
+			}
+			assert.Equal(t, tt.expect, types)
+		})
+	}
+
+	events := Events(KindGcp, "vms",
+		&common.ScalerStatusPeriod{Name: "night", Type: "down"},
+		&common.ScalerStatus{CurrentPeriod: &common.ScalerStatusPeriod{Name: "day", Type: "up"}}, now)
+	require.Len(t, events, 1)
+	assert.Equal(t, "night", events[0].PreviousPeriod)
+	assert.Equal(t, "down", events[0].PreviousPeriodType)
+
+	postponed := &common.ScalerStatusPeriod{Name: "night", Type: "down", Successful: []common.ScalerStatusSuccess{
+		{Kind: "deployments", Name: "default/web"},
+		{Kind: "deployments", Name: "default/api", PostponedUntil: &metav1.Time{Time: now.Add(time.Hour)}},
+	}}
+	events = Events(KindK8s, "staging", nil, &common.ScalerStatus{CurrentPeriod: postponed}, now)
+	require.Len(t, events, 1)
+	assert.Equal(t, 1, events[0].Succeeded)
+	assert.Equal(t, 1, events[0].Postponed)
+
+	assert.Empty(t, Events(KindK8s, "staging", nil, &common.ScalerStatus{}, now))
+}
+
+func TestPayload(t *testing.T) {
+	t.Parallel()
+
+	event := testEvent(common.NotificationPeriodChanged)
+
+	body, contentType, err := payload(common.NotificationEndpoint{Name: "events"}, event)
+	require.NoError(t, err)
+	assert.Equal(t, "application/cloudevents+json", contentType)
+	var ce map[string]any
+	require.NoError(t, json.Unmarshal(body, &ce))
+	assert.Equal(t, "1.0", ce["specversion"])
+	assert.Equal(t, "cloud.kubecloudscaler.period.changed", ce["type"])
+	assert.Equal(t, "/kubecloudscaler/k8s/staging", ce["source"])
+	assert.Equal(t, "2025-03-10T19:00:00Z", ce["time"])
+	assert.NotEmpty(t, ce["id"])
+	data, ok := ce["data"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "night", data["period"])
+	assert.Equal(t, `K8s scaler staging is going to sleep: period "night" (down) applied to 12 resources`, data["message"])
+
+	body, contentType, err = payload(common.NotificationEndpoint{
+		Name: "slack", Format: common.NotificationFormatSlack, Template: "{{ .Scaler }} is going to sleep",
+	}, event)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", contentType)
+	assert.JSONEq(t, `{"text":"staging is going to sleep"}`, string(body))
+
+	failed := testEvent(common.NotificationScalingFailed)
+	failed.PeriodType, failed.Failed = "up", 1
+	failed.Failures = []common.ScalerStatusFailed{{Kind: "deployments", Name: "default/api", Reason: "quota exceeded"}}
+	body, _, err = payload(common.NotificationEndpoint{Name: "teams", Format: common.NotificationFormatTeams}, failed)
+	require.NoError(t, err)
+	var card teamsMessageCard
+	require.NoError(t, json.Unmarshal(body, &card))
+	assert.Equal(t, "MessageCard", card.Type)
+	assert.Equal(t, teamsColorFailure, card.ThemeColor)
+	assert.Equal(t, "K8s scaler staging failed to scale 1 resources for period \"night\" (up)\n"+
+		"- deployments default/api: quota exceeded", card.Text)
+
+	_, _, err = payload(common.NotificationEndpoint{Name: "broken", Template: "{{ .Missing }}"}, event)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error executing notification template")
+}
+
+func TestWarningEvent(t *testing.T) {
+	t.Parallel()
+
+	scaleDown := time.Date(2025, 3, 10, 19, 0, 0, 0, time.UTC)
+	warning := &common.ScalerStatusWarning{
+		Period:        "night",
+		ScaleDownTime: metav1.NewTime(scaleDown),
+		Before:        metav1.Duration{Duration: 15 * time.Minute},
+		Time:          metav1.NewTime(scaleDown.Add(-15 * time.Minute)),
+	}
+	snooze := `kubectl patch k8s staging --type merge -p '{"spec":{"override":{"type":"up","until":"2025-03-10T20:00:00Z"}}}'`
+
+	event := WarningEvent(KindK8s, "staging", warning, []string{"deployments default/api"}, snooze)
+	assert.Equal(t, common.NotificationScaleDownWarning, event.Type)
+	assert.Equal(t, "down", event.PeriodType)
+	assert.Equal(t, scaleDown, event.ScaleDownTime)
+	assert.Equal(t, warning.Time.Time, event.Time)
+
+	body, _, err := payload(common.NotificationEndpoint{Name: "events"}, event)
+	require.NoError(t, err)
+	var ce map[string]any
+	require.NoError(t, json.Unmarshal(body, &ce))
+	assert.Equal(t, "cloud.kubecloudscaler.scaledown.warning", ce["type"])
+	data, ok := ce["data"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "2025-03-10T19:00:00Z", data["scaleDownTime"])
+	assert.Equal(t, "K8s scaler staging is going to sleep at 19:00 UTC: period \"night\" scales 1 resources down\n"+
+		"- deployments default/api\nTo snooze it: "+snooze, data["message"])
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	t.Parallel()
+
+	server, received := newServer(t)
+	pager, paged := newServer(t)
+	dispatcher := newTestDispatcher(staticSource{{Name: "audit", URL: server.URL}})
+
+	dispatcher.Notify(context.Background(), []common.NotificationEndpoint{
+		{Name: "pager", URL: pager.URL, Events: []common.NotificationEvent{common.NotificationScalingFailed}},
+	}, testEvent(common.NotificationPeriodChanged), testEvent(common.NotificationScalingFailed))
+	dispatcher.wait()
+
+	// The operator-wide endpoint gets every event, the scaler's one only failures
+	assert.Len(t, received(), 2)
+	require.Len(t, paged(), 1)
+	assert.Contains(t, string(paged()[0].body), "cloud.kubecloudscaler.scaling.failed")
+	assert.Empty(t, paged()[0].header.Get(SignatureHeader))
+}
+
+func TestDispatcher_NotifyConcurrent(t *testing.T) {
+	t.Parallel()
+
+	// The operator-wide endpoints have spare capacity, as decoded from a ConfigMap
+	global := make(staticSource, 1, 4)
+	global[0] = common.NotificationEndpoint{Name: "audit", URL: "http://127.0.0.1:0", Events: []common.NotificationEvent{"none"}}
+	dispatcher := newTestDispatcher(global)
+
+	servers := map[string]func() []request{}
+	endpoints := map[string]common.NotificationEndpoint{}
+	for _, scaler := range []string{"staging", "preview"} {
+		server, received := newServer(t)
+		servers[scaler] = received
+		endpoints[scaler] = common.NotificationEndpoint{Name: scaler, URL: server.URL}
+	}
+
+	const calls = 20
+	var wg sync.WaitGroup
+	for i := range calls {
+		scaler := []string{"staging", "preview"}[i%2]
+		wg.Go(func() {
+			event := testEvent(common.NotificationPeriodChanged)
+			event.Scaler = scaler
+			dispatcher.Notify(context.Background(), []common.NotificationEndpoint{endpoints[scaler]}, event)
+		})
+	}
+	wg.Wait()
+	dispatcher.wait()
+
+	// Each scaler's endpoint only gets the events of its scaler
+	for scaler, received := range servers {
+		requests := received()
+		assert.Len(t, requests, calls/2)
+		for _, r := range requests {
+			assert.Contains(t, string(r.body), `"scaler":"`+scaler+`"`)
+		}
+	}
+}
+
+func TestDispatcher_NotifySecret(t *testing.T) {
+	t.Parallel()
+
+	server, received := newServer(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "slack-webhook"},
+		Data: map[string][]byte{
+			SecretKeyURL:        []byte(server.URL + "/services/T000/B000/XXXX"),
+			SecretKeySigningKey: []byte("s3cr3t"),
+		},
+	}
+	dispatcher := newTestDispatcher(nil, secret)
+
+	dispatcher.Notify(context.Background(), []common.NotificationEndpoint{
+		{Name: "slack", Format: common.NotificationFormatSlack, Secret: ptr.To("slack-webhook")},
+		{Name: "missing", Secret: ptr.To("missing")},
+	}, testEvent(common.NotificationPeriodChanged))
+	dispatcher.wait()
+
+	requests := received()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "application/json", requests[0].header.Get("Content-Type"))
+	assert.Equal(t, Sign([]byte("s3cr3t"), requests[0].body), requests[0].header.Get(SignatureHeader))
+}
+
+func TestDispatcher_Retries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		statuses []int
+		expect   int
+	}{
+		{name: "recovers from server errors", statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}, expect: 3},
+		{name: "gives up after the last attempt", statuses: []int{500, 500, 500, 500}, expect: DefaultAttempts},
+		{name: "does not retry client errors", statuses: []int{http.StatusBadRequest}, expect: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, received := newServer(t, tt.statuses...)
+			dispatcher := newTestDispatcher(nil)
+
+			dispatcher.Notify(context.Background(), []common.NotificationEndpoint{{Name: "events", URL: server.URL}},
+				testEvent(common.NotificationPeriodChanged))
+			dispatcher.wait()
+
+			assert.Len(t, received(), tt.expect)
+		})
+	}
+}
+
+func TestConfigMapSource_Endpoints(t *testing.T) {
+	t.Parallel()
+
+	key := types.NamespacedName{Namespace: testNamespace, Name: "notifications"}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Data: map[string]string{KeyEndpoints: `
+- name: audit
+  url: https://audit.example.com/events
+`},
+	}
+	reader := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := NewConfigMapSource(reader, key)
+	source.now = func() time.Time { return now }
+
+	endpoints, err := source.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []common.NotificationEndpoint{{Name: "audit", URL: "https://audit.example.com/events"}}, endpoints)
+
+	// Invalid endpoints are reported once the refresh interval elapsed
+	configMap.Data[KeyEndpoints] = "- name: audit\n"
+	require.NoError(t, reader.Update(context.Background(), configMap))
+
+	_, err = source.Endpoints(context.Background())
+	require.NoError(t, err)
+
+	now = now.Add(DefaultRefreshInterval)
+	_, err = source.Endpoints(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "endpoints[0]: either url or secret is required")
+}
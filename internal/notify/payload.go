@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+)
+
+// Content types of the payloads.
+const (
+	contentTypeCloudEvents = "application/cloudevents+json"
+	contentTypeJSON        = "application/json"
+)
+
+// cloudEventsSpecVersion is the version of the CloudEvents specification the events follow.
+const cloudEventsSpecVersion = "1.0"
+
+// cloudEventTypes maps events to the type of their CloudEvents.
+var cloudEventTypes = map[common.NotificationEvent]string{
+	common.NotificationPeriodChanged:    "cloud.kubecloudscaler.period.changed",
+	common.NotificationScalingFailed:    "cloud.kubecloudscaler.scaling.failed",
+	common.NotificationScalingRecovered: "cloud.kubecloudscaler.scaling.recovered",
+	common.NotificationScaleDownWarning: "cloud.kubecloudscaler.scaledown.warning",
+}
+
+// Theme colors of the Teams message cards.
+const (
+	teamsColorInfo    = "0076D7"
+	teamsColorFailure = "D70000"
+	teamsColorSuccess = "2EB886"
+	teamsColorWarning = "FFA500"
+)
+
+// defaultTemplate summarizes events when the endpoint has no template of its own.
+var defaultTemplate = template.Must(template.New("default").Parse(
+	`{{- if eq .Type "PeriodChanged" -}}
+{{ .Kind }} scaler {{ .Scaler }} is {{ if eq .PeriodType "down" }}going to sleep{{ else if eq .PeriodType "up" }}waking up{{ else }}restoring its resources{{ end }}: ` +
+		`period "{{ .Period }}" ({{ .PeriodType }}) applied to {{ .Succeeded }} resources{{ if .Failed }}, {{ .Failed }} failed{{ end }}
+{{- else if eq .Type "ScalingFailed" -}}
+{{ .Kind }} scaler {{ .Scaler }} failed to scale {{ .Failed }} resources for period "{{ .Period }}" ({{ .PeriodType }})
+{{- range .Failures }}
+- {{ .Kind }} {{ .Name }}: {{ .Reason }}
+{{- end }}
+{{- else if eq .Type "ScaleDownWarning" -}}
+{{ .Kind }} scaler {{ .Scaler }} is going to sleep at {{ .ScaleDownTime.Format "15:04 MST" }}: period "{{ .Period }}" scales {{ len .Resources }} resources down
+{{- range .Resources }}
+- {{ . }}
+{{- end }}
+To snooze it: {{ .Snooze }}
+{{- else -}}
+{{ .Kind }} scaler {{ .Scaler }} recovered: {{ .Succeeded }} resources are in the desired state for period "{{ .Period }}" ({{ .PeriodType }})
+{{- end -}}`))
+
+// cloudEvent is a CloudEvent in structured content mode.
+type cloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            string    `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            eventData `json:"data"`
+}
+
+// eventData is the data of a CloudEvent: the event and its message.
+type eventData struct {
+	Event
+	Message string `json:"message"`
+}
+
+// slackMessage is the payload of a Slack incoming webhook.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// teamsMessageCard is the payload of a Microsoft Teams incoming webhook.
+type teamsMessageCard struct {
+	Type       string `json:"@type"`
+	Context    string `json:"@context"`
+	ThemeColor string `json:"themeColor"`
+	Summary    string `json:"summary"`
+	Text       string `json:"text"`
+}
+
+// payload builds the body of the notification of event to endpoint, and its content type.
+func payload(endpoint common.NotificationEndpoint, event Event) ([]byte, string, error) {
+	message, err := renderMessage(endpoint, event)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var body []byte
+	contentType := contentTypeJSON
+	switch endpoint.Format {
+	case common.NotificationFormatSlack:
+		body, err = json.Marshal(slackMessage{Text: message})
+	case common.NotificationFormatTeams:
+		body, err = json.Marshal(teamsMessageCard{
+			Type:       "MessageCard",
+			Context:    "https://schema.org/extensions",
+			ThemeColor: teamsColor(event.Type),
+			Summary:    fmt.Sprintf("%s scaler %s: %s", event.Kind, event.Scaler, event.Type),
+			Text:       message,
+		})
+	default:
+		contentType = contentTypeCloudEvents
+		body, err = json.Marshal(cloudEvent{
+			SpecVersion:     cloudEventsSpecVersion,
+			ID:              uuid.NewString(),
+			Source:          fmt.Sprintf("/kubecloudscaler/%s/%s", strings.ToLower(event.Kind), event.Scaler),
+			Type:            cloudEventTypes[event.Type],
+			Subject:         event.Scaler,
+			Time:            event.Time.UTC().Format(time.RFC3339),
+			DataContentType: contentTypeJSON,
+			Data:            eventData{Event: event, Message: message},
+		})
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("error encoding notification: %w", err)
+	}
+
+	return body, contentType, nil
+}
+
+// renderMessage executes the template of endpoint, or the default one, with event.
+func renderMessage(endpoint common.NotificationEndpoint, event Event) (string, error) {
+	tmpl := defaultTemplate
+	if endpoint.Template != "" {
+		var err error
+		if tmpl, err = template.New(endpoint.Name).Parse(endpoint.Template); err != nil {
+			return "", fmt.Errorf("error parsing notification template: %w", err)
+		}
+	}
+
+	var message strings.Builder
+	if err := tmpl.Execute(&message, event); err != nil {
+		return "", fmt.Errorf("error executing notification template: %w", err)
+	}
+
+	return message.String(), nil
+}
+
+// teamsColor returns the theme color of the message card of an event.
+func teamsColor(event common.NotificationEvent) string {
+	switch event {
+	case common.NotificationScalingFailed:
+		return teamsColorFailure
+	case common.NotificationScalingRecovered:
+		return teamsColorSuccess
+	case common.NotificationScaleDownWarning:
+		return teamsColorWarning
+	default:
+		return teamsColorInfo
+	}
+}
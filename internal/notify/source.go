@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+)
+
+// KeyEndpoints is the key of the ConfigMap holding the operator-wide endpoints, as a YAML list.
+const KeyEndpoints = "endpoints"
+
+// DefaultRefreshInterval is how long the operator-wide endpoints are used before being read again.
+const DefaultRefreshInterval = time.Minute
+
+// EndpointSource provides the operator-wide notification endpoints, notified of every scaler.
+type EndpointSource interface {
+	Endpoints(ctx context.Context) ([]common.NotificationEndpoint, error)
+}
+
+// Parse reads the endpoints of the data of a ConfigMap.
+func Parse(data map[string]string) ([]common.NotificationEndpoint, error) {
+	var endpoints []common.NotificationEndpoint
+	if err := yaml.UnmarshalStrict([]byte(data[KeyEndpoints]), &endpoints); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", KeyEndpoints, err)
+	}
+
+	for i, endpoint := range endpoints {
+		if endpoint.Name == "" {
+			return nil, fmt.Errorf("%s[%d]: name is required", KeyEndpoints, i)
+		}
+		if err := endpoint.Validate(); err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", KeyEndpoints, i, err)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// ConfigMapSource reads the operator-wide endpoints from a ConfigMap, at most once per refresh
+// interval.
+type ConfigMapSource struct {
+	reader  client.Reader
+	key     types.NamespacedName
+	refresh time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	endpoints []common.NotificationEndpoint
+	err       error
+	loadedAt  time.Time
+}
+
+// NewConfigMapSource creates a source reading the endpoints from the ConfigMap key with reader,
+// usually an uncached reader so that the operator does not watch every ConfigMap.
+func NewConfigMapSource(reader client.Reader, key types.NamespacedName) *ConfigMapSource {
+	return &ConfigMapSource{
+		reader:  reader,
+		key:     key,
+		refresh: DefaultRefreshInterval,
+		now:     time.Now,
+	}
+}
+
+// Endpoints returns the endpoints, reading the ConfigMap again once the last read is older than
+// the refresh interval. Read and parse errors are kept until the next read.
+func (s *ConfigMapSource) Endpoints(ctx context.Context) ([]common.NotificationEndpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loadedAt.IsZero() && s.now().Sub(s.loadedAt) < s.refresh {
+		return s.endpoints, s.err
+	}
+
+	s.endpoints, s.err = s.load(ctx)
+	s.loadedAt = s.now()
+
+	return s.endpoints, s.err
+}
+
+// load reads and parses the ConfigMap.
+func (s *ConfigMapSource) load(ctx context.Context) ([]common.NotificationEndpoint, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := s.reader.Get(ctx, s.key, configMap); err != nil {
+		return nil, fmt.Errorf("error reading notification endpoints %s: %w", s.key, err)
+	}
+
+	endpoints, err := Parse(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing notification endpoints %s: %w", s.key, err)
+	}
+
+	return endpoints, nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pricing estimates the cost saved by scaling resources down, from a price table kept in
+// a ConfigMap.
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys of the price table ConfigMap.
+const (
+	// KeyCurrency holds the currency of the prices, reported along with the estimates.
+	KeyCurrency = "currency"
+	// KeyCPU holds the price of a CPU core requested for an hour.
+	KeyCPU = "cpu"
+	// KeyMemory holds the price of a GiB of memory requested for an hour.
+	KeyMemory = "memory"
+	// KeyMachineTypePrefix prefixes the keys holding the price of a GCP machine type running for
+	// an hour, such as machineType.e2-standard-4.
+	KeyMachineTypePrefix = "machineType."
+)
+
+// DefaultRefreshInterval is how long a price table is used before being read again.
+const DefaultRefreshInterval = time.Minute
+
+// bytesPerGiB converts memory requests to the unit of the memory price.
+const bytesPerGiB = 1 << 30
+
+// Table holds the prices used to estimate savings. Missing prices count as zero.
+type Table struct {
+	// Currency of the prices
+	Currency string
+	// CPU is the price of a CPU core per hour
+	CPU float64
+	// Memory is the price of a GiB of memory per hour
+	Memory float64
+	// MachineTypes maps GCP machine types to their price per hour
+	MachineTypes map[string]float64
+}
+
+// Parse builds a price table from the data of a ConfigMap.
+func Parse(data map[string]string) (*Table, error) {
+	table := &Table{
+		Currency:     data[KeyCurrency],
+		MachineTypes: map[string]float64{},
+	}
+
+	for key, value := range data {
+		if key == KeyCurrency {
+			continue
+		}
+
+		price, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || price < 0 {
+			return nil, fmt.Errorf("invalid price %q for %s: must be a non-negative number", value, key)
+		}
+
+		switch {
+		case key == KeyCPU:
+			table.CPU = price
+		case key == KeyMemory:
+			table.Memory = price
+		case strings.HasPrefix(key, KeyMachineTypePrefix):
+			table.MachineTypes[strings.TrimPrefix(key, KeyMachineTypePrefix)] = price
+		default:
+			return nil, fmt.Errorf("unknown price table key %q", key)
+		}
+	}
+
+	return table, nil
+}
+
+// RequestsRate returns the hourly price of the CPU and memory in requests.
+func (t *Table) RequestsRate(requests corev1.ResourceList) float64 {
+	cores := requests.Cpu().AsApproximateFloat64()
+	gib := requests.Memory().AsApproximateFloat64() / bytesPerGiB
+
+	return cores*t.CPU + gib*t.Memory
+}
+
+// MachineTypeRate returns the hourly price of machineType, and whether it is known.
+func (t *Table) MachineTypeRate(machineType string) (float64, bool) {
+	price, ok := t.MachineTypes[machineType]
+	return price, ok
+}
+
+// Source provides the current price table.
+type Source interface {
+	Table(ctx context.Context) (*Table, error)
+}
+
+// ConfigMapSource reads the price table from a ConfigMap, at most once per refresh interval.
+type ConfigMapSource struct {
+	reader  client.Reader
+	key     types.NamespacedName
+	refresh time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	table    *Table
+	err      error
+	loadedAt time.Time
+}
+
+// NewConfigMapSource creates a source reading the price table from the ConfigMap key with reader,
+// usually an uncached reader so that the operator does not watch every ConfigMap.
+func NewConfigMapSource(reader client.Reader, key types.NamespacedName) *ConfigMapSource {
+	return &ConfigMapSource{
+		reader:  reader,
+		key:     key,
+		refresh: DefaultRefreshInterval,
+		now:     time.Now,
+	}
+}
+
+// Table returns the price table, reading the ConfigMap again once the last read is older than
+// the refresh interval. Read and parse errors are kept until the next read.
+func (s *ConfigMapSource) Table(ctx context.Context) (*Table, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loadedAt.IsZero() && s.now().Sub(s.loadedAt) < s.refresh {
+		return s.table, s.err
+	}
+
+	s.table, s.err = s.load(ctx)
+	s.loadedAt = s.now()
+
+	return s.table, s.err
+}
+
+// load reads and parses the ConfigMap.
+func (s *ConfigMapSource) load(ctx context.Context) (*Table, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := s.reader.Get(ctx, s.key, configMap); err != nil {
+		return nil, fmt.Errorf("error reading price table %s: %w", s.key, err)
+	}
+
+	table, err := Parse(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing price table %s: %w", s.key, err)
+	}
+
+	return table, nil
+}
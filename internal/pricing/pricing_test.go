@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		data            map[string]string
+		expect          *Table
+		wantErrContains string
+	}{
+		{
+			name: "all prices",
+			data: map[string]string{
+				"currency":                  "EUR",
+				"cpu":                       "0.03",
+				"memory":                    " 0.004 ",
+				"machineType.e2-standard-4": "0.15",
+			},
+			expect: &Table{Currency: "EUR", CPU: 0.03, Memory: 0.004, MachineTypes: map[string]float64{"e2-standard-4": 0.15}},
+		},
+		{
+			name:   "missing prices count as zero",
+			data:   map[string]string{"cpu": "0.03"},
+			expect: &Table{CPU: 0.03, MachineTypes: map[string]float64{}},
+		},
+		{
+			name:            "invalid price",
+			data:            map[string]string{"cpu": "cheap"},
+			wantErrContains: `invalid price "cheap" for cpu`,
+		},
+		{
+			name:            "negative price",
+			data:            map[string]string{"memory": "-1"},
+			wantErrContains: `invalid price "-1" for memory`,
+		},
+		{
+			name:            "unknown key",
+			data:            map[string]string{"gpu": "2"},
+			wantErrContains: `unknown price table key "gpu"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			table, err := Parse(tt.data)
+
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, table)
+		})
+	}
+}
+
+func TestTable_Rates(t *testing.T) {
+	t.Parallel()
+
+	table := &Table{CPU: 0.04, Memory: 0.005, MachineTypes: map[string]float64{"e2-small": 0.02}}
+
+	rate := table.RequestsRate(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1500m"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+	})
+	assert.InDelta(t, 0.08, rate, 1e-9)
+	assert.Zero(t, table.RequestsRate(nil))
+
+	price, ok := table.MachineTypeRate("e2-small")
+	assert.True(t, ok)
+	assert.InDelta(t, 0.02, price, 1e-9)
+	_, ok = table.MachineTypeRate("n2-standard-8")
+	assert.False(t, ok)
+}
+
+func TestConfigMapSource_Table(t *testing.T) {
+	t.Parallel()
+
+	key := types.NamespacedName{Namespace: "kubecloudscaler-system", Name: "prices"}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Data:       map[string]string{"cpu": "0.03"},
+	}
+	reader := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := NewConfigMapSource(reader, key)
+	source.now = func() time.Time { return now }
+
+	table, err := source.Table(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 0.03, table.CPU, 1e-9)
+
+	// Changes are picked up once the refresh interval elapsed
+	configMap.Data["cpu"] = "0.05"
+	require.NoError(t, reader.Update(context.Background(), configMap))
+
+	table, err = source.Table(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 0.03, table.CPU, 1e-9)
+
+	now = now.Add(DefaultRefreshInterval)
+	table, err = source.Table(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 0.05, table.CPU, 1e-9)
+}
+
+func TestConfigMapSource_TableMissing(t *testing.T) {
+	t.Parallel()
+
+	source := NewConfigMapSource(fake.NewClientBuilder().Build(), types.NamespacedName{Namespace: "default", Name: "prices"})
+
+	_, err := source.Table(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error reading price table default/prices")
+}
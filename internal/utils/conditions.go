@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+)
+
+// Reasons of the conditions set on the status of K8s and Gcp scalers.
+const (
+	ReasonReconciled     = "Reconciled"
+	ReasonScalingFailed  = "ScalingFailed"
+	ReasonInvalidPeriod  = "InvalidPeriod"
+	ReasonPeriodActive   = "PeriodActive"
+	ReasonNoActivePeriod = "NoActivePeriod"
+	ReasonSuspended      = "Suspended"
+	ReasonNotSuspended   = "NotSuspended"
+)
+
+// SetReconciledConditions updates the conditions, resource counts and observed generation of
+// status from the results recorded in status.CurrentPeriod by a reconciliation of a scaler
+// suspended in mode, if any.
+func SetReconciledConditions(status *common.ScalerStatus, generation int64, suspend common.SuspendMode) {
+	status.ObservedGeneration = generation
+
+	var succeeded, failed, postponed int32
+	periodName, periodType := "", periodPkg.NoactionPeriodName
+	if cp := status.CurrentPeriod; cp != nil {
+		succeeded, postponed = cp.Outcomes()
+		failed = int32(len(cp.Failed)) //nolint:gosec // bounded by the number of resources
+		periodName, periodType = cp.Name, cp.Type
+	}
+
+	if suspend != "" {
+		setCondition(status, generation, common.ConditionSuspended, metav1.ConditionTrue, ReasonSuspended,
+			fmt.Sprintf("Scaling is suspended in %s mode", suspend))
+	} else {
+		setCondition(status, generation, common.ConditionSuspended, metav1.ConditionFalse, ReasonNotSuspended, "Scaling is not suspended")
+	}
+
+	// A frozen scaler leaves its resources alone: the results of the last reconciliation no longer apply
+	if suspend == common.SuspendFreeze {
+		message := "Resources are left in their current state"
+		setCondition(status, generation, common.ConditionScaling, metav1.ConditionFalse, ReasonSuspended, message)
+		setCondition(status, generation, common.ConditionReady, metav1.ConditionTrue, ReasonSuspended, message)
+		setCondition(status, generation, common.ConditionDegraded, metav1.ConditionFalse, ReasonSuspended, message)
+		return
+	}
+
+	status.Resources = &common.ScalerStatusResources{
+		Managed:   succeeded + failed + postponed,
+		Succeeded: succeeded,
+		Failed:    failed,
+		Postponed: postponed,
+		Clusters:  clusterResources(status.CurrentPeriod),
+	}
+
+	if periodType == periodPkg.NoactionPeriodName {
+		setCondition(status, generation, common.ConditionScaling, metav1.ConditionFalse, ReasonNoActivePeriod,
+			"Resources are kept in their original state")
+	} else {
+		setCondition(status, generation, common.ConditionScaling, metav1.ConditionTrue, ReasonPeriodActive,
+			fmt.Sprintf("Period %q (%s) is applied", periodName, periodType))
+	}
+
+	if failed > 0 {
+		message := fmt.Sprintf("%d of %d resources failed to scale", failed, succeeded+failed+postponed)
+		setCondition(status, generation, common.ConditionReady, metav1.ConditionFalse, ReasonScalingFailed, message)
+		setCondition(status, generation, common.ConditionDegraded, metav1.ConditionTrue, ReasonScalingFailed, message)
+		return
+	}
+
+	message := fmt.Sprintf("%d resources are in the desired state", succeeded)
+	if postponed > 0 {
+		message += fmt.Sprintf(", the scale-down of %d is postponed", postponed)
+	}
+	setCondition(status, generation, common.ConditionReady, metav1.ConditionTrue, ReasonReconciled, message)
+	setCondition(status, generation, common.ConditionDegraded, metav1.ConditionFalse, ReasonReconciled, message)
+}
+
+// clusterResources returns the resource counts of each cluster of the results of cp, in the order
+// of the results, or nil when the results are not tagged with clusters.
+func clusterResources(cp *common.ScalerStatusPeriod) []common.ScalerStatusClusterResources {
+	if cp == nil {
+		return nil
+	}
+
+	var clusters []common.ScalerStatusClusterResources
+	count := func(name string) *common.ScalerStatusClusterResources {
+		for i := range clusters {
+			if clusters[i].Name == name {
+				clusters[i].Managed++
+				return &clusters[i]
+			}
+		}
+		clusters = append(clusters, common.ScalerStatusClusterResources{Name: name, Managed: 1})
+		return &clusters[len(clusters)-1]
+	}
+
+	for _, s := range cp.Successful {
+		switch {
+		case s.Cluster == "":
+		case s.PostponedUntil != nil:
+			count(s.Cluster).Postponed++
+		default:
+			count(s.Cluster).Succeeded++
+		}
+	}
+	for _, f := range cp.Failed {
+		if f.Cluster != "" {
+			count(f.Cluster).Failed++
+		}
+	}
+	return clusters
+}
+
+// SetInvalidPeriodConditions marks status as not ready and degraded because the periods could
+// not be evaluated.
+func SetInvalidPeriodConditions(status *common.ScalerStatus, generation int64, err error) {
+	status.ObservedGeneration = generation
+	setCondition(status, generation, common.ConditionReady, metav1.ConditionFalse, ReasonInvalidPeriod, err.Error())
+	setCondition(status, generation, common.ConditionDegraded, metav1.ConditionTrue, ReasonInvalidPeriod, err.Error())
+}
+
+// setCondition sets a condition on status. Its transition time only changes with its status.
+func setCondition(
+	status *common.ScalerStatus, generation int64, conditionType string,
+	conditionStatus metav1.ConditionStatus, reason, message string,
+) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
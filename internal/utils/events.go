@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/consts"
+	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+)
+
+// EventRecorderName is the reporting controller of the events recorded by the operator.
+const EventRecorderName = "kubecloudscaler"
+
+// ScalerEvents returns recorder, or nil when events are disabled for the scaler.
+func ScalerEvents(recorder events.EventRecorder, disabled bool) events.EventRecorder {
+	if disabled {
+		return nil
+	}
+
+	return recorder
+}
+
+// RecordPeriodChange records a PeriodChanged event on scaler when the active period differs
+// from the previous one. prev is the period status captured before SetActivePeriod ran.
+func RecordPeriodChange(recorder events.EventRecorder, scaler runtime.Object, prev *common.ScalerStatusPeriod, period *periodPkg.Period) {
+	if recorder == nil || period == nil {
+		return
+	}
+
+	from := "none"
+	if prev != nil {
+		if prev.Name == period.Name && prev.Type == string(period.Type) {
+			return
+		}
+		from = fmt.Sprintf("%q (%s)", prev.Name, prev.Type)
+	}
+
+	recorder.Eventf(scaler, nil, coreV1.EventTypeNormal, consts.EventReasonPeriodChanged, consts.EventActionChangePeriod,
+		"Period changed from %s to %q (%s)", from, period.Name, period.Type)
+}
+
+// RecordScalingFailures records a ScalingFailed warning on scaler for each failed resource.
+func RecordScalingFailures(recorder events.EventRecorder, scaler runtime.Object, failed []common.ScalerStatusFailed) {
+	if recorder == nil {
+		return
+	}
+
+	for _, f := range failed {
+		recorder.Eventf(scaler, nil, coreV1.EventTypeWarning, consts.EventReasonScalingFailed, consts.EventActionScale,
+			"Unable to scale %s %s: %s", f.Kind, f.Name, f.Reason)
+	}
+}
+
+// maxWarnedResources bounds the resources listed in ScaleDownWarning events.
+const maxWarnedResources = 5
+
+// RecordScaleDownWarning records a ScaleDownWarning event on scaler, listing the resources about
+// to be scaled down and the command snoozing the scale-down.
+func RecordScaleDownWarning(
+	recorder events.EventRecorder, scaler runtime.Object, warning *common.ScalerStatusWarning, resources []string, snooze string,
+) {
+	if recorder == nil {
+		return
+	}
+
+	listed := resources
+	more := ""
+	if len(listed) > maxWarnedResources {
+		listed, more = listed[:maxWarnedResources], fmt.Sprintf(" and %d more", len(resources)-maxWarnedResources)
+	}
+
+	recorder.Eventf(scaler, nil, coreV1.EventTypeNormal, consts.EventReasonScaleDownWarning, consts.EventActionWarn,
+		"Period %q scales %d resources down in %s, at %s: %s%s. To snooze it: %s",
+		warning.Period, len(resources), warning.ScaleDownTime.Sub(warning.Time.Time).Round(time.Second),
+		warning.ScaleDownTime.UTC().Format(time.RFC3339), strings.Join(listed, ", "), more, snooze)
+}
+
+// RecordHookFailure records a HookFailed warning on scaler for the failed hook run, noting when
+// it blocks the transition to period.
+func RecordHookFailure(recorder events.EventRecorder, scaler runtime.Object, hook *common.ScalerStatusHook, period string, blocking bool) {
+	if recorder == nil {
+		return
+	}
+
+	effect := ""
+	if blocking {
+		effect = fmt.Sprintf(", transition to period %q blocked", period)
+	}
+	recorder.Eventf(scaler, nil, coreV1.EventTypeWarning, consts.EventReasonHookFailed, consts.EventActionRunHook,
+		"%s-hook %q failed: %s%s", hook.Phase, hook.Name, hook.Message, effect)
+}
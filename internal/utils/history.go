@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+)
+
+const (
+	// HistoryLimit is the number of entries kept in the history of a scaler.
+	HistoryLimit = 10
+	// HistoryFailedItemsLimit is the number of failed resources kept in a history entry.
+	HistoryFailedItemsLimit = 10
+)
+
+// RecordHistory adds the outcome of the reconciliation recorded in status.CurrentPeriod to the
+// history of status when the period changed, or when resources started or stopped failing.
+// The history is bounded to HistoryLimit entries, newest first.
+func RecordHistory(status *common.ScalerStatus, now metav1.Time) {
+	cp := status.CurrentPeriod
+	if cp == nil {
+		return
+	}
+
+	succeeded, postponed := cp.Outcomes()
+	entry := common.ScalerStatusHistory{
+		Time:        now,
+		Name:        cp.Name,
+		Type:        cp.Type,
+		Succeeded:   succeeded,
+		Failed:      int32(len(cp.Failed)), //nolint:gosec // bounded by the number of resources
+		Postponed:   postponed,
+		FailedItems: slices.Clone(cp.Failed[:min(len(cp.Failed), HistoryFailedItemsLimit)]),
+	}
+
+	if len(status.History) > 0 && sameOutcome(&status.History[0], &entry) {
+		return
+	}
+
+	status.History = slices.Insert(status.History, 0, entry)
+	if len(status.History) > HistoryLimit {
+		status.History = status.History[:HistoryLimit]
+	}
+}
+
+// sameOutcome reports whether a and b are for the same period, the same failed resources,
+// whatever the reasons of the failures, and the same number of postponed resources.
+func sameOutcome(a, b *common.ScalerStatusHistory) bool {
+	if a.Name != b.Name || a.Type != b.Type || a.Failed != b.Failed || a.Postponed != b.Postponed {
+		return false
+	}
+
+	return slices.EqualFunc(a.FailedItems, b.FailedItems, func(x, y common.ScalerStatusFailed) bool {
+		return x.Kind == y.Kind && x.Name == y.Name
+	})
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+)
+
+// savingsPrecision is the number of decimals of the amounts reported in status.
+const savingsPrecision = 4
+
+// AccrueSavings adds to the savings of status the cost saved at the previous hourly rate since
+// their last update, then records hourlyRate as the rate of the resources now scaled down. The
+// estimation starts over when the currency changes.
+func AccrueSavings(status *common.ScalerStatus, hourlyRate float64, currency string, now metav1.Time) {
+	savings := status.Savings
+	if savings == nil || savings.Currency != currency {
+		status.Savings = &common.ScalerStatusSavings{
+			Currency:       currency,
+			HourlyRate:     formatAmount(hourlyRate),
+			Total:          formatAmount(0),
+			Since:          now,
+			LastUpdateTime: now,
+		}
+		return
+	}
+
+	total := ParseAmount(savings.Total)
+	if elapsed := now.Sub(savings.LastUpdateTime.Time); elapsed > 0 {
+		total += ParseAmount(savings.HourlyRate) * elapsed.Hours()
+	}
+
+	savings.HourlyRate = formatAmount(hourlyRate)
+	savings.Total = formatAmount(total)
+	savings.LastUpdateTime = now
+}
+
+// ParseAmount parses an amount reported in status; invalid amounts count as zero.
+func ParseAmount(amount string) float64 {
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// formatAmount formats an amount reported in status.
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', savingsPrecision, 64)
+}
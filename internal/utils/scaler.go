@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/internal/notify"
+)
+
+// Scaler gives the period handling shared by the K8s and Gcp controllers access to a scaler,
+// whatever its kind.
+type Scaler interface {
+	// Object returns the scaler resource, as read and patched through the client.
+	Object() client.Object
+	// Spec returns the part of the spec shared by all scalers.
+	Spec() ScalerSpec
+	// Status returns the status of the scaler, updated in place.
+	Status() *common.ScalerStatus
+	// ClearOverride removes the manual override from the spec.
+	ClearOverride()
+	// Empty returns a scaler of the same kind to read the latest version of the resource into.
+	Empty() Scaler
+	// DeepCopy returns a copy of the scaler, the base of the patches made to it.
+	DeepCopy() Scaler
+}
+
+// ScalerSpec is the part of the spec shared by all scalers.
+type ScalerSpec struct {
+	Periods       []common.ScalerPeriod
+	Override      *common.ScalerOverride
+	Suspend       common.SuspendMode
+	DryRun        bool
+	DisableEvents bool
+	Notifications []common.NotificationEndpoint
+}
+
+// ScalerReconciliation holds what the shared period handling needs from the reconciliation of
+// a scaler.
+type ScalerReconciliation struct {
+	Ctx      context.Context
+	Client   client.Client
+	Logger   *zerolog.Logger
+	Events   events.EventRecorder
+	Notifier notify.Notifier
+	Scaler   Scaler
+	// Kind is the kind of the scaler in notifications.
+	Kind string
+	// SnoozeResource is the kubectl resource type of the scaler, used in snooze commands.
+	SnoozeResource string
+	// ShouldFinalize is set while the scaler is being deleted.
+	ShouldFinalize bool
+	// RequeueAfter is the requeue delay of the reconciliation, only set while still zero.
+	RequeueAfter *time.Duration
+}
+
+// requeue reconciles the scaler again after d, unless an earlier handler set a delay already.
+func (r *ScalerReconciliation) requeue(d time.Duration) {
+	if *r.RequeueAfter == 0 {
+		*r.RequeueAfter = d
+	}
+}
+
+// ApplyOverride returns the manual override in effect, removing it from the scaler once expired.
+// While active, the scaler is requeued for the moment the override expires so it drops back to
+// its periods.
+func ApplyOverride(r *ScalerReconciliation) *common.ScalerStatusOverride {
+	spec := r.Scaler.Spec()
+	if r.ShouldFinalize || spec.Suspend != "" || spec.Override == nil {
+		return nil
+	}
+
+	now := time.Now()
+	override := ActiveOverride(spec.Override, r.Scaler.Status().CurrentPeriod, now)
+	if override == nil {
+		r.Logger.Info().Time("until", spec.Override.Until.Time).Msg("manual override expired, removing it")
+		if err := removeExpiredOverride(r.Ctx, r.Client, r.Scaler, now); err != nil {
+			r.Logger.Warn().Err(err).Msg("failed to remove expired override")
+		}
+		return nil
+	}
+
+	r.Logger.Info().Str("type", string(override.Type)).Time("until", override.Until.Time).Msg("manual override active")
+	r.requeue(override.Until.Sub(now))
+
+	return override
+}
+
+// PatchStatus persists the in-memory status of scaler via a status-subresource patch with
+// optimistic locking and retry on conflict, so spec is never transmitted. NotFound is a no-op:
+// the scaler was deleted in the meantime and there is nothing to patch.
+func PatchStatus(ctx context.Context, c client.Client, scaler Scaler) error {
+	desired := scaler.Status().DeepCopy()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := scaler.Empty()
+		if err := c.Get(ctx, client.ObjectKeyFromObject(scaler.Object()), latest.Object()); err != nil {
+			return err
+		}
+		patch := client.MergeFromWithOptions(latest.DeepCopy().Object(), client.MergeFromWithOptimisticLock{})
+		*latest.Status() = *desired.DeepCopy()
+		return c.Status().Patch(ctx, latest.Object(), patch)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// removeExpiredOverride clears spec.override via an optimistic-locked merge patch with retry on
+// conflict. An override replaced in the meantime by one still active is left untouched.
+func removeExpiredOverride(ctx context.Context, c client.Client, scaler Scaler, now time.Time) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := scaler.Empty()
+		if err := c.Get(ctx, client.ObjectKeyFromObject(scaler.Object()), latest.Object()); err != nil {
+			return err
+		}
+		if override := latest.Spec().Override; override == nil || override.Until.After(now) {
+			return nil
+		}
+		patch := client.MergeFromWithOptions(latest.DeepCopy().Object(), client.MergeFromWithOptimisticLock{})
+		latest.ClearOverride()
+		return c.Patch(ctx, latest.Object(), patch)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// WarnScaleDown records an event and notifies the endpoints of the scaler when a warning of its
+// next down period is due, then records the warning in status so that it is sent once. It runs
+// before the active period is resolved, so that the status persisted along still describes the
+// last reconciliation, whose resources are listed in the warning.
+func WarnScaleDown(r *ScalerReconciliation) {
+	spec, status := r.Scaler.Spec(), r.Scaler.Status()
+	if r.ShouldFinalize || spec.Suspend != "" || spec.DryRun {
+		return
+	}
+
+	now := time.Now()
+	warning, next := DueWarning(spec.Periods, spec.Override, status.LastWarning, now)
+	// Reconcile again in time for the next warning
+	if !next.IsZero() && next.Sub(now) < ReconcileSuccessDuration {
+		r.requeue(next.Sub(now))
+	}
+	if warning == nil {
+		return
+	}
+
+	name := r.Scaler.Object().GetName()
+	resources := ManagedResources(status)
+	snooze := SnoozeCommand(r.SnoozeResource, name, warning.ScaleDownTime.Add(SnoozeDuration))
+	RecordScaleDownWarning(ScalerEvents(r.Events, spec.DisableEvents), r.Scaler.Object(), warning, resources, snooze)
+	if r.Notifier != nil {
+		r.Notifier.Notify(r.Ctx, spec.Notifications, notify.WarningEvent(r.Kind, name, warning, resources, snooze))
+	}
+	r.Logger.Info().Str("period", warning.Period).Time("scaleDown", warning.ScaleDownTime.Time).
+		Dur("before", warning.Before.Duration).Msg("scale-down warning sent")
+
+	status.LastWarning = warning
+	if err := PatchStatus(r.Ctx, r.Client, r.Scaler); err != nil {
+		r.Logger.Warn().Err(err).Msg("failed to persist the scale-down warning")
+	}
+}
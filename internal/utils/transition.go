@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+)
+
+// NextTransition returns the first time after now at which the active period of a scaler may
+// change: the expiry of its manual override while one is in effect, else the next start or end
+// of one of its periods. It returns false when none of them starts nor ends again. Invalid
+// periods are left out; the period handlers report them.
+func NextTransition(periods []common.ScalerPeriod, override *common.ScalerOverride, now time.Time) (time.Time, bool) {
+	if override != nil && override.Until.After(now) {
+		return override.Until.Time, true
+	}
+
+	var next time.Time
+	for i := range periods {
+		transition, ok, err := periodPkg.NextTransition(&periods[i], now)
+		if err != nil || !ok {
+			continue
+		}
+		if next.IsZero() || transition.Before(next) {
+			next = transition
+		}
+	}
+
+	return next, !next.IsZero()
+}
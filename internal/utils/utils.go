@@ -2,12 +2,17 @@
 package utils
 
 import (
+	"time"
+
 	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/consts"
 	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
 )
 
@@ -31,6 +36,101 @@ func IgnoreDeletionPredicate() predicate.Predicate {
 	}
 }
 
+// WakeAnnotation is the scaler annotation set by the activator to keep it awake.
+const WakeAnnotation = consts.AnnotationsPrefix + "/" + consts.WakeUntil
+
+// WakeOverridePredicate returns a predicate that processes updates changing the wake annotation.
+// The activator only touches metadata, so these updates do not bump the generation.
+func WakeOverridePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetAnnotations()[WakeAnnotation] != e.ObjectNew.GetAnnotations()[WakeAnnotation]
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// WakeUntil returns the time until which obj is kept awake by the activator.
+// It returns false when the annotation is missing, malformed or already expired.
+func WakeUntil(obj metav1.Object, now time.Time) (time.Time, bool) {
+	value, ok := obj.GetAnnotations()[WakeAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil || !until.After(now) {
+		return time.Time{}, false
+	}
+
+	return until, true
+}
+
+// WakeReason is the reason of the override applied while the activator keeps a scaler awake.
+const WakeReason = "woken up by the activator"
+
+// WakeOverride returns the "up" override applied until until while the activator keeps a scaler
+// awake. It scales resources with the replicas of the first "up" period of periods, if any, so
+// the environment comes back as it runs during its scheduled up periods.
+func WakeOverride(periods []common.ScalerPeriod, until time.Time) *common.ScalerOverride {
+	override := &common.ScalerOverride{
+		Type:   common.OverrideTypeUp,
+		Until:  metav1.NewTime(until),
+		Reason: WakeReason,
+	}
+	for _, period := range periods {
+		if period.Type == common.PeriodTypeUp {
+			override.MinReplicas, override.MaxReplicas = period.MinReplicas, period.MaxReplicas
+			break
+		}
+	}
+	return override
+}
+
+// OverridePeriodName is the name of the synthetic period applied by a manual override.
+const OverridePeriodName = "override"
+
+// ActiveOverride returns the manual override in effect at now, or nil when none is set or it
+// expired. The time it took effect is carried over from prev while the override is unchanged,
+// so the synthetic period keeps the same start across reconciliations.
+func ActiveOverride(override *common.ScalerOverride, prev *common.ScalerStatusPeriod, now time.Time) *common.ScalerStatusOverride {
+	if override == nil || !override.Until.After(now) {
+		return nil
+	}
+
+	since := metav1.NewTime(now.Truncate(time.Second))
+	if prev != nil && prev.Override != nil && equality.Semantic.DeepEqual(prev.Override.ScalerOverride, *override) {
+		since = prev.Override.Since
+	}
+
+	return &common.ScalerStatusOverride{ScalerOverride: *override, Since: since}
+}
+
+// newOverridePeriod returns the synthetic fixed period applying override. A restore override
+// is a "noaction" period, so resources are brought back to their original state.
+func newOverridePeriod(override *common.ScalerStatusOverride) *common.ScalerPeriod {
+	periodType := common.PeriodType(override.Type)
+	if override.Type == common.OverrideTypeRestore {
+		periodType = common.PeriodType(periodPkg.NoactionPeriodName)
+	}
+
+	return &common.ScalerPeriod{
+		Type: periodType,
+		Name: OverridePeriodName,
+		Time: common.TimePeriod{
+			Fixed: &common.FixedPeriod{
+				StartTime: override.Since.UTC().Format(time.DateTime),
+				EndTime:   override.Until.UTC().Format(time.DateTime),
+				Timezone:  ptr.To("UTC"),
+			},
+		},
+		MinReplicas: override.MinReplicas,
+		MaxReplicas: override.MaxReplicas,
+	}
+}
+
 // newNoactionPeriod returns a fresh ScalerPeriod representing "no active period".
 // A factory is used instead of a package-level var to prevent callers from
 // accidentally mutating shared state.
@@ -50,10 +150,11 @@ func newNoactionPeriod() *common.ScalerPeriod {
 }
 
 // SetActivePeriod determines the active period from the given list and updates status as a side effect.
-// If forceRestore is true, the "noaction" period (spanning the entire day) is used unconditionally.
+// An active override takes precedence over the periods. If forceRestore is true, the "noaction"
+// period (spanning the entire day) is used unconditionally.
 func SetActivePeriod(
 	logger *zerolog.Logger, periods []*common.ScalerPeriod,
-	status *common.ScalerStatus, forceRestore bool,
+	status *common.ScalerStatus, override *common.ScalerStatusOverride, forceRestore bool,
 ) (*periodPkg.Period, error) {
 	// check we are in an active period
 	onPeriod, err := periodPkg.New(newNoactionPeriod())
@@ -63,7 +164,16 @@ func SetActivePeriod(
 		return nil, ErrLoadNoactionPeriod
 	}
 
-	if !forceRestore {
+	switch {
+	case forceRestore:
+		override = nil
+	case override != nil:
+		onPeriod, err = periodPkg.New(newOverridePeriod(override))
+		if err != nil {
+			logger.Error().Err(err).Msg("unable to load override period")
+			return nil, ErrLoadPeriod
+		}
+	default:
 		for _, period := range periods {
 			curPeriod, err := periodPkg.New(period)
 			if err != nil {
@@ -83,6 +193,13 @@ func SetActivePeriod(
 		return onPeriod, ErrRunOncePeriod
 	}
 
+	// Keep the time at which the period became active while it stays the same
+	transition := metav1.Now()
+	if prev := status.CurrentPeriod; prev != nil && prev.LastTransitionTime != nil &&
+		prev.Name == onPeriod.Name && prev.Type == string(onPeriod.Type) {
+		transition = *prev.LastTransitionTime
+	}
+
 	// prepare status
 	status.CurrentPeriod = &common.ScalerStatusPeriod{}
 	spec := onPeriod.OriginalTime
@@ -90,6 +207,8 @@ func SetActivePeriod(
 	status.CurrentPeriod.SpecSHA = onPeriod.Hash
 	status.CurrentPeriod.Type = string(onPeriod.Type)
 	status.CurrentPeriod.Name = onPeriod.Name
+	status.CurrentPeriod.Override = override
+	status.CurrentPeriod.LastTransitionTime = &transition
 
 	return onPeriod, nil
 }
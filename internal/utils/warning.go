@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+)
+
+// SnoozeDuration is how long the override suggested to snooze a scale-down keeps resources up.
+const SnoozeDuration = time.Hour
+
+// DueWarning returns the warning due at now before the next scale-down of a scaler, if any, and
+// the time at which its next warning is due (zero when none is). A warning is due once the
+// smallest of the warning offsets of the down period elapsed, unless last already covers it.
+// While a manual override is in effect, the scale-down happening when it expires is warned
+// about. Invalid periods are left out; the period handlers report them.
+func DueWarning(
+	periods []common.ScalerPeriod, override *common.ScalerOverride, last *common.ScalerStatusWarning, now time.Time,
+) (*common.ScalerStatusWarning, time.Time) {
+	var horizon time.Duration
+	for _, p := range periods {
+		for _, warning := range p.Warnings {
+			horizon = max(horizon, warning.Duration)
+		}
+	}
+	if horizon == 0 {
+		return nil, time.Time{}
+	}
+
+	at, index, ok := nextScaleDown(periods, override, now, now.Add(horizon))
+	if !ok {
+		return nil, time.Time{}
+	}
+
+	var due *time.Duration
+	var next time.Time
+	for _, warning := range periods[index].Warnings {
+		warnAt := at.Add(-warning.Duration)
+		switch {
+		case warnAt.After(now):
+			if next.IsZero() || warnAt.Before(next) {
+				next = warnAt
+			}
+		case due == nil || warning.Duration < *due:
+			due = &warning.Duration
+		}
+	}
+
+	if due == nil || (last != nil && last.ScaleDownTime.Time.Equal(at) && last.Before.Duration <= *due) {
+		return nil, next
+	}
+
+	return &common.ScalerStatusWarning{
+		Period:        periods[index].Name,
+		ScaleDownTime: metav1.NewTime(at),
+		Before:        metav1.Duration{Duration: *due},
+		Time:          metav1.NewTime(now),
+	}, next
+}
+
+// nextScaleDown returns the next time after now, and not after until, at which a period scales
+// the resources of a scaler down, and the index of that period.
+func nextScaleDown(
+	periods []common.ScalerPeriod, override *common.ScalerOverride, now, until time.Time,
+) (time.Time, int, bool) {
+	from := now
+	var down bool
+	if override != nil && override.Until.After(now) {
+		// The override holds the resources until it expires: periods apply again from then
+		from, down = override.Until.Time, override.Type == common.OverrideTypeDown
+		if from.After(until) {
+			return time.Time{}, -1, false
+		}
+
+		active, err := periodPkg.Active(periods, from.Add(time.Second))
+		if err != nil {
+			return time.Time{}, -1, false
+		}
+		if active >= 0 && periods[active].Type == common.PeriodTypeDown && !down {
+			return from, active, true
+		}
+	} else {
+		active, err := periodPkg.Active(periods, now)
+		if err != nil {
+			return time.Time{}, -1, false
+		}
+		down = active >= 0 && periods[active].Type == common.PeriodTypeDown
+	}
+
+	at, index, ok, err := periodPkg.NextScaleDown(periods, from, until, down)
+	if err != nil {
+		return time.Time{}, -1, false
+	}
+	return at, index, ok
+}
+
+// ManagedResources lists the resources handled by the last reconciliation recorded in status,
+// as "kind name", followed by the cluster of the resource, if any.
+func ManagedResources(status *common.ScalerStatus) []string {
+	cp := status.CurrentPeriod
+	if cp == nil {
+		return nil
+	}
+
+	resources := make([]string, 0, len(cp.Successful)+len(cp.Failed))
+	for _, s := range cp.Successful {
+		resources = append(resources, managedResource(s.Kind, s.Name, s.Cluster))
+	}
+	for _, f := range cp.Failed {
+		resources = append(resources, managedResource(f.Kind, f.Name, f.Cluster))
+	}
+	return resources
+}
+
+// managedResource describes a resource by its kind and name, followed by its cluster, if any.
+func managedResource(kind, name, cluster string) string {
+	if cluster == "" {
+		return kind + " " + name
+	}
+	return fmt.Sprintf("%s %s (%s)", kind, name, cluster)
+}
+
+// SnoozeCommand returns the kubectl command keeping the resources of the scaler name, of the
+// resource type resource (k8s or gcps), up until the given time.
+func SnoozeCommand(resource, name string, until time.Time) string {
+	return fmt.Sprintf(`kubectl patch %s %s --type merge -p '{"spec":{"override":{"type":"up","until":"%s"}}}'`,
+		resource, name, until.UTC().Format(time.RFC3339))
+}
@@ -79,5 +79,13 @@ func (v *GcpCustomValidator) validateGcp(gcp *kubecloudscalerv1alpha3.Gcp) error
 		}
 	}
 
+	if err := validateOverride(gcp.Spec.Override); err != nil {
+		return err
+	}
+
+	if err := validateNotifications(gcp.Spec.Config.Notifications); err != nil {
+		return err
+	}
+
 	return nil
 }
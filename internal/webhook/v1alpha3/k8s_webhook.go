@@ -75,5 +75,37 @@ func (v *K8sCustomValidator) validateK8s(k8s *kubecloudscalerv1alpha3.K8s) error
 		}
 	}
 
+	if err := validateOverride(k8s.Spec.Override); err != nil {
+		return err
+	}
+
+	if err := validateNotifications(k8s.Spec.Config.Notifications); err != nil {
+		return err
+	}
+
+	if err := validateNamespaceSelector(k8s.Spec.Config.NamespaceSelector); err != nil {
+		return err
+	}
+
+	if err := validateSleepingService(k8s.Spec.Resources.Types, k8s.Spec.Config.SleepingService); err != nil {
+		return err
+	}
+
+	if err := validateScalingGroups(k8s.Spec.Resources.Types, k8s.Spec.Config.ScalingGroups); err != nil {
+		return err
+	}
+
+	if err := validateReadinessTimeouts(k8s.Spec.Config); err != nil {
+		return err
+	}
+
+	if err := validateHooks(k8s.Spec.Config.Hooks); err != nil {
+		return err
+	}
+
+	if err := validateClusters(k8s.Spec.Config); err != nil {
+		return err
+	}
+
 	return nil
 }
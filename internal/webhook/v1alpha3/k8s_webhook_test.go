@@ -18,9 +18,12 @@ package v1alpha3
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
@@ -100,6 +103,299 @@ var _ = Describe("K8s Webhook Validation", func() {
 			Expect(err.Error()).To(ContainSubstring("type must be 'up' or 'down'"))
 			Expect(warnings).To(BeNil())
 		})
+
+		It("should require a sleeping service for routing resources", func() {
+			k8s := &kubecloudscalerv1alpha3.K8s{
+				Spec: kubecloudscalerv1alpha3.K8sSpec{
+					Periods: []common.ScalerPeriod{
+						{
+							Type: common.PeriodTypeDown,
+							Time: common.TimePeriod{
+								Recurring: &common.RecurringPeriod{
+									Days:      []common.DayOfWeek{common.DayAll},
+									StartTime: "20:00",
+									EndTime:   "07:00",
+								},
+							},
+						},
+					},
+					Resources: common.Resources{
+						Types: []common.ResourceKind{common.ResourceIngresses},
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config.sleepingService is required"))
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Config.SleepingService = &common.SleepingService{Name: "sleeping", Port: 80}
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+		It("should reject scaling groups selecting unmanaged resource types", func() {
+			k8s := &kubecloudscalerv1alpha3.K8s{
+				Spec: kubecloudscalerv1alpha3.K8sSpec{
+					Periods: []common.ScalerPeriod{
+						{
+							Type: common.PeriodTypeDown,
+							Time: common.TimePeriod{
+								Recurring: &common.RecurringPeriod{
+									Days:      []common.DayOfWeek{common.DayAll},
+									StartTime: "20:00",
+									EndTime:   "07:00",
+								},
+							},
+						},
+					},
+					Resources: common.Resources{
+						Types: []common.ResourceKind{common.ResourceDeployments},
+					},
+					Config: kubecloudscalerv1alpha3.K8sConfig{
+						ScalingGroups: []kubecloudscalerv1alpha3.K8sScalingGroup{
+							{Name: "databases", Types: []common.ResourceKind{common.ResourceStatefulSets}},
+						},
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config.scalingGroups[0]: statefulsets is not managed by the scaler"))
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Resources.Types = append(k8s.Spec.Resources.Types, common.ResourceStatefulSets)
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+
+		It("should reject readiness timeouts above the maximum", func() {
+			k8s := &kubecloudscalerv1alpha3.K8s{
+				Spec: kubecloudscalerv1alpha3.K8sSpec{
+					Periods: []common.ScalerPeriod{
+						{
+							Type: common.PeriodTypeDown,
+							Time: common.TimePeriod{
+								Recurring: &common.RecurringPeriod{
+									Days:      []common.DayOfWeek{common.DayAll},
+									StartTime: "20:00",
+									EndTime:   "07:00",
+								},
+							},
+						},
+					},
+					Config: kubecloudscalerv1alpha3.K8sConfig{
+						ReadinessTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+						ScalingGroups: []kubecloudscalerv1alpha3.K8sScalingGroup{
+							{Name: "databases", ReadinessTimeout: &metav1.Duration{Duration: time.Hour}},
+						},
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config.scalingGroups[0].readinessTimeout must be positive and at most 15m0s"))
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Config.ScalingGroups[0].ReadinessTimeout.Duration = 15 * time.Minute
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+		It("should reject hooks without exactly one of job and http", func() {
+			k8s := &kubecloudscalerv1alpha3.K8s{
+				Spec: kubecloudscalerv1alpha3.K8sSpec{
+					Periods: []common.ScalerPeriod{
+						{
+							Type: common.PeriodTypeDown,
+							Time: common.TimePeriod{
+								Recurring: &common.RecurringPeriod{
+									Days:      []common.DayOfWeek{common.DayAll},
+									StartTime: "20:00",
+									EndTime:   "07:00",
+								},
+							},
+						},
+					},
+					Config: kubecloudscalerv1alpha3.K8sConfig{
+						Hooks: []kubecloudscalerv1alpha3.K8sHook{
+							{Name: "backup", Phase: kubecloudscalerv1alpha3.HookPhasePre},
+						},
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config.hooks[0]: exactly one of job and http must be set"))
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Config.Hooks[0].HTTP = &kubecloudscalerv1alpha3.K8sHookHTTP{URL: "https://backup.example.com"}
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+		It("should reject an invalid namespace selector", func() {
+			k8s := &kubecloudscalerv1alpha3.K8s{
+				Spec: kubecloudscalerv1alpha3.K8sSpec{
+					Periods: []common.ScalerPeriod{
+						{
+							Type: common.PeriodTypeDown,
+							Time: common.TimePeriod{
+								Recurring: &common.RecurringPeriod{
+									Days:      []common.DayOfWeek{common.DayAll},
+									StartTime: "20:00",
+									EndTime:   "07:00",
+								},
+							},
+						},
+					},
+					Config: kubecloudscalerv1alpha3.K8sConfig{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "env", Operator: metav1.LabelSelectorOpIn},
+							},
+						},
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config.namespaceSelector: invalid label selector"))
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Config.NamespaceSelector.MatchExpressions[0].Values = []string{"preview"}
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+		It("should reject clusters reached with the same auth secret", func() {
+			k8s := &kubecloudscalerv1alpha3.K8s{
+				Spec: kubecloudscalerv1alpha3.K8sSpec{
+					Periods: []common.ScalerPeriod{
+						{
+							Type: common.PeriodTypeDown,
+							Time: common.TimePeriod{
+								Recurring: &common.RecurringPeriod{
+									Days:      []common.DayOfWeek{common.DayAll},
+									StartTime: "20:00",
+									EndTime:   "07:00",
+								},
+							},
+						},
+					},
+					Config: kubecloudscalerv1alpha3.K8sConfig{
+						AuthSecret: ptr.To("eu"),
+						Clusters: []kubecloudscalerv1alpha3.K8sCluster{
+							{Name: "local"},
+							{Name: "eu", AuthSecret: ptr.To("eu")},
+						},
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config.authSecret and config.clusters are mutually exclusive"))
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Config.AuthSecret = nil
+			k8s.Spec.Config.Clusters = append(k8s.Spec.Config.Clusters, kubecloudscalerv1alpha3.K8sCluster{Name: "eu-2", AuthSecret: ptr.To("eu")})
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`config.clusters[2]: cluster "eu-2" targets the same cluster as "eu"`))
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Config.Clusters[2].AuthSecretNamespace = ptr.To("platform")
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Config.Clusters[0].AuthSecretNamespace = ptr.To("platform")
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config.clusters[0]: authSecretNamespace requires authSecret"))
+			Expect(warnings).To(BeNil())
+		})
+		It("should reject replicas on a restore override", func() {
+			k8s := &kubecloudscalerv1alpha3.K8s{
+				Spec: kubecloudscalerv1alpha3.K8sSpec{
+					Periods: []common.ScalerPeriod{
+						{
+							Type: common.PeriodTypeDown,
+							Time: common.TimePeriod{
+								Recurring: &common.RecurringPeriod{
+									Days:      []common.DayOfWeek{common.DayAll},
+									StartTime: "20:00",
+									EndTime:   "07:00",
+								},
+							},
+						},
+					},
+					Override: &common.ScalerOverride{
+						Type:        common.OverrideTypeRestore,
+						Until:       metav1.NewTime(time.Now().Add(time.Hour)),
+						MinReplicas: ptr.To(int32(2)),
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("override: minReplicas and maxReplicas cannot be set on a restore override"))
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Override.Type = common.OverrideTypeUp
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+		It("should reject notification endpoints without a target", func() {
+			k8s := &kubecloudscalerv1alpha3.K8s{
+				Spec: kubecloudscalerv1alpha3.K8sSpec{
+					Periods: []common.ScalerPeriod{
+						{
+							Type: common.PeriodTypeDown,
+							Time: common.TimePeriod{
+								Recurring: &common.RecurringPeriod{
+									Days:      []common.DayOfWeek{common.DayAll},
+									StartTime: "20:00",
+									EndTime:   "07:00",
+								},
+							},
+						},
+					},
+					Config: kubecloudscalerv1alpha3.K8sConfig{
+						Notifications: []common.NotificationEndpoint{{Name: "slack", Format: common.NotificationFormatSlack}},
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, k8s)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config.notifications[0]: either url or secret is required"))
+			Expect(warnings).To(BeNil())
+
+			k8s.Spec.Config.Notifications[0].Secret = ptr.To("slack-webhook")
+
+			warnings, err = validator.ValidateCreate(ctx, k8s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
 	})
 
 	Context("When validating K8s updates", func() {
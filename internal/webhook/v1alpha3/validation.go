@@ -18,8 +18,15 @@ package v1alpha3
 
 import (
 	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	kubecloudscalerv1alpha3 "github.com/kubecloudscaler/kubecloudscaler/api/v1alpha3"
+	k8sUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/resources"
 )
 
 // validatePeriod validates a single ScalerPeriod configuration, wrapping errors with the period index.
@@ -30,3 +37,147 @@ func validatePeriod(p common.ScalerPeriod, index int) error {
 
 	return nil
 }
+
+// validateOverride validates the manual override of a scaler, if any.
+func validateOverride(override *common.ScalerOverride) error {
+	if override == nil {
+		return nil
+	}
+
+	if err := override.Validate(); err != nil {
+		return fmt.Errorf("override: %w", err)
+	}
+
+	return nil
+}
+
+// validateNotifications validates the notification endpoints of a scaler.
+func validateNotifications(endpoints []common.NotificationEndpoint) error {
+	for i, endpoint := range endpoints {
+		if err := endpoint.Validate(); err != nil {
+			return fmt.Errorf("config.notifications[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateSleepingService ensures a sleeping Service is configured when routing resources
+// (ingresses, httproutes) are managed, since their backends are switched to it on down periods.
+func validateSleepingService(types []common.ResourceKind, sleeping *common.SleepingService) error {
+	for _, kind := range []common.ResourceKind{common.ResourceIngresses, common.ResourceHTTPRoutes} {
+		if slices.Contains(types, kind) && sleeping == nil {
+			return fmt.Errorf("config.sleepingService is required when managing %s", kind)
+		}
+	}
+
+	return nil
+}
+
+// validateScalingGroups ensures scaling groups only select resource types managed by the scaler,
+// and have valid label selectors.
+func validateScalingGroups(types []common.ResourceKind, groups []kubecloudscalerv1alpha3.K8sScalingGroup) error {
+	if len(types) == 0 {
+		types = []common.ResourceKind{resources.DefaultK8SResourceType}
+	}
+
+	for i, group := range groups {
+		for _, kind := range group.Types {
+			if !slices.Contains(types, kind) {
+				return fmt.Errorf("config.scalingGroups[%d]: %s is not managed by the scaler", i, kind)
+			}
+		}
+
+		if group.LabelSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(group.LabelSelector); err != nil {
+				return fmt.Errorf("config.scalingGroups[%d]: invalid label selector: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateReadinessTimeouts ensures the readiness timeouts of a scaler and of its scaling groups
+// are positive and within MaxReadinessTimeout, since reconciliations wait for them.
+func validateReadinessTimeouts(config kubecloudscalerv1alpha3.K8sConfig) error {
+	check := func(field string, timeout *metav1.Duration) error {
+		if timeout == nil {
+			return nil
+		}
+		if timeout.Duration <= 0 || timeout.Duration > k8sUtils.MaxReadinessTimeout {
+			return fmt.Errorf("%s must be positive and at most %s", field, k8sUtils.MaxReadinessTimeout)
+		}
+		return nil
+	}
+
+	if err := check("config.readinessTimeout", config.ReadinessTimeout); err != nil {
+		return err
+	}
+	for i, group := range config.ScalingGroups {
+		if err := check(fmt.Sprintf("config.scalingGroups[%d].readinessTimeout", i), group.ReadinessTimeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateNamespaceSelector ensures the namespace selector, if any, is a valid label selector.
+func validateNamespaceSelector(selector *metav1.LabelSelector) error {
+	if selector == nil {
+		return nil
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return fmt.Errorf("config.namespaceSelector: invalid label selector: %w", err)
+	}
+
+	return nil
+}
+
+// validateHooks ensures each hook runs either a Job or an HTTP call, within a positive timeout.
+func validateHooks(hooks []kubecloudscalerv1alpha3.K8sHook) error {
+	for i, hook := range hooks {
+		if (hook.Job == nil) == (hook.HTTP == nil) {
+			return fmt.Errorf("config.hooks[%d]: exactly one of job and http must be set", i)
+		}
+
+		if hook.Timeout != nil && hook.Timeout.Duration <= 0 {
+			return fmt.Errorf("config.hooks[%d]: timeout must be positive", i)
+		}
+	}
+
+	return nil
+}
+
+// validateClusters ensures auth secret namespaces come with an auth secret, and the clusters of a
+// scaler replace its auth secret and are each reached with a distinct auth secret.
+func validateClusters(config kubecloudscalerv1alpha3.K8sConfig) error {
+	if config.AuthSecretNamespace != nil && config.AuthSecret == nil {
+		return fmt.Errorf("config.authSecretNamespace requires config.authSecret")
+	}
+
+	if len(config.Clusters) == 0 {
+		return nil
+	}
+
+	if config.AuthSecret != nil {
+		return fmt.Errorf("config.authSecret and config.clusters are mutually exclusive")
+	}
+
+	secrets := make(map[string]string, len(config.Clusters))
+	for i, cluster := range config.Clusters {
+		if cluster.AuthSecretNamespace != nil && cluster.AuthSecret == nil {
+			return fmt.Errorf("config.clusters[%d]: authSecretNamespace requires authSecret", i)
+		}
+
+		secret := ptr.Deref(cluster.AuthSecretNamespace, "") + "/" + ptr.Deref(cluster.AuthSecret, "")
+		if other, ok := secrets[secret]; ok {
+			return fmt.Errorf("config.clusters[%d]: cluster %q targets the same cluster as %q", i, cluster.Name, other)
+		}
+		secrets[secret] = cluster.Name
+	}
+
+	return nil
+}
@@ -36,4 +36,6 @@ const (
 	PeriodTimezone = "period-timezone"
 	// FieldManager is the field manager name for Kubernetes resources.
 	FieldManager = "kubecloudscaler"
+	// WakeUntil is the scaler annotation key holding the RFC3339 time until which the activator keeps it awake.
+	WakeUntil = "wake-until"
 )
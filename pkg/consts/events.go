@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consts
+
+// Event reasons and actions recorded on scalers and on the resources they scale.
+const (
+	// EventReasonPeriodChanged is recorded on a scaler when its active period changes.
+	EventReasonPeriodChanged = "PeriodChanged"
+	// EventReasonScaledDown is recorded on a resource scaled for a down period.
+	EventReasonScaledDown = "ScaledDown"
+	// EventReasonScaledUp is recorded on a resource scaled for an up period.
+	EventReasonScaledUp = "ScaledUp"
+	// EventReasonRestored is recorded on a resource restored to its original state.
+	EventReasonRestored = "Restored"
+	// EventReasonScalingFailed is recorded on a scaler or resource that could not be scaled.
+	EventReasonScalingFailed = "ScalingFailed"
+	// EventReasonScaleDownWarning is recorded on a scaler ahead of a period scaling its resources down.
+	EventReasonScaleDownWarning = "ScaleDownWarning"
+	// EventReasonHookFailed is recorded on a scaler when a hook of a transition fails.
+	EventReasonHookFailed = "HookFailed"
+
+	// EventActionChangePeriod is the action of PeriodChanged events.
+	EventActionChangePeriod = "ChangePeriod"
+	// EventActionScale is the action of scaling events.
+	EventActionScale = "Scale"
+	// EventActionWarn is the action of ScaleDownWarning events.
+	EventActionWarn = "Warn"
+	// EventActionRunHook is the action of HookFailed events.
+	EventActionRunHook = "RunHook"
+)
@@ -12,6 +12,7 @@ import (
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	gcpUtils "github.com/kubecloudscaler/kubecloudscaler/pkg/gcp/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
 )
 
 const (
@@ -27,13 +28,19 @@ func (c *VMInstances) SetState(ctx context.Context) ([]common.ScalerStatusSucces
 	failed := make([]common.ScalerStatusFailed, 0)
 
 	// Get all zones in the region
-	zones, err := gcpUtils.GetZonesFromRegion(ctx, c.Config.Client, c.Config.ProjectID, c.Config.Region)
+	zonesCtx, span := tracing.Start(ctx, "gcp.GetZones")
+	zones, err := gcpUtils.GetZonesFromRegion(zonesCtx, c.Config.Client, c.Config.ProjectID, c.Config.Region)
+	span.SetAttributes(tracing.AttrItems.Int(len(zones)))
+	tracing.End(span, err)
 	if err != nil {
 		return success, failed, fmt.Errorf("failed to get zones: %w", err)
 	}
 
 	// Get instances in the zones filtered by label selector (filter applied at GCP API level)
-	filteredInstances, err := gcpUtils.GetInstancesInZones(ctx, c.Config.Client, c.Config.ProjectID, zones, c.Config.LabelSelector)
+	listCtx, span := tracing.Start(ctx, "gcp.ListInstances")
+	filteredInstances, err := gcpUtils.GetInstancesInZones(listCtx, c.Config.Client, c.Config.ProjectID, zones, c.Config.LabelSelector)
+	span.SetAttributes(tracing.AttrItems.Int(len(filteredInstances)))
+	tracing.End(span, err)
 	if err != nil {
 		return success, failed, fmt.Errorf("failed to get instances: %w", err)
 	}
@@ -67,6 +74,7 @@ func (c *VMInstances) SetState(ctx context.Context) ([]common.ScalerStatusSucces
 		if c.isInstanceInDesiredState(instance, desiredState) {
 			status.Comment = "Instance is already in the desired state"
 			success = append(success, status)
+			c.reportRelease(instance, desiredState)
 			continue
 		}
 
@@ -87,6 +95,7 @@ func (c *VMInstances) SetState(ctx context.Context) ([]common.ScalerStatusSucces
 		}
 
 		success = append(success, status)
+		c.reportRelease(instance, desiredState)
 	}
 
 	return success, failed, nil
@@ -116,6 +125,22 @@ func (c *VMInstances) getDesiredState() string {
 	}
 }
 
+// reportRelease reports the machine type of instance to OnRelease when it is kept stopped.
+func (c *VMInstances) reportRelease(instance *computepb.Instance, desiredState string) {
+	if c.Config.OnRelease == nil || desiredState != gcpUtils.InstanceStopped {
+		return
+	}
+
+	// Format: https://www.googleapis.com/compute/v1/projects/PROJECT_ID/zones/ZONE_NAME/machineTypes/MACHINE_TYPE
+	machineTypeURL := strings.TrimSuffix(instance.GetMachineType(), "/")
+	if machineTypeURL == "" {
+		return
+	}
+
+	parts := strings.Split(machineTypeURL, "/")
+	c.Config.OnRelease(parts[len(parts)-1])
+}
+
 // isInstanceInDesiredState checks if the instance is already in the desired state
 func (c *VMInstances) isInstanceInDesiredState(instance *computepb.Instance, desiredState string) bool {
 	currentState := gcpUtils.GetInstanceStatus(instance)
@@ -123,7 +148,13 @@ func (c *VMInstances) isInstanceInDesiredState(instance *computepb.Instance, des
 }
 
 // applyInstanceState applies the desired state to the instance
-func (c *VMInstances) applyInstanceState(ctx context.Context, instance *computepb.Instance, desiredState string) error {
+func (c *VMInstances) applyInstanceState(ctx context.Context, instance *computepb.Instance, desiredState string) (err error) {
+	ctx, span := tracing.Start(ctx, "gcp.ApplyInstanceState",
+		tracing.AttrResourceKind.String("ComputeInstance"),
+		tracing.AttrResourceName.String(instance.GetName()),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	zone := c.extractZoneFromInstance(instance)
 	if zone == "" {
 		return fmt.Errorf("cannot extract zone from instance %s", instance.GetName())
@@ -144,11 +175,13 @@ func (c *VMInstances) startInstance(ctx context.Context, instance *computepb.Ins
 	if gcpUtils.IsInstanceRunning(instance) {
 		return nil
 	}
+	start := time.Now()
 	op, err := c.Config.Client.Instances.Start(ctx, &computepb.StartInstanceRequest{
 		Project:  c.Config.ProjectID,
 		Zone:     zone,
 		Instance: instance.GetName(),
 	})
+	c.Config.Client.Observe(gcpUtils.OperationInstancesStart, start, err)
 	return c.finalizeInstanceMutation(ctx, op, zone, instance.GetName(), "start", "compute.instances.start", err)
 }
 
@@ -157,11 +190,13 @@ func (c *VMInstances) stopInstance(ctx context.Context, instance *computepb.Inst
 	if gcpUtils.IsInstanceStopped(instance) {
 		return nil
 	}
+	start := time.Now()
 	op, err := c.Config.Client.Instances.Stop(ctx, &computepb.StopInstanceRequest{
 		Project:  c.Config.ProjectID,
 		Zone:     zone,
 		Instance: instance.GetName(),
 	})
+	c.Config.Client.Observe(gcpUtils.OperationInstancesStop, start, err)
 	return c.finalizeInstanceMutation(ctx, op, zone, instance.GetName(), "stop", "compute.instances.stop", err)
 }
 
@@ -217,7 +252,9 @@ func (c *VMInstances) waitForOperation(ctx context.Context, operationName, zone
 				Project:   c.Config.ProjectID,
 				Zone:      zone,
 			}
+			start := time.Now()
 			op, err := c.Config.Client.ZoneOperations.Get(ctx, getReq)
+			c.Config.Client.Observe(gcpUtils.OperationZoneOperationsGet, start, err)
 			if err != nil {
 				return fmt.Errorf("failed to get operation status: %w", err)
 			}
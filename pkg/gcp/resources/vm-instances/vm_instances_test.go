@@ -200,6 +200,37 @@ var _ = Describe("ComputeInstances", func() {
 		})
 	})
 
+	Describe("reportRelease", func() {
+		var released []string
+
+		BeforeEach(func() {
+			released = nil
+			config.OnRelease = func(machineType string) {
+				released = append(released, machineType)
+			}
+			ci = &VMInstances{Config: config}
+		})
+
+		It("should report the machine type of stopped instances", func() {
+			instance := &computepb.Instance{
+				MachineType: stringPtr("https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/machineTypes/e2-standard-4"),
+			}
+			ci.reportRelease(instance, utils.InstanceStopped)
+			Expect(released).To(Equal([]string{"e2-standard-4"}))
+		})
+
+		It("should not report running instances", func() {
+			instance := &computepb.Instance{MachineType: stringPtr("zones/us-central1-a/machineTypes/e2-standard-4")}
+			ci.reportRelease(instance, utils.InstanceRunning)
+			Expect(released).To(BeEmpty())
+		})
+
+		It("should not report instances without a machine type", func() {
+			ci.reportRelease(&computepb.Instance{}, utils.InstanceStopped)
+			Expect(released).To(BeEmpty())
+		})
+	})
+
 	Describe("SetState", func() {
 		BeforeEach(func() {
 			ci = &VMInstances{
@@ -14,3 +14,17 @@ const (
 	// InstanceStarting is the GCP instance starting state.
 	InstanceStarting = "STARTING"
 )
+
+// Operations reported to ClientSet.OnCall.
+const (
+	// OperationRegionsGet gets a region.
+	OperationRegionsGet = "regions.get"
+	// OperationInstancesList lists the instances of a zone.
+	OperationInstancesList = "instances.list"
+	// OperationInstancesStart starts an instance.
+	OperationInstancesStart = "instances.start"
+	// OperationInstancesStop stops an instance.
+	OperationInstancesStop = "instances.stop"
+	// OperationZoneOperationsGet gets the status of a zone operation.
+	OperationZoneOperationsGet = "zoneOperations.get"
+)
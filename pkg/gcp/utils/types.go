@@ -3,6 +3,7 @@ package utils
 
 import (
 	"errors"
+	"time"
 
 	compute "cloud.google.com/go/compute/apiv1"
 	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
@@ -17,12 +18,25 @@ type GcpResource struct {
 	Period    *periodPkg.Period `json:"period,omitempty"`
 }
 
+// CallFunc is called after each GCP API call with its operation, duration and error.
+type CallFunc func(operation string, duration time.Duration, err error)
+
 // ClientSet groups all GCP compute clients used by the scaler.
 // Callers must call Close() when the ClientSet is no longer needed.
 type ClientSet struct {
 	Instances      *compute.InstancesClient
 	ZoneOperations *compute.ZoneOperationsClient
 	Regions        *compute.RegionsClient
+	// OnCall is called after each API call made through the clients; nil disables it.
+	OnCall CallFunc
+}
+
+// Observe reports to OnCall the API call of operation started at start, which returned err.
+func (cs *ClientSet) Observe(operation string, start time.Time, err error) {
+	if cs == nil || cs.OnCall == nil {
+		return
+	}
+	cs.OnCall(operation, time.Since(start), err)
 }
 
 // Close releases all underlying GCP client connections.
@@ -57,4 +71,6 @@ type Config struct {
 	WaitForOperation  bool                  `json:"waitForOperation,omitempty"`
 	DryRun            bool                  `json:"dryRun,omitempty"`
 	DefaultPeriodType string                `json:"defaultPeriodType,omitempty"`
+	// OnRelease is called with the machine type of each instance kept stopped; nil disables it.
+	OnRelease func(machineType string) `json:"-"`
 }
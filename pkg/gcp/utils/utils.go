@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	computepb "cloud.google.com/go/compute/apiv1/computepb"
 	"google.golang.org/api/iterator"
@@ -28,7 +29,9 @@ func GetZonesFromRegion(ctx context.Context, clients *ClientSet, projectID, regi
 		Region:  region,
 	}
 
+	start := time.Now()
 	reg, err := clients.Regions.Get(ctx, req)
+	clients.Observe(OperationRegionsGet, start, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get region %q in project %q (check that the region exists "+
 			"and the service account has compute.regions.get permission): %w", region, projectID, err)
@@ -74,13 +77,16 @@ func GetInstancesInZones(
 			req.Filter = &filter
 		}
 
+		start := time.Now()
 		it := clients.Instances.List(ctx, req)
 		for {
 			inst, err := it.Next()
 			if err == iterator.Done {
+				clients.Observe(OperationInstancesList, start, nil)
 				break
 			}
 			if err != nil {
+				clients.Observe(OperationInstancesList, start, err)
 				return nil, fmt.Errorf("failed to list instances in zone %q of project %q "+
 					"(check that the service account has compute.instances.list permission): %w", zone, projectID, err)
 			}
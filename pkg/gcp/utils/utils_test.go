@@ -2,6 +2,8 @@ package utils
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	computepb "cloud.google.com/go/compute/apiv1/computepb"
 	. "github.com/onsi/ginkgo/v2"
@@ -286,6 +288,33 @@ var _ = Describe("GCP Utils", func() {
 			})
 		})
 	})
+
+	Describe("ClientSet.Observe", func() {
+		It("should report the call to OnCall", func() {
+			var (
+				operation string
+				duration  time.Duration
+				callErr   error
+			)
+			clients := &ClientSet{OnCall: func(op string, d time.Duration, err error) {
+				operation, duration, callErr = op, d, err
+			}}
+
+			failure := errors.New("quota exceeded")
+			clients.Observe(OperationInstancesStop, time.Now().Add(-time.Second), failure)
+
+			Expect(operation).To(Equal(OperationInstancesStop))
+			Expect(duration).To(BeNumerically(">=", time.Second))
+			Expect(callErr).To(MatchError(failure))
+		})
+
+		It("should ignore calls without OnCall", func() {
+			Expect(func() {
+				(&ClientSet{}).Observe(OperationRegionsGet, time.Now(), nil)
+				(*ClientSet)(nil).Observe(OperationRegionsGet, time.Now(), nil)
+			}).NotTo(Panic())
+		})
+	})
 })
 
 // Helper function to create string pointers
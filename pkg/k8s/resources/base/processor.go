@@ -22,13 +22,24 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
+	"time"
 
 	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+	coreV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/consts"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
 )
 
 // ResourceLister defines the interface for listing resources.
@@ -37,13 +48,14 @@ type ResourceLister interface {
 }
 
 // ResourceGetter defines the interface for getting individual resources.
+// It is used to refresh a resource after a conflict.
 type ResourceGetter interface {
 	Get(ctx context.Context, namespace, name string, opts metaV1.GetOptions) (ResourceItem, error)
 }
 
-// ResourceUpdater defines the interface for updating resources.
-type ResourceUpdater interface {
-	Update(ctx context.Context, namespace string, resource ResourceItem, opts metaV1.UpdateOptions) (ResourceItem, error)
+// ResourcePatcher defines the interface for patching resources with a JSON merge patch.
+type ResourcePatcher interface {
+	Patch(ctx context.Context, namespace, name string, data []byte, opts metaV1.PatchOptions) (ResourceItem, error)
 }
 
 // ResourceItem represents a Kubernetes resource item.
@@ -52,6 +64,9 @@ type ResourceItem interface {
 	GetNamespace() string
 	GetAnnotations() map[string]string
 	SetAnnotations(map[string]string)
+	// GetObject returns the Kubernetes object reflecting the current state of the item, including
+	// changes made by the strategy. It is diffed to build patches and referenced by events.
+	GetObject() client.Object
 }
 
 // ScalingStrategy defines the interface for resource-specific scaling logic.
@@ -63,36 +78,122 @@ type ScalingStrategy interface {
 	GetKind() string
 }
 
+// ReadinessReporter is implemented by resource items whose workload takes time to become ready
+// after being scaled up. Other items are ready as soon as they are patched.
+type ReadinessReporter interface {
+	// Ready reports whether the workload is ready, and otherwise why not.
+	Ready() (bool, string)
+}
+
+// ReadinessDiagnoser is implemented by resource getters that can tell why a resource is not ready,
+// such as its pods failing to pull their image or to be scheduled.
+type ReadinessDiagnoser interface {
+	// DiagnoseReadiness returns why resource is not ready, or an empty string when unknown.
+	DiagnoseReadiness(ctx context.Context, resource ResourceItem) (string, error)
+}
+
+// ReleaseReporter is implemented by resource items running pods, whose scale-down frees the
+// resources requested by the pods removed.
+type ReleaseReporter interface {
+	// Released returns the resources requested by the pods removed from the original replicas.
+	Released() coreV1.ResourceList
+}
+
+// StateDescriber is implemented by strategies that can summarize the scaled state of a resource.
+// The summary is reported in the status during dry runs.
+type StateDescriber interface {
+	DescribeState(resource ResourceItem) string
+}
+
+// ReplicasReady reports whether a workload with desired replicas (1 when unset) and ready ready
+// replicas is ready, once its controller observed its latest generation.
+func ReplicasReady(generation, observedGeneration int64, desired *int32, ready int32) (bool, string) {
+	if observedGeneration < generation {
+		return false, "latest generation not observed yet"
+	}
+
+	want := ptr.Deref(desired, 1)
+	if ready < want {
+		return false, fmt.Sprintf("%d/%d replicas ready", ready, want)
+	}
+
+	return true, ""
+}
+
+// ReleasedRequests returns the resources requested by the pods of template that a workload with
+// replicas (1 when unset) no longer runs, compared to the original replicas recorded in
+// annotations. It is empty when the workload was not scaled down.
+func ReleasedRequests(annotations map[string]string, replicas *int32, template *coreV1.PodSpec) coreV1.ResourceList {
+	original, err := strconv.ParseInt(annotations[utils.AnnotationsPrefix+"/"+utils.AnnotationsOrigValue], 10, 32)
+	if err != nil {
+		return nil
+	}
+
+	removed := original - int64(ptr.Deref(replicas, 1))
+	if removed <= 0 {
+		return nil
+	}
+
+	released := coreV1.ResourceList{}
+	for _, container := range template.Containers {
+		for name, request := range container.Resources.Requests {
+			request.Mul(removed)
+			total := released[name]
+			total.Add(request)
+			released[name] = total
+		}
+	}
+
+	return released
+}
+
+// maxEventNoteLength is the maximum length of an event note accepted by the API server.
+const maxEventNoteLength = 1024
+
+// emptyPatch is the merge patch between two identical objects.
+const emptyPatch = "{}"
+
+// maxConflictRetries is how many times a resource is fetched again and re-patched after a conflict.
+const maxConflictRetries = 4
+
+// readinessPollInterval is how often resources awaited until ready are fetched again.
+const readinessPollInterval = 2 * time.Second
+
 // Processor handles the common resource scaling workflow.
 type Processor struct {
 	lister   ResourceLister
 	getter   ResourceGetter
-	updater  ResourceUpdater
+	patcher  ResourcePatcher
 	strategy ScalingStrategy
 	resource *utils.K8sResource
 	logger   *zerolog.Logger
+	// pollInterval is how often resources awaited until ready are fetched again
+	pollInterval time.Duration
 }
 
 // NewProcessor creates a new base processor.
 func NewProcessor(
 	lister ResourceLister,
 	getter ResourceGetter,
-	updater ResourceUpdater,
+	patcher ResourcePatcher,
 	strategy ScalingStrategy,
 	resource *utils.K8sResource,
 	logger *zerolog.Logger,
 ) *Processor {
 	return &Processor{
-		lister:   lister,
-		getter:   getter,
-		updater:  updater,
-		strategy: strategy,
-		resource: resource,
-		logger:   logger,
+		lister:       lister,
+		getter:       getter,
+		patcher:      patcher,
+		strategy:     strategy,
+		resource:     resource,
+		logger:       logger,
+		pollInterval: readinessPollInterval,
 	}
 }
 
 // ProcessResources processes all resources and returns success/failure results.
+// Up to Concurrency resources are processed in parallel, within the calls shared with the
+// other resource kinds; results keep the listing order.
 func (p *Processor) ProcessResources(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
 	scalerStatusSuccess := []common.ScalerStatusSuccess{}
 	scalerStatusFailed := []common.ScalerStatusFailed{}
@@ -103,14 +204,37 @@ func (p *Processor) ProcessResources(ctx context.Context) ([]common.ScalerStatus
 		return scalerStatusSuccess, scalerStatusFailed, err
 	}
 
-	// Process each resource. Propagate context cancellation/deadline errors;
-	// other errors are recorded in scalerStatusFailed and processing continues.
-	for _, item := range list {
-		if err := p.processResource(ctx, item, &scalerStatusSuccess, &scalerStatusFailed); err != nil {
+	// Process each resource. Propagate context cancellation/deadline errors and skip the
+	// resources not started yet; other errors are recorded in scalerStatusFailed and
+	// processing continues.
+	outcomes := make([]resourceOutcome, len(list))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(p.concurrency())
+	for i, item := range list {
+		g.Go(func() error {
+			release, err := p.acquire(gctx)
+			if err != nil {
+				return err
+			}
+			defer release()
+			err = p.processResource(ctx, item, &outcomes[i])
 			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-				return scalerStatusSuccess, scalerStatusFailed, err
+				return err
 			}
-		}
+			return nil
+		})
+	}
+	waitErr := g.Wait()
+	if waitErr == nil {
+		waitErr = p.awaitReadiness(ctx, list, outcomes)
+	}
+
+	for _, outcome := range outcomes {
+		scalerStatusSuccess = append(scalerStatusSuccess, outcome.success...)
+		scalerStatusFailed = append(scalerStatusFailed, outcome.failed...)
+	}
+	if waitErr != nil {
+		return scalerStatusSuccess, scalerStatusFailed, waitErr
 	}
 
 	p.logger.Debug().
@@ -123,21 +247,70 @@ func (p *Processor) ProcessResources(ctx context.Context) ([]common.ScalerStatus
 	return scalerStatusSuccess, scalerStatusFailed, nil
 }
 
-// listResources lists all resources across configured namespaces.
-func (p *Processor) listResources(ctx context.Context) ([]ResourceItem, error) {
-	var allItems []ResourceItem
+// resourceOutcome holds the status entries produced by processing a single resource.
+type resourceOutcome struct {
+	success []common.ScalerStatusSuccess
+	failed  []common.ScalerStatusFailed
+	// scaled is set when the scaled state of the resource was changed
+	scaled bool
+}
 
-	for _, ns := range p.resource.NsList {
-		items, err := p.lister.List(ctx, ns, p.resource.ListOptions)
-		if err != nil {
-			// Use plural form for error message to match existing test expectations
-			kindPlural := p.strategy.GetKind() + "s"
-			return nil, fmt.Errorf("error listing %s: %w", kindPlural, err)
-		}
+// concurrency returns how many namespaces or resources may be processed in parallel.
+func (p *Processor) concurrency() int {
+	return max(p.resource.Concurrency, 1)
+}
+
+// acquire waits for a slot among the calls shared with the other resource kinds, returning the
+// function releasing it.
+func (p *Processor) acquire(ctx context.Context) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	calls := p.resource.Calls
+	if calls == nil {
+		return func() {}, nil
+	}
+	if err := calls.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { calls.Release(1) }, nil
+}
 
-		allItems = append(allItems, items...)
+// listResources lists all resources across configured namespaces, in namespace order.
+func (p *Processor) listResources(ctx context.Context) (allItems []ResourceItem, err error) {
+	ctx, span := tracing.Start(ctx, "k8s.ListResources", tracing.AttrResourceKind.String(p.strategy.GetKind()))
+	defer func() {
+		span.SetAttributes(tracing.AttrItems.Int(len(allItems)))
+		tracing.End(span, err)
+	}()
+
+	nsItems := make([][]ResourceItem, len(p.resource.NsList))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(p.concurrency())
+	for i, ns := range p.resource.NsList {
+		g.Go(func() error {
+			release, err := p.acquire(gctx)
+			if err != nil {
+				return err
+			}
+			defer release()
+			items, err := p.lister.List(gctx, ns, p.resource.ListOptions)
+			if err != nil {
+				// Use plural form for error message to match existing test expectations
+				kindPlural := p.strategy.GetKind() + "s"
+				return fmt.Errorf("error listing %s: %w", kindPlural, err)
+			}
+			nsItems[i] = items
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
+	allItems = slices.Concat(nsItems...)
+
 	// Filter by resource names if specified
 	if len(p.resource.Names) > 0 {
 		allItems = slices.DeleteFunc(allItems, func(item ResourceItem) bool {
@@ -145,45 +318,383 @@ func (p *Processor) listResources(ctx context.Context) ([]ResourceItem, error) {
 		})
 	}
 
+	if p.resource.Filter != nil {
+		allItems = slices.DeleteFunc(allItems, func(item ResourceItem) bool {
+			return !p.resource.Filter(item.GetObject())
+		})
+	}
+
 	return allItems, nil
 }
 
-// processResource processes a single resource.
-func (p *Processor) processResource(
-	ctx context.Context,
-	item ResourceItem,
-	successList *[]common.ScalerStatusSuccess,
-	failedList *[]common.ScalerStatusFailed,
-) error {
-	// Get the full resource
-	resource, err := p.getter.Get(ctx, item.GetNamespace(), item.GetName(), metaV1.GetOptions{})
+// awaitReadiness verifies that the resources processed without error are ready. The resources
+// scaled by this reconciliation are awaited for up to ReadinessTimeout; the others are checked
+// once, so that a workload failing for other reasons does not hold every reconciliation. The
+// resources not ready are turned into failures. Down periods and dry runs are not verified.
+func (p *Processor) awaitReadiness(ctx context.Context, list []ResourceItem, outcomes []resourceOutcome) error {
+	timeout := p.resource.ReadinessTimeout
+	if timeout <= 0 || p.resource.DryRun || p.resource.Period.Type == common.PeriodTypeDown {
+		return nil
+	}
+
+	// pending maps the index of each resource not ready yet to the reason why
+	pending := make(map[int]string)
+	for i, item := range list {
+		if _, ok := item.(ReadinessReporter); ok && len(outcomes[i].failed) == 0 {
+			pending[i] = "readiness not checked"
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// notReady collects the reasons of the resources given up on
+	notReady := make(map[int]string)
+	err := wait.PollUntilContextTimeout(ctx, p.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		for i := range pending {
+			ready, reason := p.checkReadiness(ctx, list[i])
+			switch {
+			case ready:
+				delete(pending, i)
+			case !outcomes[i].scaled:
+				delete(pending, i)
+				notReady[i] = "not ready: " + reason
+			default:
+				pending[i] = reason
+			}
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		for i, reason := range pending {
+			notReady[i] = fmt.Sprintf("not ready after %s: %s", timeout, reason)
+		}
+	}
+
+	for i, reason := range notReady {
+		item := list[i]
+		if diagnoser, ok := p.getter.(ReadinessDiagnoser); ok {
+			diagnosis, err := diagnoser.DiagnoseReadiness(ctx, item)
+			if err != nil {
+				p.logger.Debug().Err(err).Str("name", item.GetName()).Msg("unable to diagnose readiness")
+			}
+			if diagnosis != "" {
+				reason += " (" + diagnosis + ")"
+			}
+		}
+
+		p.logger.Warn().
+			Str("kind", p.strategy.GetKind()).
+			Str("namespace", item.GetNamespace()).
+			Str("name", item.GetName()).
+			Str("reason", reason).
+			Msg("resource not ready")
+		outcomes[i].success = nil
+		p.appendFailure(&outcomes[i].failed, item.GetName(), reason)
+		p.recordEvent(item, coreV1.EventTypeWarning, consts.EventReasonScalingFailed, reason)
+	}
+
+	return nil
+}
+
+// checkReadiness fetches resource again and reports whether it is ready, and otherwise why not.
+func (p *Processor) checkReadiness(ctx context.Context, resource ResourceItem) (bool, string) {
+	fresh, err := p.getter.Get(ctx, resource.GetNamespace(), resource.GetName(), metaV1.GetOptions{})
+	if err != nil {
+		return false, err.Error()
+	}
+
+	reporter, ok := fresh.(ReadinessReporter)
+	if !ok {
+		return true, ""
+	}
+	return reporter.Ready()
+}
+
+// scaleResult is the outcome of scaling a single resource.
+type scaleResult struct {
+	// comment is reported in the status for postponed and dry-run resources
+	comment string
+	// postponedUntil is set when the scale-down of the resource is postponed until then
+	postponedUntil time.Time
+	// alreadyRestored is set when the resource needed no action
+	alreadyRestored bool
+	// change describes the state transition, when the strategy can describe states
+	change string
+	// changed reports whether the scaled state changed, as described by the strategy when it
+	// can, or as seen in the patch otherwise
+	changed bool
+	// drifted is set when the resource had left the state of the current period
+	drifted bool
+}
+
+// processResource processes a single resource. The listed item is used as is; it is only
+// fetched again when the patch conflicts with a concurrent change.
+func (p *Processor) processResource(ctx context.Context, item ResourceItem, outcome *resourceOutcome) (err error) {
+	ctx, span := tracing.Start(ctx, "k8s.ScaleResource",
+		tracing.AttrResourceKind.String(p.strategy.GetKind()),
+		tracing.AttrNamespace.String(item.GetNamespace()),
+		tracing.AttrResourceName.String(item.GetName()),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	successList, failedList := &outcome.success, &outcome.failed
+	resource := item
+	result, err := p.scaleResource(ctx, resource)
+	for attempt := 0; apierrors.IsConflict(err) && attempt < maxConflictRetries; attempt++ {
+		// Another writer changed the resource since it was read: retry on a fresh copy
+		fresh, getErr := p.getter.Get(ctx, item.GetNamespace(), item.GetName(), metaV1.GetOptions{})
+		if getErr != nil {
+			err = getErr
+			break
+		}
+		resource = fresh
+		result, err = p.scaleResource(ctx, resource)
+	}
 	if err != nil {
 		p.appendFailure(failedList, item.GetName(), err.Error())
+		p.recordEvent(resource, coreV1.EventTypeWarning, consts.EventReasonScalingFailed, err.Error())
 		return err
 	}
 
+	// Record success
+	switch {
+	case result.alreadyRestored:
+		return nil
+	case !result.postponedUntil.IsZero():
+		p.appendPostponed(successList, item.GetName(), result.comment, result.postponedUntil)
+		return nil
+	case result.comment != "":
+		p.appendCommentedSuccess(successList, item.GetName(), result.comment)
+		return nil
+	}
+
+	p.appendSuccess(successList, item.GetName())
+	outcome.scaled = result.changed
+	p.reportRelease(resource)
+	// Only record events when the state visibly changed: down periods re-apply on every reconciliation
+	if result.changed {
+		p.recordEvent(resource, coreV1.EventTypeNormal, p.scaledReason(), result.change)
+	}
+	if result.changed && result.drifted {
+		p.logger.Info().
+			Str("kind", p.strategy.GetKind()).
+			Str("namespace", item.GetNamespace()).
+			Str("name", item.GetName()).
+			Msg("resource drifted from the period state")
+		if p.resource.OnDrift != nil {
+			p.resource.OnDrift(p.strategy.GetKind())
+		}
+	}
+	return nil
+}
+
+// scaleResource applies the strategy to resource and patches the fields it changed. The patch
+// carries the resource version read, so that it fails with a conflict instead of overwriting a
+// concurrent change. No request is sent when the strategy changed nothing.
+func (p *Processor) scaleResource(ctx context.Context, resource ResourceItem) (scaleResult, error) {
+	// Postpone the scale-down of recently deployed resources
+	if until, postponed := p.deploymentGraceUntil(resource); postponed {
+		return scaleResult{
+			comment:        fmt.Sprintf("scale-down postponed until %s (recently deployed)", until.UTC().Format(time.RFC3339)),
+			postponedUntil: until,
+		}, nil
+	}
+
+	original, ok := resource.GetObject().DeepCopyObject().(client.Object)
+	if !ok {
+		return scaleResult{}, NewTypeAssertionError("client.Object", resource)
+	}
+
+	// Record the current state before the strategy mutates the resource
+	describer, canDescribe := p.strategy.(StateDescriber)
+	var currentState string
+	if canDescribe {
+		currentState = describer.DescribeState(resource)
+	}
+
+	// Tell drift apart from a period transition before the strategy updates the annotations
+	drifted := p.hasDrifted(resource)
+
 	// Apply scaling strategy
 	alreadyRestored, err := p.strategy.ApplyScaling(ctx, resource, string(p.resource.Period.Type), p.resource.Period)
 	if err != nil {
-		p.appendFailure(failedList, item.GetName(), err.Error())
-		return err
+		return scaleResult{}, err
 	}
 
 	if alreadyRestored {
-		return nil
+		return scaleResult{alreadyRestored: true}, nil
+	}
+
+	modified := resource.GetObject()
+	diff, err := client.MergeFrom(original).Data(modified)
+	if err != nil {
+		return scaleResult{}, fmt.Errorf("error computing patch: %w", err)
+	}
+	patchNeeded := string(diff) != emptyPatch
+
+	result := scaleResult{changed: patchNeeded, drifted: drifted}
+	if canDescribe {
+		newState := describer.DescribeState(resource)
+		result.change = fmt.Sprintf("%s -> %s", currentState, newState)
+		result.changed = newState != currentState
 	}
 
-	_, err = p.updater.Update(ctx, resource.GetNamespace(), resource, metaV1.UpdateOptions{
+	if p.resource.DryRun {
+		result.comment = "dry run"
+		if result.change != "" {
+			result.comment += ": " + result.change
+		}
+	}
+
+	if !patchNeeded {
+		return result, nil
+	}
+
+	// Lock on the listed resourceVersion so a concurrent writer surfaces as a conflict
+	patch := client.MergeFrom(original)
+	if original.GetResourceVersion() != "" {
+		patch = client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+	}
+	data, err := patch.Data(modified)
+	if err != nil {
+		return scaleResult{}, fmt.Errorf("error computing patch: %w", err)
+	}
+
+	opts := metaV1.PatchOptions{
 		FieldManager: utils.FieldManager,
-	})
+	}
+	if p.resource.DryRun {
+		// The API server validates and admits the patch without persisting it
+		opts.DryRun = []string{metaV1.DryRunAll}
+	}
+
+	if _, err := p.patcher.Patch(ctx, resource.GetNamespace(), resource.GetName(), data, opts); err != nil {
+		return scaleResult{}, err
+	}
+
+	return result, nil
+}
+
+// reportRelease reports the resources freed by resource to OnRelease during down periods.
+func (p *Processor) reportRelease(resource ResourceItem) {
+	if p.resource.OnRelease == nil || p.resource.Period.Type != common.PeriodTypeDown {
+		return
+	}
+
+	reporter, ok := resource.(ReleaseReporter)
+	if !ok {
+		return
+	}
+
+	if released := reporter.Released(); len(released) > 0 {
+		p.resource.OnRelease(p.strategy.GetKind(), released)
+	}
+}
+
+// hasDrifted reports whether resource may have left the state of the current up or down period
+// after reaching it: it carries the annotations of the current period, or it was created after
+// the period started.
+func (p *Processor) hasDrifted(resource ResourceItem) bool {
+	period := p.resource.Period
+	if period.Type != common.PeriodTypeDown && period.Type != common.PeriodTypeUp {
+		return false
+	}
+
+	annotations := resource.GetAnnotations()
+	if annotations[utils.AnnotationsPrefix+"/"+utils.PeriodType] == string(period.Type) &&
+		annotations[utils.AnnotationsPrefix+"/"+utils.PeriodStartTime] == period.StartTime.Format(time.RFC3339) {
+		return true
+	}
+
+	created := resource.GetObject().GetCreationTimestamp()
+	return !created.IsZero() && created.After(period.StartTime)
+}
+
+// deploymentGraceUntil returns the end of the deployment grace period of resource, and whether
+// its scale-down must be postponed until then. Missing or unparsable deploy times never block.
+func (p *Processor) deploymentGraceUntil(resource ResourceItem) (time.Time, bool) {
+	if p.resource.DeploymentTimeAnnotation == "" || p.resource.Period.Type != common.PeriodTypeDown {
+		return time.Time{}, false
+	}
+
+	value, ok := resource.GetAnnotations()[p.resource.DeploymentTimeAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	deployedAt, err := parseDeploymentTime(value)
 	if err != nil {
-		p.appendFailure(failedList, item.GetName(), err.Error())
-		return err
+		p.logger.Warn().Err(err).
+			Str("name", resource.GetName()).
+			Str("annotation", p.resource.DeploymentTimeAnnotation).
+			Msg("ignoring invalid deployment time")
+		return time.Time{}, false
 	}
 
-	// Record success
-	p.appendSuccess(successList, item.GetName())
-	return nil
+	until := deployedAt.Add(p.resource.DeploymentGracePeriod)
+	return until, time.Now().Before(until)
+}
+
+// parseDeploymentTime parses a deploy time given as RFC3339 or Unix seconds.
+func parseDeploymentTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("deployment time %q is neither RFC3339 nor Unix seconds", value)
+	}
+
+	return time.Unix(seconds, 0), nil
+}
+
+// scaledReason returns the event reason matching the current period type.
+func (p *Processor) scaledReason() string {
+	switch p.resource.Period.Type {
+	case common.PeriodTypeDown:
+		return consts.EventReasonScaledDown
+	case common.PeriodTypeUp:
+		return consts.EventReasonScaledUp
+	default:
+		return consts.EventReasonRestored
+	}
+}
+
+// recordEvent records an event on resource, naming the scaler and period responsible for it.
+// It is a no-op when events are disabled.
+func (p *Processor) recordEvent(resource ResourceItem, eventType, reason, detail string) {
+	if p.resource.Recorder == nil {
+		return
+	}
+
+	note := fmt.Sprintf("period %q of scaler %s", p.resource.Period.Name, p.scalerName())
+	if detail != "" {
+		note += ": " + detail
+	}
+	if len(note) > maxEventNoteLength {
+		note = note[:maxEventNoteLength-3] + "..."
+	}
+
+	p.resource.Recorder.Eventf(resource.GetObject(), p.resource.Scaler, eventType, reason, consts.EventActionScale, "%s", note)
+}
+
+// scalerName returns the name of the scaler owning the processed resources.
+func (p *Processor) scalerName() string {
+	if p.resource.Scaler == nil {
+		return "unknown"
+	}
+
+	accessor, err := meta.Accessor(p.resource.Scaler)
+	if err != nil {
+		return "unknown"
+	}
+
+	return accessor.GetName()
 }
 
 // appendFailure appends a failure to the list.
@@ -202,3 +713,24 @@ func (p *Processor) appendSuccess(successList *[]common.ScalerStatusSuccess, nam
 		Name: name,
 	})
 }
+
+// appendPostponed appends a resource whose scale-down is postponed until until to the list,
+// where it is counted apart from the resources scaled.
+func (p *Processor) appendPostponed(successList *[]common.ScalerStatusSuccess, name, comment string, until time.Time) {
+	*successList = append(*successList, common.ScalerStatusSuccess{
+		Kind:           p.strategy.GetKind(),
+		Name:           name,
+		Comment:        comment,
+		PostponedUntil: &metaV1.Time{Time: until},
+	})
+}
+
+// appendCommentedSuccess appends a success with a comment, such as the would-be state of a dry
+// run or the reason a resource was left untouched, to the list.
+func (p *Processor) appendCommentedSuccess(successList *[]common.ScalerStatusSuccess, name, comment string) {
+	*successList = append(*successList, common.ScalerStatusSuccess{
+		Kind:    p.strategy.GetKind(),
+		Name:    name,
+		Comment: comment,
+	})
+}
@@ -19,12 +19,26 @@ package base
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
 
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
 )
@@ -51,14 +65,14 @@ func (m *mockGetter) Get(
 	return m.getFn(ctx, namespace, name, opts)
 }
 
-type mockUpdater struct {
-	updateFn func(ctx context.Context, namespace string, resource ResourceItem, opts metaV1.UpdateOptions) (ResourceItem, error)
+type mockPatcher struct {
+	patchFn func(ctx context.Context, namespace, name string, data []byte, opts metaV1.PatchOptions) (ResourceItem, error)
 }
 
-func (m *mockUpdater) Update(
-	ctx context.Context, namespace string, resource ResourceItem, opts metaV1.UpdateOptions,
+func (m *mockPatcher) Patch(
+	ctx context.Context, namespace, name string, data []byte, opts metaV1.PatchOptions,
 ) (ResourceItem, error) {
-	return m.updateFn(ctx, namespace, resource, opts)
+	return m.patchFn(ctx, namespace, name, data, opts)
 }
 
 type mockStrategy struct {
@@ -79,17 +93,26 @@ func (m *mockStrategy) GetKind() string { return m.kind }
 func newTestProcessor(
 	lister ResourceLister,
 	getter ResourceGetter,
-	updater ResourceUpdater,
+	patcher ResourcePatcher,
 	strategy ScalingStrategy,
 	resource *utils.K8sResource,
 ) *Processor {
-	return NewProcessor(lister, getter, updater, strategy, resource, testLogger())
+	return NewProcessor(lister, getter, patcher, strategy, resource, testLogger())
 }
 
 func newItem(name, namespace string) *mockResourceItem {
 	return &mockResourceItem{name: name, namespace: namespace, annotations: map[string]string{}}
 }
 
+// markScaled mutates resource the way a strategy would, so that the processor patches it.
+func markScaled(_ context.Context, resource ResourceItem, _ string, _ *periodPkg.Period) (bool, error) {
+	resource.SetAnnotations(map[string]string{"scaled": "true"})
+	return false, nil
+}
+
+// conflictErr is returned by patchers simulating a concurrent change.
+var conflictErr = apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "app", errors.New("modified"))
+
 // --- Tests ---
 
 func TestProcessResources(t *testing.T) {
@@ -102,7 +125,7 @@ func TestProcessResources(t *testing.T) {
 		resource        *utils.K8sResource
 		lister          *mockLister
 		getter          *mockGetter
-		updater         *mockUpdater
+		patcher         *mockPatcher
 		strategy        *mockStrategy
 		wantSuccessLen  int
 		wantFailedLen   int
@@ -121,7 +144,7 @@ func TestProcessResources(t *testing.T) {
 				},
 			},
 			getter:          &mockGetter{},
-			updater:         &mockUpdater{},
+			patcher:         &mockPatcher{},
 			strategy:        &mockStrategy{kind: "Deployment"},
 			wantSuccessLen:  0,
 			wantFailedLen:   0,
@@ -140,7 +163,7 @@ func TestProcessResources(t *testing.T) {
 				},
 			},
 			getter:         &mockGetter{},
-			updater:        &mockUpdater{},
+			patcher:        &mockPatcher{},
 			strategy:       &mockStrategy{kind: "Deployment"},
 			wantSuccessLen: 0,
 			wantFailedLen:  0,
@@ -167,9 +190,38 @@ func TestProcessResources(t *testing.T) {
 					return newItem(name, "default"), nil
 				},
 			},
-			updater: &mockUpdater{
-				updateFn: func(_ context.Context, _ string, r ResourceItem, _ metaV1.UpdateOptions) (ResourceItem, error) {
-					return r, nil
+			patcher: &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					return nil, nil
+				},
+			},
+			strategy: &mockStrategy{
+				kind: "Deployment",
+				applyScalingFn: func(_ context.Context, _ ResourceItem, _ string, _ *periodPkg.Period) (bool, error) {
+					return false, nil
+				},
+			},
+			wantSuccessLen: 1,
+			wantFailedLen:  0,
+			wantErr:        false,
+		},
+		{
+			name: "unchanged resource is not patched",
+			resource: &utils.K8sResource{
+				NsList: []string{"default"},
+				Period: &periodPkg.Period{},
+			},
+			lister: &mockLister{
+				listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+					return []ResourceItem{newItem("steady", "default")}, nil
+				},
+			},
+			getter: &mockGetter{},
+			patcher: &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					t.Fatal("patcher should not be called when the strategy changed nothing")
+
+					return nil, nil
 				},
 			},
 			strategy: &mockStrategy{
@@ -183,7 +235,41 @@ func TestProcessResources(t *testing.T) {
 			wantErr:        false,
 		},
 		{
-			name: "getter non-context error adds to failed and continues",
+			name: "conflict refetches the resource and retries",
+			resource: &utils.K8sResource{
+				NsList: []string{"default"},
+				Period: &periodPkg.Period{},
+			},
+			lister: &mockLister{
+				listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+					return []ResourceItem{newItem("stale", "default")}, nil
+				},
+			},
+			getter: &mockGetter{
+				getFn: func(_ context.Context, _, name string, _ metaV1.GetOptions) (ResourceItem, error) {
+					return newItem(name, "default"), nil
+				},
+			},
+			patcher: func() *mockPatcher {
+				calls := 0
+				return &mockPatcher{
+					patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+						calls++
+						if calls == 1 {
+							return nil, conflictErr
+						}
+
+						return nil, nil
+					},
+				}
+			}(),
+			strategy:       &mockStrategy{kind: "Deployment", applyScalingFn: markScaled},
+			wantSuccessLen: 1,
+			wantFailedLen:  0,
+			wantErr:        false,
+		},
+		{
+			name: "getter error after a conflict adds to failed and continues",
 			resource: &utils.K8sResource{
 				NsList: []string{"default"},
 				Period: &periodPkg.Period{},
@@ -197,25 +283,20 @@ func TestProcessResources(t *testing.T) {
 				},
 			},
 			getter: &mockGetter{
-				getFn: func(_ context.Context, _, name string, _ metaV1.GetOptions) (ResourceItem, error) {
+				getFn: func(_ context.Context, _, _ string, _ metaV1.GetOptions) (ResourceItem, error) {
+					return nil, errTest
+				},
+			},
+			patcher: &mockPatcher{
+				patchFn: func(_ context.Context, _, name string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
 					if name == "fail-get" {
-						return nil, errTest
+						return nil, conflictErr
 					}
 
-					return newItem(name, "default"), nil
-				},
-			},
-			updater: &mockUpdater{
-				updateFn: func(_ context.Context, _ string, r ResourceItem, _ metaV1.UpdateOptions) (ResourceItem, error) {
-					return r, nil
-				},
-			},
-			strategy: &mockStrategy{
-				kind: "Deployment",
-				applyScalingFn: func(_ context.Context, _ ResourceItem, _ string, _ *periodPkg.Period) (bool, error) {
-					return false, nil
+					return nil, nil
 				},
 			},
+			strategy:       &mockStrategy{kind: "Deployment", applyScalingFn: markScaled},
 			wantSuccessLen: 1,
 			wantFailedLen:  1,
 			wantErr:        false,
@@ -239,8 +320,12 @@ func TestProcessResources(t *testing.T) {
 					return nil, context.Canceled
 				},
 			},
-			updater:        &mockUpdater{},
-			strategy:       &mockStrategy{kind: "Deployment"},
+			patcher: &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					return nil, conflictErr
+				},
+			},
+			strategy:       &mockStrategy{kind: "Deployment", applyScalingFn: markScaled},
 			wantSuccessLen: 0,
 			wantFailedLen:  1,
 			wantErr:        true,
@@ -264,9 +349,9 @@ func TestProcessResources(t *testing.T) {
 					return newItem(name, "default"), nil
 				},
 			},
-			updater: &mockUpdater{
-				updateFn: func(_ context.Context, _ string, r ResourceItem, _ metaV1.UpdateOptions) (ResourceItem, error) {
-					return r, nil
+			patcher: &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					return nil, nil
 				},
 			},
 			strategy: &mockStrategy{
@@ -284,7 +369,7 @@ func TestProcessResources(t *testing.T) {
 			wantErr:        false,
 		},
 		{
-			name: "alreadyRestored true skips update and does not add to success",
+			name: "alreadyRestored true skips patch and does not add to success",
 			resource: &utils.K8sResource{
 				NsList: []string{"default"},
 				Period: &periodPkg.Period{},
@@ -299,9 +384,9 @@ func TestProcessResources(t *testing.T) {
 					return newItem(name, "default"), nil
 				},
 			},
-			updater: &mockUpdater{
-				updateFn: func(_ context.Context, _ string, _ ResourceItem, _ metaV1.UpdateOptions) (ResourceItem, error) {
-					t.Fatal("updater should not be called when alreadyRestored is true")
+			patcher: &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					t.Fatal("patcher should not be called when alreadyRestored is true")
 
 					return nil, nil
 				},
@@ -317,7 +402,7 @@ func TestProcessResources(t *testing.T) {
 			wantErr:        false,
 		},
 		{
-			name: "updater error adds to failed",
+			name: "patcher error adds to failed",
 			resource: &utils.K8sResource{
 				NsList: []string{"default"},
 				Period: &periodPkg.Period{},
@@ -332,16 +417,14 @@ func TestProcessResources(t *testing.T) {
 					return newItem(name, "default"), nil
 				},
 			},
-			updater: &mockUpdater{
-				updateFn: func(_ context.Context, _ string, _ ResourceItem, _ metaV1.UpdateOptions) (ResourceItem, error) {
+			patcher: &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
 					return nil, errTest
 				},
 			},
 			strategy: &mockStrategy{
-				kind: "Deployment",
-				applyScalingFn: func(_ context.Context, _ ResourceItem, _ string, _ *periodPkg.Period) (bool, error) {
-					return false, nil
-				},
+				kind:           "Deployment",
+				applyScalingFn: markScaled,
 			},
 			wantSuccessLen: 0,
 			wantFailedLen:  1,
@@ -367,9 +450,9 @@ func TestProcessResources(t *testing.T) {
 					return newItem(name, ns), nil
 				},
 			},
-			updater: &mockUpdater{
-				updateFn: func(_ context.Context, _ string, r ResourceItem, _ metaV1.UpdateOptions) (ResourceItem, error) {
-					return r, nil
+			patcher: &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					return nil, nil
 				},
 			},
 			strategy: &mockStrategy{
@@ -388,7 +471,7 @@ func TestProcessResources(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			processor := newTestProcessor(tc.lister, tc.getter, tc.updater, tc.strategy, tc.resource)
+			processor := newTestProcessor(tc.lister, tc.getter, tc.patcher, tc.strategy, tc.resource)
 
 			success, failed, err := processor.ProcessResources(context.Background())
 
@@ -422,14 +505,14 @@ func TestProcessResources_ContextDeadlineExceeded(t *testing.T) {
 		},
 	}
 
-	getter := &mockGetter{
-		getFn: func(_ context.Context, _, _ string, _ metaV1.GetOptions) (ResourceItem, error) {
+	patcher := &mockPatcher{
+		patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
 			return nil, context.DeadlineExceeded
 		},
 	}
 
-	strategy := &mockStrategy{kind: "StatefulSet"}
-	processor := newTestProcessor(lister, getter, &mockUpdater{}, strategy, resource)
+	strategy := &mockStrategy{kind: "StatefulSet", applyScalingFn: markScaled}
+	processor := newTestProcessor(lister, &mockGetter{}, patcher, strategy, resource)
 
 	_, _, err := processor.ProcessResources(context.Background())
 
@@ -451,14 +534,14 @@ func TestProcessResources_FailedEntryContainsKindAndReason(t *testing.T) {
 		},
 	}
 
-	getter := &mockGetter{
-		getFn: func(_ context.Context, _, _ string, _ metaV1.GetOptions) (ResourceItem, error) {
+	patcher := &mockPatcher{
+		patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
 			return nil, errors.New("not found")
 		},
 	}
 
-	strategy := &mockStrategy{kind: "CronJob"}
-	processor := newTestProcessor(lister, getter, &mockUpdater{}, strategy, resource)
+	strategy := &mockStrategy{kind: "CronJob", applyScalingFn: markScaled}
+	processor := newTestProcessor(lister, &mockGetter{}, patcher, strategy, resource)
 
 	_, failed, _ := processor.ProcessResources(context.Background())
 
@@ -488,9 +571,9 @@ func TestProcessResources_SuccessEntryContainsKindAndName(t *testing.T) {
 		},
 	}
 
-	updater := &mockUpdater{
-		updateFn: func(_ context.Context, _ string, r ResourceItem, _ metaV1.UpdateOptions) (ResourceItem, error) {
-			return r, nil
+	patcher := &mockPatcher{
+		patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+			return nil, nil
 		},
 	}
 
@@ -501,7 +584,7 @@ func TestProcessResources_SuccessEntryContainsKindAndName(t *testing.T) {
 		},
 	}
 
-	processor := newTestProcessor(lister, getter, updater, strategy, resource)
+	processor := newTestProcessor(lister, getter, patcher, strategy, resource)
 
 	success, _, err := processor.ProcessResources(context.Background())
 
@@ -510,3 +593,849 @@ func TestProcessResources_SuccessEntryContainsKindAndName(t *testing.T) {
 	assert.Equal(t, "HPA", success[0].Kind)
 	assert.Equal(t, "my-app", success[0].Name)
 }
+
+func TestProcessResources_DryRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		strategy        ScalingStrategy
+		expectedComment string
+	}{
+		{
+			name: "reports current and would-be state",
+			strategy: func() ScalingStrategy {
+				replicas := ptr.To(int32(3))
+				return NewIntReplicasStrategy(
+					"deployment",
+					func(_ ResourceItem) *int32 { return replicas },
+					func(_ ResourceItem, v *int32) { replicas = v },
+					testLogger(),
+					utils.NewAnnotationManager(),
+				)
+			}(),
+			expectedComment: "dry run: replicas=3 -> replicas=0",
+		},
+		{
+			name:            "falls back to a plain comment for strategies without state description",
+			strategy:        &mockStrategy{kind: "deployment", applyScalingFn: markScaled},
+			expectedComment: "dry run",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			period := newTestPeriod()
+			period.MinReplicas = 0
+			resource := &utils.K8sResource{
+				NsList: []string{"default"},
+				Period: period,
+				DryRun: true,
+			}
+
+			lister := &mockLister{
+				listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+					return []ResourceItem{newItem("my-app", "default")}, nil
+				},
+			}
+			getter := &mockGetter{
+				getFn: func(_ context.Context, _, name string, _ metaV1.GetOptions) (ResourceItem, error) {
+					return newItem(name, "default"), nil
+				},
+			}
+
+			var patchOpts metaV1.PatchOptions
+			patcher := &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, opts metaV1.PatchOptions) (ResourceItem, error) {
+					patchOpts = opts
+					return nil, nil
+				},
+			}
+
+			processor := newTestProcessor(lister, getter, patcher, tt.strategy, resource)
+
+			success, failed, err := processor.ProcessResources(context.Background())
+
+			require.NoError(t, err)
+			assert.Empty(t, failed)
+			require.Len(t, success, 1)
+			assert.Equal(t, tt.expectedComment, success[0].Comment)
+			assert.Equal(t, []string{metaV1.DryRunAll}, patchOpts.DryRun)
+		})
+	}
+}
+
+func TestProcessResources_Events(t *testing.T) {
+	t.Parallel()
+
+	errUpdate := errors.New("forbidden")
+
+	tests := []struct {
+		name          string
+		replicas      int32
+		updateErr     error
+		dryRun        bool
+		expectedEvent string
+	}{
+		{
+			name:          "records the scaled state on the resource",
+			replicas:      3,
+			expectedEvent: `Normal ScaledDown period "night" of scaler my-scaler: replicas=3 -> replicas=0`,
+		},
+		{
+			name:          "records a warning when the update fails",
+			replicas:      3,
+			updateErr:     errUpdate,
+			expectedEvent: `Warning ScalingFailed period "night" of scaler my-scaler: forbidden`,
+		},
+		{
+			name:     "records nothing when the state is unchanged",
+			replicas: 0,
+		},
+		{
+			name:     "records nothing on dry run",
+			replicas: 3,
+			dryRun:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := events.NewFakeRecorder(10)
+			period := newTestPeriod()
+			period.Name = "night"
+			resource := &utils.K8sResource{
+				NsList:   []string{"default"},
+				Period:   period,
+				DryRun:   tt.dryRun,
+				Recorder: recorder,
+				Scaler:   &appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "my-scaler"}},
+			}
+
+			replicas := ptr.To(tt.replicas)
+			strategy := NewIntReplicasStrategy(
+				"deployment",
+				func(_ ResourceItem) *int32 { return replicas },
+				func(_ ResourceItem, v *int32) { replicas = v },
+				testLogger(),
+				utils.NewAnnotationManager(),
+			)
+
+			lister := &mockLister{
+				listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+					return []ResourceItem{newItem("my-app", "default")}, nil
+				},
+			}
+			getter := &mockGetter{
+				getFn: func(_ context.Context, _, name string, _ metaV1.GetOptions) (ResourceItem, error) {
+					return newItem(name, "default"), nil
+				},
+			}
+			patcher := &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					return nil, tt.updateErr
+				},
+			}
+
+			processor := newTestProcessor(lister, getter, patcher, strategy, resource)
+
+			_, _, err := processor.ProcessResources(context.Background())
+			require.NoError(t, err)
+
+			if tt.expectedEvent == "" {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			require.Len(t, recorder.Events, 1)
+			assert.Equal(t, tt.expectedEvent, <-recorder.Events)
+		})
+	}
+}
+
+func TestProcessResources_DeploymentGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	const annotation = "example.com/deployed-at"
+	recent := time.Now().Add(-5 * time.Minute)
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		periodType    common.PeriodType
+		expectUpdate  bool
+		expectComment string
+	}{
+		{
+			name:          "postpones scale-down of a workload deployed recently (RFC3339)",
+			annotations:   map[string]string{annotation: recent.Format(time.RFC3339)},
+			periodType:    common.PeriodTypeDown,
+			expectComment: "scale-down postponed until " + recent.Add(time.Hour).UTC().Format(time.RFC3339) + " (recently deployed)",
+		},
+		{
+			name:          "postpones scale-down of a workload deployed recently (Unix seconds)",
+			annotations:   map[string]string{annotation: strconv.FormatInt(recent.Unix(), 10)},
+			periodType:    common.PeriodTypeDown,
+			expectComment: "scale-down postponed until " + recent.Add(time.Hour).UTC().Format(time.RFC3339) + " (recently deployed)",
+		},
+		{
+			name:         "scales down once the grace period has elapsed",
+			annotations:  map[string]string{annotation: time.Now().Add(-2 * time.Hour).Format(time.RFC3339)},
+			periodType:   common.PeriodTypeDown,
+			expectUpdate: true,
+		},
+		{
+			name:         "scales down workloads without the annotation",
+			annotations:  map[string]string{},
+			periodType:   common.PeriodTypeDown,
+			expectUpdate: true,
+		},
+		{
+			name:         "ignores an invalid deployment time",
+			annotations:  map[string]string{annotation: "yesterday"},
+			periodType:   common.PeriodTypeDown,
+			expectUpdate: true,
+		},
+		{
+			name:         "never postpones scale-up",
+			annotations:  map[string]string{annotation: recent.Format(time.RFC3339)},
+			periodType:   common.PeriodTypeUp,
+			expectUpdate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			period := newTestPeriod()
+			period.Type = tt.periodType
+			resource := &utils.K8sResource{
+				NsList:                   []string{"default"},
+				Period:                   period,
+				DeploymentTimeAnnotation: annotation,
+				DeploymentGracePeriod:    time.Hour,
+			}
+
+			lister := &mockLister{
+				listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+					return []ResourceItem{&mockResourceItem{name: "my-app", namespace: "default", annotations: tt.annotations}}, nil
+				},
+			}
+			updated := false
+			patcher := &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					updated = true
+					return nil, nil
+				},
+			}
+			strategy := &mockStrategy{
+				kind:           "deployment",
+				applyScalingFn: markScaled,
+			}
+
+			processor := newTestProcessor(lister, &mockGetter{}, patcher, strategy, resource)
+
+			success, failed, err := processor.ProcessResources(context.Background())
+
+			require.NoError(t, err)
+			assert.Empty(t, failed)
+			require.Len(t, success, 1)
+			assert.Equal(t, tt.expectUpdate, updated)
+			assert.Equal(t, tt.expectComment, success[0].Comment)
+			assert.Equal(t, tt.expectComment != "", success[0].PostponedUntil != nil, "postponed resources are told apart")
+		})
+	}
+}
+
+func TestProcessResources_Drift(t *testing.T) {
+	t.Parallel()
+
+	period := newTestPeriod()
+	periodAnnotations := map[string]string{
+		utils.AnnotationsPrefix + "/" + utils.PeriodType:      string(period.Type),
+		utils.AnnotationsPrefix + "/" + utils.PeriodStartTime: period.StartTime.Format(time.RFC3339),
+	}
+	previousPeriodAnnotations := map[string]string{
+		utils.AnnotationsPrefix + "/" + utils.PeriodType:      string(period.Type),
+		utils.AnnotationsPrefix + "/" + utils.PeriodStartTime: period.StartTime.Add(-24 * time.Hour).Format(time.RFC3339),
+	}
+
+	tests := []struct {
+		name        string
+		item        *mockResourceItem
+		apply       func(context.Context, ResourceItem, string, *periodPkg.Period) (bool, error)
+		expectDrift bool
+	}{
+		{
+			name:        "counts a resource scaled for the current period that changed since",
+			item:        &mockResourceItem{name: "app", namespace: "default", annotations: periodAnnotations},
+			apply:       markScaled,
+			expectDrift: true,
+		},
+		{
+			name:        "counts a resource created after the period started",
+			item:        &mockResourceItem{name: "app", namespace: "default", created: period.StartTime.Add(time.Hour)},
+			apply:       markScaled,
+			expectDrift: true,
+		},
+		{
+			name:  "ignores a resource scaled for a previous period",
+			item:  &mockResourceItem{name: "app", namespace: "default", annotations: previousPeriodAnnotations},
+			apply: markScaled,
+		},
+		{
+			name:  "ignores a resource created before the period started",
+			item:  &mockResourceItem{name: "app", namespace: "default", created: period.StartTime.Add(-time.Hour)},
+			apply: markScaled,
+		},
+		{
+			name: "ignores a resource still in the period state",
+			item: &mockResourceItem{name: "app", namespace: "default", annotations: periodAnnotations},
+			apply: func(context.Context, ResourceItem, string, *periodPkg.Period) (bool, error) {
+				return false, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var drifts []string
+			resource := &utils.K8sResource{
+				NsList:  []string{"default"},
+				Period:  newTestPeriod(),
+				OnDrift: func(kind string) { drifts = append(drifts, kind) },
+			}
+			lister := &mockLister{
+				listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+					return []ResourceItem{tt.item}, nil
+				},
+			}
+			patcher := &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					return nil, nil
+				},
+			}
+			strategy := &mockStrategy{kind: "deployment", applyScalingFn: tt.apply}
+
+			processor := newTestProcessor(lister, &mockGetter{}, patcher, strategy, resource)
+
+			_, failed, err := processor.ProcessResources(context.Background())
+
+			require.NoError(t, err)
+			assert.Empty(t, failed)
+			if tt.expectDrift {
+				assert.Equal(t, []string{"deployment"}, drifts)
+			} else {
+				assert.Empty(t, drifts)
+			}
+		})
+	}
+}
+
+func TestProcessResources_Filter(t *testing.T) {
+	t.Parallel()
+
+	resource := &utils.K8sResource{
+		NsList: []string{"default"},
+		Period: newTestPeriod(),
+		Filter: func(obj metaV1.Object) bool { return obj.GetLabels()["tier"] == "db" },
+	}
+	lister := &mockLister{
+		listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+			return []ResourceItem{
+				&mockResourceItem{name: "postgres", namespace: "default", labels: map[string]string{"tier": "db"}},
+				&mockResourceItem{name: "api", namespace: "default", labels: map[string]string{"tier": "app"}},
+			}, nil
+		},
+	}
+	patcher := &mockPatcher{
+		patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+			return nil, nil
+		},
+	}
+	strategy := &mockStrategy{kind: "deployment", applyScalingFn: markScaled}
+
+	processor := newTestProcessor(lister, &mockGetter{}, patcher, strategy, resource)
+
+	success, failed, err := processor.ProcessResources(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, failed)
+	require.Len(t, success, 1)
+	assert.Equal(t, "postgres", success[0].Name)
+}
+
+// mockReadyItem is a resource item reporting its readiness.
+type mockReadyItem struct {
+	*mockResourceItem
+	ready  bool
+	reason string
+}
+
+func (m *mockReadyItem) Ready() (bool, string) { return m.ready, m.reason }
+
+// mockDiagnosingGetter is a resource getter explaining why resources are not ready.
+type mockDiagnosingGetter struct {
+	*mockGetter
+	diagnosis string
+}
+
+func (m *mockDiagnosingGetter) DiagnoseReadiness(context.Context, ResourceItem) (string, error) {
+	return m.diagnosis, nil
+}
+
+func TestProcessResources_Readiness(t *testing.T) {
+	t.Parallel()
+
+	upPeriod := func() *periodPkg.Period {
+		period := newTestPeriod()
+		period.Type = common.PeriodTypeUp
+		return period
+	}
+
+	tests := []struct {
+		name          string
+		period        *periodPkg.Period
+		timeout       time.Duration
+		item          ResourceItem
+		unchanged     bool
+		diagnosis     string
+		readyAfter    int32
+		expectGets    bool
+		expectFailure string
+		expectSuccess bool
+	}{
+		{
+			name:          "waits until the resource is ready",
+			period:        upPeriod(),
+			timeout:       time.Minute,
+			item:          &mockReadyItem{mockResourceItem: newItem("app", "default")},
+			readyAfter:    3,
+			expectGets:    true,
+			expectSuccess: true,
+		},
+		{
+			name:          "fails a resource still not ready after the timeout",
+			period:        upPeriod(),
+			timeout:       50 * time.Millisecond,
+			item:          &mockReadyItem{mockResourceItem: newItem("app", "default")},
+			readyAfter:    1000,
+			expectGets:    true,
+			expectFailure: "not ready after 50ms: 0/1 replicas ready",
+		},
+		{
+			name:          "explains why a resource is not ready",
+			period:        upPeriod(),
+			timeout:       50 * time.Millisecond,
+			item:          &mockReadyItem{mockResourceItem: newItem("app", "default")},
+			diagnosis:     "pod app-1: container app: ImagePullBackOff",
+			readyAfter:    1000,
+			expectGets:    true,
+			expectFailure: "not ready after 50ms: 0/1 replicas ready (pod app-1: container app: ImagePullBackOff)",
+		},
+		{
+			name:          "checks a resource already scaled only once",
+			period:        upPeriod(),
+			timeout:       time.Minute,
+			item:          &mockReadyItem{mockResourceItem: newItem("app", "default")},
+			unchanged:     true,
+			readyAfter:    1000,
+			expectGets:    true,
+			expectFailure: "not ready: 0/1 replicas ready",
+		},
+		{
+			name:          "does not wait during down periods",
+			period:        newTestPeriod(),
+			timeout:       time.Minute,
+			item:          &mockReadyItem{mockResourceItem: newItem("app", "default")},
+			readyAfter:    1000,
+			expectSuccess: true,
+		},
+		{
+			name:          "does not wait without a timeout",
+			period:        upPeriod(),
+			item:          &mockReadyItem{mockResourceItem: newItem("app", "default")},
+			readyAfter:    1000,
+			expectSuccess: true,
+		},
+		{
+			name:          "does not wait for resources without readiness",
+			period:        upPeriod(),
+			timeout:       time.Minute,
+			item:          newItem("app", "default"),
+			expectSuccess: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resource := &utils.K8sResource{
+				NsList:           []string{"default"},
+				Period:           tt.period,
+				ReadinessTimeout: tt.timeout,
+			}
+			lister := &mockLister{
+				listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+					return []ResourceItem{tt.item}, nil
+				},
+			}
+			var gets atomic.Int32
+			getter := &mockGetter{
+				getFn: func(_ context.Context, namespace, name string, _ metaV1.GetOptions) (ResourceItem, error) {
+					ready := gets.Add(1) >= tt.readyAfter
+					return &mockReadyItem{mockResourceItem: newItem(name, namespace), ready: ready, reason: "0/1 replicas ready"}, nil
+				},
+			}
+			patcher := &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					return nil, nil
+				},
+			}
+			strategy := &mockStrategy{kind: "deployment", applyScalingFn: markScaled}
+			if tt.unchanged {
+				strategy.applyScalingFn = func(context.Context, ResourceItem, string, *periodPkg.Period) (bool, error) {
+					return false, nil
+				}
+			}
+			diagnosingGetter := &mockDiagnosingGetter{mockGetter: getter, diagnosis: tt.diagnosis}
+
+			processor := newTestProcessor(lister, diagnosingGetter, patcher, strategy, resource)
+			processor.pollInterval = 5 * time.Millisecond
+
+			success, failed, err := processor.ProcessResources(context.Background())
+
+			require.NoError(t, err)
+			if tt.expectGets {
+				assert.Positive(t, gets.Load())
+			} else {
+				assert.Zero(t, gets.Load())
+			}
+			if tt.expectSuccess {
+				assert.Len(t, success, 1)
+				assert.Empty(t, failed)
+			}
+			if tt.expectFailure != "" {
+				assert.Empty(t, success)
+				require.Len(t, failed, 1)
+				assert.Equal(t, tt.expectFailure, failed[0].Reason)
+			}
+		})
+	}
+}
+
+func TestReplicasReady(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		generation         int64
+		observedGeneration int64
+		desired            *int32
+		ready              int32
+		expectReady        bool
+		expectReason       string
+	}{
+		{name: "ready", generation: 2, observedGeneration: 2, desired: ptr.To(int32(3)), ready: 3, expectReady: true},
+		{name: "missing replicas", generation: 2, observedGeneration: 2, desired: ptr.To(int32(3)), ready: 1, expectReason: "1/3 replicas ready"},
+		{name: "unset replicas default to one", generation: 1, observedGeneration: 1, ready: 0, expectReason: "0/1 replicas ready"},
+		{name: "scaled to zero", generation: 1, observedGeneration: 1, desired: ptr.To(int32(0)), expectReady: true},
+		{name: "stale status", generation: 3, observedGeneration: 2, desired: ptr.To(int32(1)), ready: 1, expectReason: "latest generation not observed yet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ready, reason := ReplicasReady(tt.generation, tt.observedGeneration, tt.desired, tt.ready)
+
+			assert.Equal(t, tt.expectReady, ready)
+			assert.Equal(t, tt.expectReason, reason)
+		})
+	}
+}
+
+// mockReleasingItem is a resource item reporting the resources its scale-down freed.
+type mockReleasingItem struct {
+	*mockResourceItem
+	released coreV1.ResourceList
+}
+
+func (m *mockReleasingItem) Released() coreV1.ResourceList { return m.released }
+
+func TestProcessResources_Release(t *testing.T) {
+	t.Parallel()
+
+	released := coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("2")}
+	upPeriod := newTestPeriod()
+	upPeriod.Type = common.PeriodTypeUp
+
+	tests := []struct {
+		name          string
+		period        *periodPkg.Period
+		item          ResourceItem
+		dryRun        bool
+		expectRelease bool
+	}{
+		{
+			name:          "reports the resources freed during down periods",
+			period:        newTestPeriod(),
+			item:          &mockReleasingItem{mockResourceItem: newItem("app", "default"), released: released},
+			expectRelease: true,
+		},
+		{
+			name:   "does not report outside of down periods",
+			period: upPeriod,
+			item:   &mockReleasingItem{mockResourceItem: newItem("app", "default"), released: released},
+		},
+		{
+			name:   "does not report dry runs",
+			period: newTestPeriod(),
+			item:   &mockReleasingItem{mockResourceItem: newItem("app", "default"), released: released},
+			dryRun: true,
+		},
+		{
+			name:   "ignores resources not running pods",
+			period: newTestPeriod(),
+			item:   newItem("app", "default"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var reports []string
+			resource := &utils.K8sResource{
+				NsList: []string{"default"},
+				Period: tt.period,
+				DryRun: tt.dryRun,
+				OnRelease: func(kind string, requests coreV1.ResourceList) {
+					reports = append(reports, kind+":"+requests.Cpu().String())
+				},
+			}
+			lister := &mockLister{
+				listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+					return []ResourceItem{tt.item}, nil
+				},
+			}
+			patcher := &mockPatcher{
+				patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+					return nil, nil
+				},
+			}
+			strategy := &mockStrategy{kind: "deployment", applyScalingFn: markScaled}
+
+			processor := newTestProcessor(lister, &mockGetter{}, patcher, strategy, resource)
+
+			_, failed, err := processor.ProcessResources(context.Background())
+
+			require.NoError(t, err)
+			assert.Empty(t, failed)
+			if tt.expectRelease {
+				assert.Equal(t, []string{"deployment:2"}, reports)
+			} else {
+				assert.Empty(t, reports)
+			}
+		})
+	}
+}
+
+func TestReleasedRequests(t *testing.T) {
+	t.Parallel()
+
+	template := &coreV1.PodSpec{Containers: []coreV1.Container{
+		{Resources: coreV1.ResourceRequirements{Requests: coreV1.ResourceList{
+			coreV1.ResourceCPU:    resource.MustParse("250m"),
+			coreV1.ResourceMemory: resource.MustParse("256Mi"),
+		}}},
+		{Resources: coreV1.ResourceRequirements{Requests: coreV1.ResourceList{
+			coreV1.ResourceCPU: resource.MustParse("250m"),
+		}}},
+	}}
+	original := func(replicas string) map[string]string {
+		return map[string]string{utils.AnnotationsPrefix + "/" + utils.AnnotationsOrigValue: replicas}
+	}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		replicas     *int32
+		expectCPU    string
+		expectMemory string
+	}{
+		{name: "scaled to zero", annotations: original("3"), replicas: ptr.To(int32(0)), expectCPU: "1500m", expectMemory: "768Mi"},
+		{name: "scaled down partially", annotations: original("3"), replicas: ptr.To(int32(1)), expectCPU: "1", expectMemory: "512Mi"},
+		{name: "unset replicas default to one", annotations: original("2"), expectCPU: "500m", expectMemory: "256Mi"},
+		{name: "not scaled down", annotations: original("1"), replicas: ptr.To(int32(1))},
+		{name: "no original replicas", annotations: map[string]string{}, replicas: ptr.To(int32(0))},
+		{name: "invalid original replicas", annotations: original("many"), replicas: ptr.To(int32(0))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			released := ReleasedRequests(tt.annotations, tt.replicas, template)
+
+			if tt.expectCPU == "" {
+				assert.Empty(t, released)
+				return
+			}
+			assert.Equal(t, tt.expectCPU, released.Cpu().String())
+			assert.Equal(t, tt.expectMemory, released.Memory().String())
+		})
+	}
+}
+
+func TestProcessResources_Concurrency(t *testing.T) {
+	t.Parallel()
+
+	const concurrency = 3
+	namespaces := []string{"ns-a", "ns-b", "ns-c"}
+	resource := &utils.K8sResource{
+		NsList:      namespaces,
+		Period:      newTestPeriod(),
+		Concurrency: concurrency,
+	}
+
+	lister := &mockLister{
+		listFn: func(_ context.Context, namespace string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+			items := make([]ResourceItem, 0, 4)
+			for i := range 4 {
+				items = append(items, newItem(namespace+"-"+strconv.Itoa(i), namespace))
+			}
+			return items, nil
+		},
+	}
+
+	var inFlight, peak atomic.Int32
+	patcher := &mockPatcher{
+		patchFn: func(_ context.Context, _, name string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				seen := peak.Load()
+				if current <= seen || peak.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			if strings.HasSuffix(name, "-2") {
+				return nil, errors.New("patch error")
+			}
+			return nil, nil
+		},
+	}
+	strategy := &mockStrategy{kind: "deployment", applyScalingFn: markScaled}
+	processor := newTestProcessor(lister, &mockGetter{}, patcher, strategy, resource)
+
+	success, failed, err := processor.ProcessResources(context.Background())
+
+	require.NoError(t, err)
+	var successNames, failedNames, expectSuccess, expectFailed []string
+	for _, s := range success {
+		successNames = append(successNames, s.Name)
+	}
+	for _, f := range failed {
+		failedNames = append(failedNames, f.Name)
+	}
+	for _, ns := range namespaces {
+		expectSuccess = append(expectSuccess, ns+"-0", ns+"-1", ns+"-3")
+		expectFailed = append(expectFailed, ns+"-2")
+	}
+	assert.Equal(t, expectSuccess, successNames, "results keep the listing order")
+	assert.Equal(t, expectFailed, failedNames, "failures keep the listing order")
+	assert.LessOrEqual(t, peak.Load(), int32(concurrency))
+	assert.Greater(t, peak.Load(), int32(1), "resources are processed in parallel")
+}
+
+func TestProcessResources_SharedCalls(t *testing.T) {
+	t.Parallel()
+
+	const concurrency = 2
+	calls := semaphore.NewWeighted(concurrency)
+	var inFlight, peak atomic.Int32
+	patcher := &mockPatcher{
+		patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				seen := peak.Load()
+				if current <= seen || peak.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil, nil
+		},
+	}
+
+	var g errgroup.Group
+	for _, kind := range []string{"deployment", "statefulset", "cronjob"} {
+		resource := &utils.K8sResource{
+			NsList:      []string{"default"},
+			Period:      newTestPeriod(),
+			Concurrency: concurrency,
+			Calls:       calls,
+		}
+		lister := &mockLister{
+			listFn: func(_ context.Context, namespace string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+				items := make([]ResourceItem, 0, 4)
+				for i := range 4 {
+					items = append(items, newItem(kind+"-"+strconv.Itoa(i), namespace))
+				}
+				return items, nil
+			},
+		}
+		strategy := &mockStrategy{kind: kind, applyScalingFn: markScaled}
+		processor := newTestProcessor(lister, &mockGetter{}, patcher, strategy, resource)
+		g.Go(func() error {
+			_, _, err := processor.ProcessResources(context.Background())
+			return err
+		})
+	}
+
+	require.NoError(t, g.Wait())
+	assert.LessOrEqual(t, peak.Load(), int32(concurrency), "kinds share the bound on calls")
+}
+
+func TestProcessResources_ContextErrorSkipsRemainingResources(t *testing.T) {
+	t.Parallel()
+
+	resource := &utils.K8sResource{
+		NsList:      []string{"default"},
+		Period:      newTestPeriod(),
+		Concurrency: 1,
+	}
+
+	lister := &mockLister{
+		listFn: func(_ context.Context, _ string, _ metaV1.ListOptions) ([]ResourceItem, error) {
+			return []ResourceItem{newItem("item-1", "default"), newItem("item-2", "default")}, nil
+		},
+	}
+	var calls atomic.Int32
+	patcher := &mockPatcher{
+		patchFn: func(_ context.Context, _, _ string, _ []byte, _ metaV1.PatchOptions) (ResourceItem, error) {
+			calls.Add(1)
+			return nil, context.Canceled
+		},
+	}
+	strategy := &mockStrategy{kind: "deployment", applyScalingFn: markScaled}
+	processor := newTestProcessor(lister, &mockGetter{}, patcher, strategy, resource)
+
+	success, failed, err := processor.ProcessResources(context.Background())
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, success)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "item-1", failed[0].Name)
+	assert.Equal(t, int32(1), calls.Load())
+}
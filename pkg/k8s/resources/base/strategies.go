@@ -18,8 +18,15 @@ package base
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/rs/zerolog"
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
@@ -42,6 +49,14 @@ const (
 	CNPGHibernationOff = "off"
 )
 
+// formatReplicas formats an optional replica count for state descriptions.
+func formatReplicas(replicas *int32) string {
+	if replicas == nil {
+		return "unset"
+	}
+	return strconv.Itoa(int(*replicas))
+}
+
 // IntReplicasStrategy handles scaling for resources with integer replicas (Deployments, StatefulSets).
 type IntReplicasStrategy struct {
 	kind          string
@@ -73,6 +88,11 @@ func (s *IntReplicasStrategy) GetKind() string {
 	return s.kind
 }
 
+// DescribeState returns the replica count of the resource.
+func (s *IntReplicasStrategy) DescribeState(resource ResourceItem) string {
+	return "replicas=" + formatReplicas(s.getReplicas(resource))
+}
+
 // ApplyScaling applies scaling logic for integer replicas.
 func (s *IntReplicasStrategy) ApplyScaling(
 	_ context.Context,
@@ -139,6 +159,12 @@ func (s *MinMaxReplicasStrategy) GetKind() string {
 	return s.kind
 }
 
+// DescribeState returns the min/max replica counts of the resource.
+func (s *MinMaxReplicasStrategy) DescribeState(resource ResourceItem) string {
+	minReplicas, maxReplicas := s.getMinMaxReplicas(resource)
+	return fmt.Sprintf("minReplicas=%s, maxReplicas=%s", formatReplicas(minReplicas), formatReplicas(maxReplicas))
+}
+
 // ApplyScaling applies scaling logic for min/max replicas.
 func (s *MinMaxReplicasStrategy) ApplyScaling(
 	_ context.Context,
@@ -211,6 +237,11 @@ func (s *BoolSuspendStrategy) GetKind() string {
 	return s.kind
 }
 
+// DescribeState returns the suspend state of the resource.
+func (s *BoolSuspendStrategy) DescribeState(resource ResourceItem) string {
+	return fmt.Sprintf("suspend=%t", ptr.Deref(s.getSuspend(resource), false))
+}
+
 // ApplyScaling applies scaling logic for boolean suspend.
 func (s *BoolSuspendStrategy) ApplyScaling(
 	_ context.Context,
@@ -290,6 +321,14 @@ func (s *KedaPauseStrategy) GetKind() string {
 	return s.kind
 }
 
+// DescribeState returns the min/max replica counts and the KEDA pause state of the ScaledObject.
+func (s *KedaPauseStrategy) DescribeState(resource ResourceItem) string {
+	minReplicas, maxReplicas := s.getMinMaxReplicas(resource)
+	return fmt.Sprintf("minReplicas=%s, maxReplicas=%s, paused=%t",
+		formatReplicas(minReplicas), formatReplicas(maxReplicas),
+		resource.GetAnnotations()[KedaPausedAnnotation] == "true")
+}
+
 // ApplyScaling applies scaling logic for KEDA ScaledObjects.
 // On "down" with minReplicas == 0, it adds KEDA pause annotations.
 // On "up", it sets min/max replicas normally.
@@ -400,6 +439,11 @@ func (s *CNPGHibernateStrategy) GetKind() string {
 	return s.kind
 }
 
+// DescribeState returns the hibernation state of the cluster.
+func (s *CNPGHibernateStrategy) DescribeState(resource ResourceItem) string {
+	return fmt.Sprintf("hibernated=%t", isHibernating(resource.GetAnnotations()))
+}
+
 // ApplyScaling toggles CloudNativePG hibernation based on the period type.
 // On "down" it hibernates the cluster; on "up" it resumes it. Both record the
 // original hibernation state so that restoring (going out of period) can return
@@ -457,3 +501,268 @@ func (s *CNPGHibernateStrategy) restore(resource ResourceItem) (bool, error) {
 func isHibernating(annotations map[string]string) bool {
 	return annotations[CNPGHibernationAnnotation] == CNPGHibernationOn
 }
+
+// serviceSnapshot records the LoadBalancer settings of a Service that are
+// dropped when it is downgraded to ClusterIP. It is stored as JSON in the
+// original-service annotation and is all that is needed to recreate the same
+// load balancer, including a reserved static IP referenced by loadBalancerIP or
+// by a cloud-provider annotation.
+type serviceSnapshot struct {
+	Type                          coreV1.ServiceType                  `json:"type"`
+	Annotations                   map[string]string                   `json:"annotations,omitempty"`
+	LoadBalancerIP                string                              `json:"loadBalancerIP,omitempty"`
+	LoadBalancerClass             *string                             `json:"loadBalancerClass,omitempty"`
+	LoadBalancerSourceRanges      []string                            `json:"loadBalancerSourceRanges,omitempty"`
+	AllocateLoadBalancerNodePorts *bool                               `json:"allocateLoadBalancerNodePorts,omitempty"`
+	ExternalTrafficPolicy         coreV1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+	HealthCheckNodePort           int32                               `json:"healthCheckNodePort,omitempty"`
+	Ports                         []coreV1.ServicePort                `json:"ports,omitempty"`
+}
+
+// ServiceTypeStrategy handles scaling for Services.
+// Scaling is not replica-based: on "down" a LoadBalancer Service is switched to
+// ClusterIP so the cloud load balancer is released, and on "up" or restore the
+// original type, annotations, loadBalancerIP and ports are put back.
+// Services that are not of type LoadBalancer are left untouched.
+type ServiceTypeStrategy struct {
+	kind          string
+	getSpec       func(ResourceItem) *coreV1.ServiceSpec
+	logger        *zerolog.Logger
+	annotationMgr utils.AnnotationManager
+}
+
+// NewServiceTypeStrategy creates a new ServiceTypeStrategy.
+func NewServiceTypeStrategy(
+	kind string,
+	getSpec func(ResourceItem) *coreV1.ServiceSpec,
+	logger *zerolog.Logger,
+	annotationMgr utils.AnnotationManager,
+) *ServiceTypeStrategy {
+	return &ServiceTypeStrategy{
+		kind:          kind,
+		getSpec:       getSpec,
+		logger:        logger,
+		annotationMgr: annotationMgr,
+	}
+}
+
+// GetKind returns the resource kind.
+func (s *ServiceTypeStrategy) GetKind() string {
+	return s.kind
+}
+
+// DescribeState returns the type of the Service.
+func (s *ServiceTypeStrategy) DescribeState(resource ResourceItem) string {
+	spec := s.getSpec(resource)
+	if spec == nil {
+		return "type=unknown"
+	}
+	return "type=" + string(spec.Type)
+}
+
+// ApplyScaling downgrades or restores the Service type based on the period type.
+// Returns true when the Service needs no update: a non-LoadBalancer Service in a
+// down period, or a Service with nothing recorded on up/restore.
+func (s *ServiceTypeStrategy) ApplyScaling(
+	_ context.Context,
+	resource ResourceItem,
+	periodType string,
+	period *periodPkg.Period,
+) (bool, error) {
+	spec := s.getSpec(resource)
+	if spec == nil {
+		return false, NewTypeAssertionError("*coreV1.ServiceSpec", resource)
+	}
+
+	if periodType == periodTypeDown {
+		return s.downgrade(resource, spec, period)
+	}
+
+	return s.restore(resource, spec)
+}
+
+// downgrade records the LoadBalancer settings on first use and switches the
+// Service to ClusterIP. The snapshot is never overwritten, so repeated
+// reconciliations during the same down period keep the original settings.
+func (s *ServiceTypeStrategy) downgrade(resource ResourceItem, spec *coreV1.ServiceSpec, period *periodPkg.Period) (bool, error) {
+	key := utils.AnnotationsPrefix + "/" + utils.AnnotationsOrigService
+
+	_, isDowngraded := resource.GetAnnotations()[key]
+	if !isDowngraded && spec.Type != coreV1.ServiceTypeLoadBalancer {
+		return true, nil
+	}
+
+	var snapshot []byte
+	if !isDowngraded {
+		var err error
+		snapshot, err = json.Marshal(newServiceSnapshot(resource.GetAnnotations(), spec))
+		if err != nil {
+			return false, fmt.Errorf("error encoding original service: %w", err)
+		}
+	}
+
+	annotations := s.annotationMgr.AddAnnotations(resource.GetAnnotations(), period)
+	if !isDowngraded {
+		annotations[key] = string(snapshot)
+	}
+	resource.SetAnnotations(annotations)
+
+	spec.Type = coreV1.ServiceTypeClusterIP
+	spec.LoadBalancerIP = ""
+	spec.LoadBalancerClass = nil
+	spec.LoadBalancerSourceRanges = nil
+	spec.AllocateLoadBalancerNodePorts = nil
+	spec.ExternalTrafficPolicy = ""
+	spec.HealthCheckNodePort = 0
+	for i := range spec.Ports {
+		spec.Ports[i].NodePort = 0
+	}
+
+	return false, nil
+}
+
+// restore puts back the recorded LoadBalancer settings and clears the scaler
+// bookkeeping annotations.
+func (s *ServiceTypeStrategy) restore(resource ResourceItem, spec *coreV1.ServiceSpec) (bool, error) {
+	raw, isExists := resource.GetAnnotations()[utils.AnnotationsPrefix+"/"+utils.AnnotationsOrigService]
+	if !isExists {
+		return true, nil
+	}
+
+	snapshot := serviceSnapshot{}
+	if err := json.Unmarshal([]byte(raw), &snapshot); err != nil {
+		return false, fmt.Errorf("error parsing original service: %w", err)
+	}
+
+	spec.Type = snapshot.Type
+	spec.LoadBalancerIP = snapshot.LoadBalancerIP
+	spec.LoadBalancerClass = snapshot.LoadBalancerClass
+	spec.LoadBalancerSourceRanges = snapshot.LoadBalancerSourceRanges
+	spec.AllocateLoadBalancerNodePorts = snapshot.AllocateLoadBalancerNodePorts
+	spec.ExternalTrafficPolicy = snapshot.ExternalTrafficPolicy
+	spec.HealthCheckNodePort = snapshot.HealthCheckNodePort
+	if snapshot.Ports != nil {
+		spec.Ports = snapshot.Ports
+	}
+
+	annotations := s.annotationMgr.RemoveAnnotations(resource.GetAnnotations())
+	if annotations == nil && len(snapshot.Annotations) > 0 {
+		annotations = make(map[string]string, len(snapshot.Annotations))
+	}
+	maps.Copy(annotations, snapshot.Annotations)
+	resource.SetAnnotations(annotations)
+
+	return false, nil
+}
+
+// newServiceSnapshot captures the LoadBalancer settings of a Service. Scaler
+// bookkeeping annotations and kubectl's last-applied-configuration are left out
+// of the recorded annotations.
+func newServiceSnapshot(annotations map[string]string, spec *coreV1.ServiceSpec) serviceSnapshot {
+	snapshot := serviceSnapshot{
+		Type:                          spec.Type,
+		LoadBalancerIP:                spec.LoadBalancerIP,
+		LoadBalancerClass:             spec.LoadBalancerClass,
+		LoadBalancerSourceRanges:      spec.LoadBalancerSourceRanges,
+		AllocateLoadBalancerNodePorts: spec.AllocateLoadBalancerNodePorts,
+		ExternalTrafficPolicy:         spec.ExternalTrafficPolicy,
+		HealthCheckNodePort:           spec.HealthCheckNodePort,
+		Ports:                         slices.Clone(spec.Ports),
+	}
+
+	for k, v := range annotations {
+		if strings.HasPrefix(k, utils.AnnotationsPrefix) || k == coreV1.LastAppliedConfigAnnotation {
+			continue
+		}
+		if snapshot.Annotations == nil {
+			snapshot.Annotations = make(map[string]string)
+		}
+		snapshot.Annotations[k] = v
+	}
+
+	return snapshot
+}
+
+// BackendSwitchStrategy handles scaling for routing resources (Ingresses, HTTPRoutes).
+// On "down" every backend is pointed at a sleeping Service so that users get a
+// maintenance page instead of a 503; the original backends are serialized by
+// getBackends and recorded in the original-value annotation. On "up" or restore
+// they are handed back to setBackends.
+type BackendSwitchStrategy struct {
+	kind          string
+	getBackends   func(ResourceItem) (string, error)
+	setBackends   func(ResourceItem, string) error
+	sleepBackends func(ResourceItem) error
+	logger        *zerolog.Logger
+	annotationMgr utils.AnnotationManager
+}
+
+// NewBackendSwitchStrategy creates a new BackendSwitchStrategy.
+func NewBackendSwitchStrategy(
+	kind string,
+	getBackends func(ResourceItem) (string, error),
+	setBackends func(ResourceItem, string) error,
+	sleepBackends func(ResourceItem) error,
+	logger *zerolog.Logger,
+	annotationMgr utils.AnnotationManager,
+) *BackendSwitchStrategy {
+	return &BackendSwitchStrategy{
+		kind:          kind,
+		getBackends:   getBackends,
+		setBackends:   setBackends,
+		sleepBackends: sleepBackends,
+		logger:        logger,
+		annotationMgr: annotationMgr,
+	}
+}
+
+// GetKind returns the resource kind.
+func (s *BackendSwitchStrategy) GetKind() string {
+	return s.kind
+}
+
+// DescribeState returns the serialized backends of the resource.
+func (s *BackendSwitchStrategy) DescribeState(resource ResourceItem) string {
+	backends, err := s.getBackends(resource)
+	if err != nil {
+		return "backends=unknown"
+	}
+	return "backends=" + backends
+}
+
+// ApplyScaling switches backends to the sleeping Service on "down" and back to
+// the recorded backends otherwise.
+func (s *BackendSwitchStrategy) ApplyScaling(
+	_ context.Context,
+	resource ResourceItem,
+	periodType string,
+	period *periodPkg.Period,
+) (bool, error) {
+	if periodType == periodTypeDown {
+		backends, err := s.getBackends(resource)
+		if err != nil {
+			return false, err
+		}
+		// The original value is only recorded once, so re-applying a down period to
+		// an already switched resource keeps the real backends.
+		annotations := s.annotationMgr.AddStringAnnotations(resource.GetAnnotations(), period, backends)
+		if err := s.sleepBackends(resource); err != nil {
+			return false, err
+		}
+		resource.SetAnnotations(annotations)
+
+		return false, nil
+	}
+
+	isAlreadyRestored, backends, annotations := s.annotationMgr.RestoreStringAnnotations(resource.GetAnnotations())
+	if isAlreadyRestored {
+		return true, nil
+	}
+
+	if err := s.setBackends(resource, backends); err != nil {
+		return false, err
+	}
+	resource.SetAnnotations(annotations)
+
+	return false, nil
+}
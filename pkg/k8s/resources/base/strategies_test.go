@@ -25,7 +25,10 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
@@ -38,12 +41,24 @@ type mockResourceItem struct {
 	name        string
 	namespace   string
 	annotations map[string]string
+	labels      map[string]string
+	created     time.Time
 }
 
 func (m *mockResourceItem) GetName() string                    { return m.name }
 func (m *mockResourceItem) GetNamespace() string               { return m.namespace }
 func (m *mockResourceItem) GetAnnotations() map[string]string  { return m.annotations }
 func (m *mockResourceItem) SetAnnotations(a map[string]string) { m.annotations = a }
+func (m *mockResourceItem) GetObject() client.Object {
+	return &metaV1.PartialObjectMetadata{ObjectMeta: metaV1.ObjectMeta{
+		Name:              m.name,
+		Namespace:         m.namespace,
+		Annotations:       m.annotations,
+		Labels:            m.labels,
+		ResourceVersion:   "1",
+		CreationTimestamp: metaV1.NewTime(m.created),
+	}}
+}
 
 func testLogger() *zerolog.Logger {
 	l := zerolog.Nop()
@@ -528,3 +543,302 @@ func TestCNPGHibernateStrategy_Lifecycle(t *testing.T) {
 		assert.NotContains(t, resource.GetAnnotations(), "kubecloudscaler.cloud/original-value")
 	})
 }
+
+// ---------------------------------------------------------------------------
+// ServiceTypeStrategy
+// ---------------------------------------------------------------------------
+
+// mockServiceItem adds a Service spec to mockResourceItem.
+type mockServiceItem struct {
+	mockResourceItem
+	spec coreV1.ServiceSpec
+}
+
+func getMockServiceSpec(item ResourceItem) *coreV1.ServiceSpec {
+	s, ok := item.(*mockServiceItem)
+	if !ok {
+		return nil
+	}
+	return &s.spec
+}
+
+func newLoadBalancerService() *mockServiceItem {
+	return &mockServiceItem{
+		mockResourceItem: mockResourceItem{
+			name:      "web",
+			namespace: "default",
+			annotations: map[string]string{
+				"networking.gke.io/load-balancer-ip-addresses": "web-static-ip",
+			},
+		},
+		spec: coreV1.ServiceSpec{
+			Type:                     coreV1.ServiceTypeLoadBalancer,
+			LoadBalancerIP:           "203.0.113.10",
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			ExternalTrafficPolicy:    coreV1.ServiceExternalTrafficPolicyLocal,
+			HealthCheckNodePort:      30500,
+			Ports: []coreV1.ServicePort{
+				{Name: "http", Port: 80, NodePort: 30080},
+			},
+		},
+	}
+}
+
+func TestServiceTypeStrategy_ApplyScaling(t *testing.T) {
+	annotationMgr := utils.NewAnnotationManager()
+	strategy := NewServiceTypeStrategy("service", getMockServiceSpec, testLogger(), annotationMgr)
+	ctx := context.Background()
+	origKey := utils.AnnotationsPrefix + "/" + utils.AnnotationsOrigService
+
+	assert.Equal(t, "service", strategy.GetKind())
+
+	t.Run("down: downgrades LoadBalancer to ClusterIP", func(t *testing.T) {
+		svc := newLoadBalancerService()
+
+		restored, err := strategy.ApplyScaling(ctx, svc, "down", newTestPeriod())
+		require.NoError(t, err)
+		assert.False(t, restored)
+
+		assert.Equal(t, coreV1.ServiceTypeClusterIP, svc.spec.Type)
+		assert.Empty(t, svc.spec.LoadBalancerIP)
+		assert.Empty(t, svc.spec.LoadBalancerSourceRanges)
+		assert.Empty(t, svc.spec.ExternalTrafficPolicy)
+		assert.Zero(t, svc.spec.HealthCheckNodePort)
+		assert.Zero(t, svc.spec.Ports[0].NodePort)
+		assert.Contains(t, svc.GetAnnotations(), origKey)
+		assert.Equal(t, "down", svc.GetAnnotations()[utils.AnnotationsPrefix+"/"+utils.PeriodType])
+	})
+
+	t.Run("down: leaves non-LoadBalancer services untouched", func(t *testing.T) {
+		svc := &mockServiceItem{
+			mockResourceItem: mockResourceItem{name: "internal", namespace: "default"},
+			spec:             coreV1.ServiceSpec{Type: coreV1.ServiceTypeClusterIP},
+		}
+
+		restored, err := strategy.ApplyScaling(ctx, svc, "down", newTestPeriod())
+		require.NoError(t, err)
+		assert.True(t, restored)
+		assert.Nil(t, svc.GetAnnotations())
+	})
+
+	t.Run("down: keeps the first snapshot on repeated reconciliations", func(t *testing.T) {
+		svc := newLoadBalancerService()
+
+		_, err := strategy.ApplyScaling(ctx, svc, "down", newTestPeriod())
+		require.NoError(t, err)
+		first := svc.GetAnnotations()[origKey]
+
+		restored, err := strategy.ApplyScaling(ctx, svc, "down", newTestPeriod())
+		require.NoError(t, err)
+		assert.False(t, restored)
+		assert.Equal(t, first, svc.GetAnnotations()[origKey])
+	})
+
+	t.Run("restore: nothing recorded is a no-op", func(t *testing.T) {
+		svc := newLoadBalancerService()
+
+		restored, err := strategy.ApplyScaling(ctx, svc, "restore", nil)
+		require.NoError(t, err)
+		assert.True(t, restored)
+		assert.Equal(t, coreV1.ServiceTypeLoadBalancer, svc.spec.Type)
+	})
+
+	t.Run("restore: corrupted snapshot returns an error", func(t *testing.T) {
+		svc := &mockServiceItem{
+			mockResourceItem: mockResourceItem{
+				name:        "web",
+				namespace:   "default",
+				annotations: map[string]string{origKey: "not-json"},
+			},
+			spec: coreV1.ServiceSpec{Type: coreV1.ServiceTypeClusterIP},
+		}
+
+		restored, err := strategy.ApplyScaling(ctx, svc, "restore", nil)
+		require.Error(t, err)
+		assert.False(t, restored)
+		assert.Contains(t, err.Error(), "error parsing original service")
+	})
+
+	t.Run("type assertion failure", func(t *testing.T) {
+		resource := &mockResourceItem{name: "web", namespace: "default"}
+
+		_, err := strategy.ApplyScaling(ctx, resource, "down", newTestPeriod())
+		require.Error(t, err)
+		var typeErr *TypeAssertionError
+		assert.ErrorAs(t, err, &typeErr)
+	})
+}
+
+// TestServiceTypeStrategy_Lifecycle drives a LoadBalancer Service through
+// down -> restore and down -> up to verify every recorded setting, including
+// the static IP annotation, is put back.
+func TestServiceTypeStrategy_Lifecycle(t *testing.T) {
+	annotationMgr := utils.NewAnnotationManager()
+	strategy := NewServiceTypeStrategy("service", getMockServiceSpec, testLogger(), annotationMgr)
+	ctx := context.Background()
+
+	for _, leaveType := range []string{"restore", "up"} {
+		t.Run("down then "+leaveType, func(t *testing.T) {
+			svc := newLoadBalancerService()
+			want := newLoadBalancerService()
+
+			_, err := strategy.ApplyScaling(ctx, svc, "down", newTestPeriod())
+			require.NoError(t, err)
+
+			// A cloud controller may drop its own annotations once the LB is gone.
+			delete(svc.annotations, "networking.gke.io/load-balancer-ip-addresses")
+
+			restored, err := strategy.ApplyScaling(ctx, svc, leaveType, newTestPeriod())
+			require.NoError(t, err)
+			assert.False(t, restored)
+
+			assert.Equal(t, want.spec, svc.spec)
+			assert.Equal(t, want.annotations, svc.GetAnnotations())
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// BackendSwitchStrategy
+// ---------------------------------------------------------------------------
+
+// mockRouteItem adds a single backend to mockResourceItem.
+type mockRouteItem struct {
+	mockResourceItem
+	backend string
+}
+
+func newBackendSwitchStrategy() *BackendSwitchStrategy {
+	return NewBackendSwitchStrategy(
+		"route",
+		func(item ResourceItem) (string, error) { return item.(*mockRouteItem).backend, nil },
+		func(item ResourceItem, value string) error {
+			item.(*mockRouteItem).backend = value
+			return nil
+		},
+		func(item ResourceItem) error {
+			item.(*mockRouteItem).backend = "sleeping"
+			return nil
+		},
+		testLogger(),
+		utils.NewAnnotationManager(),
+	)
+}
+
+func TestBackendSwitchStrategy_ApplyScaling(t *testing.T) {
+	ctx := context.Background()
+	origKey := utils.AnnotationsPrefix + "/" + utils.AnnotationsOrigValue
+
+	t.Run("down: switches to the sleeping backend and records the original", func(t *testing.T) {
+		strategy := newBackendSwitchStrategy()
+		route := &mockRouteItem{mockResourceItem: mockResourceItem{name: "web", namespace: "default"}, backend: "web"}
+
+		assert.Equal(t, "route", strategy.GetKind())
+
+		restored, err := strategy.ApplyScaling(ctx, route, "down", newTestPeriod())
+		require.NoError(t, err)
+		assert.False(t, restored)
+		assert.Equal(t, "sleeping", route.backend)
+		assert.Equal(t, "web", route.GetAnnotations()[origKey])
+
+		// A second down period must not record the sleeping backend as the original.
+		_, err = strategy.ApplyScaling(ctx, route, "down", newTestPeriod())
+		require.NoError(t, err)
+		assert.Equal(t, "web", route.GetAnnotations()[origKey])
+	})
+
+	for _, leaveType := range []string{"up", "restore"} {
+		t.Run(leaveType+": puts the original backend back", func(t *testing.T) {
+			strategy := newBackendSwitchStrategy()
+			route := &mockRouteItem{
+				mockResourceItem: mockResourceItem{
+					name:        "web",
+					namespace:   "default",
+					annotations: map[string]string{origKey: "web"},
+				},
+				backend: "sleeping",
+			}
+
+			restored, err := strategy.ApplyScaling(ctx, route, leaveType, newTestPeriod())
+			require.NoError(t, err)
+			assert.False(t, restored)
+			assert.Equal(t, "web", route.backend)
+			assert.NotContains(t, route.GetAnnotations(), origKey)
+		})
+	}
+
+	t.Run("restore: already restored is a no-op", func(t *testing.T) {
+		strategy := newBackendSwitchStrategy()
+		route := &mockRouteItem{mockResourceItem: mockResourceItem{name: "web", namespace: "default"}, backend: "web"}
+
+		restored, err := strategy.ApplyScaling(ctx, route, "restore", nil)
+		require.NoError(t, err)
+		assert.True(t, restored)
+		assert.Equal(t, "web", route.backend)
+	})
+
+	t.Run("down: propagates sleep errors", func(t *testing.T) {
+		strategy := NewBackendSwitchStrategy(
+			"route",
+			func(ResourceItem) (string, error) { return "web", nil },
+			func(ResourceItem, string) error { return nil },
+			func(ResourceItem) error { return fmt.Errorf("no sleeping service") },
+			testLogger(),
+			utils.NewAnnotationManager(),
+		)
+		route := &mockRouteItem{mockResourceItem: mockResourceItem{name: "web", namespace: "default"}, backend: "web"}
+
+		_, err := strategy.ApplyScaling(ctx, route, "down", newTestPeriod())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no sleeping service")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// DescribeState
+// ---------------------------------------------------------------------------
+
+func TestStrategies_DescribeState(t *testing.T) {
+	annotationMgr := utils.NewAnnotationManager()
+	item := &mockResourceItem{
+		name:        "app",
+		namespace:   "default",
+		annotations: map[string]string{CNPGHibernationAnnotation: CNPGHibernationOn},
+	}
+
+	tests := []struct {
+		name     string
+		strategy StateDescriber
+		expected string
+	}{
+		{
+			name: "int replicas",
+			strategy: NewIntReplicasStrategy("deployment",
+				func(ResourceItem) *int32 { return ptr.To(int32(2)) }, nil, testLogger(), annotationMgr),
+			expected: "replicas=2",
+		},
+		{
+			name: "min/max replicas with unset min",
+			strategy: NewMinMaxReplicasStrategy("hpa",
+				func(ResourceItem) (*int32, *int32) { return nil, ptr.To(int32(4)) }, nil, testLogger(), annotationMgr),
+			expected: "minReplicas=unset, maxReplicas=4",
+		},
+		{
+			name: "suspend",
+			strategy: NewBoolSuspendStrategy("cronjob",
+				func(ResourceItem) *bool { return ptr.To(true) }, nil, true, testLogger(), nil, annotationMgr),
+			expected: "suspend=true",
+		},
+		{
+			name:     "hibernation",
+			strategy: NewCNPGHibernateStrategy("cluster", testLogger(), annotationMgr),
+			expected: "hibernated=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.strategy.DescribeState(item))
+		})
+	}
+}
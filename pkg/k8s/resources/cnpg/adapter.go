@@ -24,9 +24,12 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
 // clusterItem wraps a Cluster to implement the ResourceItem interface.
@@ -51,21 +54,40 @@ func (c *clusterItem) SetAnnotations(annotations map[string]string) {
 	c.Annotations = annotations
 }
 
+func (c *clusterItem) GetObject() client.Object {
+	// Start from a deep copy of the full live object so all Cluster fields not
+	// mirrored in the local type (spec.instances, storage, etc.) are preserved.
+	// Only the annotations we manage are taken from the local type.
+	obj := c.unstructured.DeepCopy()
+	obj.SetAnnotations(c.Cluster.GetAnnotations())
+	return obj
+}
+
+// Ready reports whether the cluster reached its healthy phase.
+func (c *clusterItem) Ready() (bool, string) {
+	phase, _, _ := unstructured.NestedString(c.unstructured.Object, "status", "phase")
+	if phase != clusterHealthyPhase {
+		return false, fmt.Sprintf("cluster phase %q", phase)
+	}
+	return true, ""
+}
+
 // clusterLister implements ResourceLister for CloudNativePG Clusters.
 type clusterLister struct {
 	client dynamic.NamespaceableResourceInterface
+	// cache serves the list when set, the API server otherwise.
+	cache  *utils.InformerCache
 	logger *zerolog.Logger
 }
 
 func (l *clusterLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
-	list, err := l.client.Namespace(namespace).List(ctx, opts)
+	list, err := l.list(ctx, namespace, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	items := make([]base.ResourceItem, 0, len(list.Items))
-	for i := range list.Items {
-		item := &list.Items[i]
+	items := make([]base.ResourceItem, 0, len(list))
+	for _, item := range list {
 		cluster := &Cluster{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, cluster); err != nil {
 			if l.logger != nil {
@@ -85,6 +107,25 @@ func (l *clusterLister) List(ctx context.Context, namespace string, opts metaV1.
 	return items, nil
 }
 
+// list returns the Cluster objects from the informer cache when there is one, from the API server otherwise.
+func (l *clusterLister) list(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]*unstructured.Unstructured, error) {
+	if l.cache != nil {
+		return utils.ListCached[*unstructured.Unstructured](ctx, l.cache, clusterGVR, namespace, opts)
+	}
+
+	list, err := l.client.Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		items[i] = &list.Items[i]
+	}
+
+	return items, nil
+}
+
 // clusterGetter implements ResourceGetter for CloudNativePG Clusters.
 type clusterGetter struct {
 	client dynamic.NamespaceableResourceInterface
@@ -107,40 +148,29 @@ func (g *clusterGetter) Get(ctx context.Context, namespace, name string, opts me
 	}, nil
 }
 
-// clusterUpdater implements ResourceUpdater for CloudNativePG Clusters.
-type clusterUpdater struct {
+// clusterPatcher implements ResourcePatcher for CloudNativePG Clusters.
+type clusterPatcher struct {
 	client dynamic.NamespaceableResourceInterface
 }
 
-func (u *clusterUpdater) Update(
+func (u *clusterPatcher) Patch(
 	ctx context.Context,
-	namespace string,
-	resource base.ResourceItem,
-	opts metaV1.UpdateOptions,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
 ) (base.ResourceItem, error) {
-	item, ok := resource.(*clusterItem)
-	if !ok {
-		return nil, base.NewTypeAssertionError("*clusterItem", resource)
-	}
-
-	// Start from a deep copy of the full live object so all Cluster fields not
-	// mirrored in the local type (spec.instances, storage, etc.) are preserved.
-	// Only the annotations we manage are patched.
-	obj := item.unstructured.DeepCopy()
-	obj.SetAnnotations(item.Cluster.GetAnnotations())
-
-	updated, err := u.client.Namespace(namespace).Update(ctx, obj, opts)
+	patched, err := u.client.Namespace(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	cluster := &Cluster{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(updated.Object, cluster); err != nil {
-		return nil, fmt.Errorf("error converting updated unstructured to Cluster: %w", err)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(patched.Object, cluster); err != nil {
+		return nil, fmt.Errorf("error converting patched unstructured to Cluster: %w", err)
 	}
 
 	return &clusterItem{
 		Cluster:      cluster,
-		unstructured: updated,
+		unstructured: patched,
 	}, nil
 }
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
 )
@@ -43,7 +44,7 @@ func liveCluster(name, namespace string, annotations map[string]interface{}) *un
 	}
 }
 
-func TestClusterUpdater_PreservesUnmanagedSpecFields(t *testing.T) {
+func TestClusterPatcher_PreservesUnmanagedSpecFields(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, AddToScheme(scheme))
 
@@ -52,11 +53,13 @@ func TestClusterUpdater_PreservesUnmanagedSpecFields(t *testing.T) {
 		map[schema.GroupVersionResource]string{testGVR: "ClusterList"},
 		liveCluster("cluster-a", "test-ns", map[string]interface{}{"team": "platform"}),
 	)
-	client := dynClient.Resource(testGVR)
+	clusters := dynClient.Resource(testGVR)
 
-	getter := &clusterGetter{client: client}
+	getter := &clusterGetter{client: clusters}
 	item, err := getter.Get(context.Background(), "test-ns", "cluster-a", metaV1.GetOptions{})
 	require.NoError(t, err)
+	original, ok := item.GetObject().DeepCopyObject().(client.Object)
+	require.True(t, ok)
 
 	// Simulate the strategy hibernating the cluster via annotations only.
 	item.SetAnnotations(map[string]string{
@@ -64,11 +67,17 @@ func TestClusterUpdater_PreservesUnmanagedSpecFields(t *testing.T) {
 		base.CNPGHibernationAnnotation: base.CNPGHibernationOn,
 	})
 
-	updater := &clusterUpdater{client: client}
-	_, err = updater.Update(context.Background(), "test-ns", item, metaV1.UpdateOptions{})
+	data, err := client.MergeFrom(original).Data(item.GetObject())
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"metadata":{"annotations":{"`+base.CNPGHibernationAnnotation+`":"`+base.CNPGHibernationOn+`"}}}`,
+		string(data))
+
+	patcher := &clusterPatcher{client: clusters}
+	_, err = patcher.Patch(context.Background(), "test-ns", "cluster-a", data, metaV1.PatchOptions{})
 	require.NoError(t, err)
 
-	live, err := client.Namespace("test-ns").Get(context.Background(), "cluster-a", metaV1.GetOptions{})
+	live, err := clusters.Namespace("test-ns").Get(context.Background(), "cluster-a", metaV1.GetOptions{})
 	require.NoError(t, err)
 
 	annotations := live.GetAnnotations()
@@ -87,24 +96,6 @@ func TestClusterUpdater_PreservesUnmanagedSpecFields(t *testing.T) {
 	assert.Equal(t, "10Gi", size)
 }
 
-func TestClusterUpdater_RejectsForeignResourceItem(t *testing.T) {
-	updater := &clusterUpdater{}
-	_, err := updater.Update(context.Background(), "test-ns", foreignItem{}, metaV1.UpdateOptions{})
-
-	require.Error(t, err)
-	var typeErr *base.TypeAssertionError
-	assert.ErrorAs(t, err, &typeErr)
-}
-
-// foreignItem is a ResourceItem that is not a *clusterItem, used to exercise the
-// updater's type-assertion guard.
-type foreignItem struct{}
-
-func (foreignItem) GetName() string                   { return "foreign" }
-func (foreignItem) GetNamespace() string              { return "test-ns" }
-func (foreignItem) GetAnnotations() map[string]string { return nil }
-func (foreignItem) SetAnnotations(map[string]string)  {}
-
 func TestCnpg_Init_WiresClientAndAnnotationManager(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, AddToScheme(scheme))
@@ -1,6 +1,6 @@
 package cnpg
 
-// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;update;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch;update;patch
 
 import (
 	"context"
@@ -17,23 +17,29 @@ const (
 	clusterKind    = "cnpgcluster"
 	clusterGroup   = "postgresql.cnpg.io"
 	clusterVersion = "v1"
+
+	// clusterHealthyPhase is the phase of a Cluster whose instances are all ready.
+	clusterHealthyPhase = "Cluster in healthy state"
 )
 
+// clusterGVR identifies the Cluster resource served by the dynamic client.
+var clusterGVR = schema.GroupVersionResource{
+	Group:    clusterGroup,
+	Version:  clusterVersion,
+	Resource: "clusters",
+}
+
 func (c *Cnpg) init(client dynamic.Interface) {
-	c.Client = client.Resource(schema.GroupVersionResource{
-		Group:    clusterGroup,
-		Version:  clusterVersion,
-		Resource: "clusters",
-	})
+	c.Client = client.Resource(clusterGVR)
 	c.AnnotationManager = utils.NewAnnotationManager()
 }
 
 // SetState sets the state of CloudNativePG Cluster resources based on the current period.
 func (c *Cnpg) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
 	// Create adapters
-	lister := &clusterLister{client: c.Client, logger: c.Logger}
+	lister := &clusterLister{client: c.Client, cache: c.Resource.Cache, logger: c.Logger}
 	getter := &clusterGetter{client: c.Client}
-	updater := &clusterUpdater{client: c.Client}
+	patcher := &clusterPatcher{client: c.Client}
 
 	// Create CNPG hibernation strategy
 	strategy := base.NewCNPGHibernateStrategy(
@@ -46,7 +52,7 @@ func (c *Cnpg) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []co
 	processor := base.NewProcessor(
 		lister,
 		getter,
-		updater,
+		patcher,
 		strategy,
 		c.Resource,
 		c.Logger,
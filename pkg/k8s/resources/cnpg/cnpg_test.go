@@ -254,11 +254,11 @@ var _ = Describe("Cnpg", func() {
 			Expect(failed[0].Reason).To(ContainSubstring("error parsing bool value"))
 		})
 
-		It("records update failures", func() {
-			setupManager(newCluster("cluster-update", "test-ns", nil))
+		It("records patch failures", func() {
+			setupManager(newCluster("cluster-patch", "test-ns", nil))
 
-			dynClient.PrependReactor("update", "clusters", func(_ testing.Action) (bool, runtime.Object, error) {
-				return true, nil, errors.New("update failure")
+			dynClient.PrependReactor("patch", "clusters", func(_ testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("patch failure")
 			})
 
 			success, failed, err := manager.SetState(ctx)
@@ -266,8 +266,8 @@ var _ = Describe("Cnpg", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(success).To(BeEmpty())
 			Expect(failed).To(HaveLen(1))
-			Expect(failed[0].Name).To(Equal("cluster-update"))
-			Expect(failed[0].Reason).To(ContainSubstring("update failure"))
+			Expect(failed[0].Name).To(Equal("cluster-patch"))
+			Expect(failed[0].Reason).To(ContainSubstring("patch failure"))
 		})
 	})
 
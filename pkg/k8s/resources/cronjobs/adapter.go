@@ -22,9 +22,12 @@ import (
 
 	batchV1 "k8s.io/api/batch/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/kubernetes/typed/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
 // cronJobItem wraps batchV1.CronJob to implement ResourceItem interface.
@@ -48,12 +51,35 @@ func (c *cronJobItem) SetAnnotations(annotations map[string]string) {
 	c.Annotations = annotations
 }
 
+func (c *cronJobItem) GetObject() client.Object {
+	return c.CronJob
+}
+
+// cronJobsGVR identifies the CronJob resource in the informer cache.
+var cronJobsGVR = batchV1.SchemeGroupVersion.WithResource("cronjobs")
+
 // cronJobLister implements ResourceLister for cronjobs.
 type cronJobLister struct {
 	client v1.BatchV1Interface
+	// cache serves the list when set, the API server otherwise.
+	cache *utils.InformerCache
 }
 
 func (l *cronJobLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
+	if l.cache != nil {
+		cached, err := utils.ListCached[*batchV1.CronJob](ctx, l.cache, cronJobsGVR, namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]base.ResourceItem, len(cached))
+		for i := range cached {
+			items[i] = &cronJobItem{CronJob: cached[i]}
+		}
+
+		return items, nil
+	}
+
 	list, err := l.client.CronJobs(namespace).List(ctx, opts)
 	if err != nil {
 		return nil, err
@@ -81,28 +107,23 @@ func (g *cronJobGetter) Get(ctx context.Context, namespace, name string, opts me
 	return &cronJobItem{CronJob: cronjob}, nil
 }
 
-// cronJobUpdater implements ResourceUpdater for cronjobs.
-type cronJobUpdater struct {
+// cronJobPatcher implements ResourcePatcher for cronjobs.
+type cronJobPatcher struct {
 	client v1.BatchV1Interface
 }
 
-func (u *cronJobUpdater) Update(
+func (u *cronJobPatcher) Patch(
 	ctx context.Context,
-	namespace string,
-	resource base.ResourceItem,
-	opts metaV1.UpdateOptions,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
 ) (base.ResourceItem, error) {
-	item, ok := resource.(*cronJobItem)
-	if !ok {
-		return nil, base.NewTypeAssertionError("*cronJobItem", resource)
-	}
-
-	updated, err := u.client.CronJobs(namespace).Update(ctx, item.CronJob, opts)
+	patched, err := u.client.CronJobs(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &cronJobItem{CronJob: updated}, nil
+	return &cronJobItem{CronJob: patched}, nil
 }
 
 // getSuspend returns the suspend value from a cronjob.
@@ -1,7 +1,7 @@
 // Package cronjobs provides CronJob scaling functionality for Kubernetes resources.
 package cronjobs
 
-// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;update;patch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;update;patch
 
 import (
 	"context"
@@ -20,9 +20,9 @@ func (c *Cronjobs) init(client kubernetes.Interface) {
 // SetState sets the state of CronJob resources based on the current period.
 func (c *Cronjobs) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
 	// Create adapters
-	lister := &cronJobLister{client: c.Client}
+	lister := &cronJobLister{client: c.Client, cache: c.Resource.Cache}
 	getter := &cronJobGetter{client: c.Client}
-	updater := &cronJobUpdater{client: c.Client}
+	patcher := &cronJobPatcher{client: c.Client}
 
 	// Create annotation manager
 	annotationMgr := utils.NewAnnotationManager()
@@ -42,7 +42,7 @@ func (c *Cronjobs) SetState(ctx context.Context) ([]common.ScalerStatusSuccess,
 	processor := base.NewProcessor(
 		lister,
 		getter,
-		updater,
+		patcher,
 		strategy,
 		c.Resource,
 		c.Logger,
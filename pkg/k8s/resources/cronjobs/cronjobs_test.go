@@ -10,8 +10,10 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog/log"
 	batchV1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
@@ -232,7 +234,11 @@ var _ = Describe("Cronjobs", func() {
 				_, err := fakeClient.BatchV1().CronJobs(testNamespace).Create(ctx, testCronJob, metaV1.CreateOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
-				// Mock the fake client to return an error when getting a specific cronjob
+				// Conflict on the patch so the cronjob is fetched again, then fail that get
+				fakeClient.PrependReactor("patch", "cronjobs", func(action testing.Action) (bool, runtime.Object, error) {
+					patchAction := action.(testing.PatchAction)
+					return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "cronjobs"}, patchAction.GetName(), errors.New("modified"))
+				})
 				fakeClient.PrependReactor("get", "cronjobs", func(action testing.Action) (bool, runtime.Object, error) {
 					return true, nil, errors.New("get error")
 				})
@@ -247,7 +253,7 @@ var _ = Describe("Cronjobs", func() {
 				Expect(failed[0].Reason).To(ContainSubstring("get error"))
 			})
 
-			It("should handle cronjob update error", func() {
+			It("should handle cronjob patch error", func() {
 				// Create a cronjob in the fake client
 				testCronJob := &batchV1.CronJob{
 					ObjectMeta: metaV1.ObjectMeta{
@@ -262,9 +268,9 @@ var _ = Describe("Cronjobs", func() {
 				_, err := fakeClient.BatchV1().CronJobs(testNamespace).Create(ctx, testCronJob, metaV1.CreateOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
-				// Mock the fake client to return an error when updating cronjobs
-				fakeClient.PrependReactor("update", "cronjobs", func(action testing.Action) (bool, runtime.Object, error) {
-					return true, nil, errors.New("update error")
+				// Mock the fake client to return an error when patching cronjobs
+				fakeClient.PrependReactor("patch", "cronjobs", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("patch error")
 				})
 
 				success, failed, err := cronjobs.SetState(ctx)
@@ -274,7 +280,7 @@ var _ = Describe("Cronjobs", func() {
 				Expect(failed).To(HaveLen(1))
 				Expect(failed[0].Kind).To(Equal("cronjob"))
 				Expect(failed[0].Name).To(Equal(testCronjob))
-				Expect(failed[0].Reason).To(ContainSubstring("update error"))
+				Expect(failed[0].Reason).To(ContainSubstring("patch error"))
 			})
 
 			It("should handle annotation restoration error", func() {
@@ -346,11 +352,11 @@ var _ = Describe("Cronjobs", func() {
 			})
 
 			It("should continue processing when one cronjob fails", func() {
-				// Mock one cronjob to fail on update
-				fakeClient.PrependReactor("update", "cronjobs", func(action testing.Action) (bool, runtime.Object, error) {
-					updateAction := action.(testing.UpdateAction)
-					if updateAction.GetObject().(*batchV1.CronJob).Name == "cronjob2" {
-						return true, nil, errors.New("update error for cronjob2")
+				// Mock one cronjob to fail on patch
+				fakeClient.PrependReactor("patch", "cronjobs", func(action testing.Action) (bool, runtime.Object, error) {
+					patchAction := action.(testing.PatchAction)
+					if patchAction.GetName() == "cronjob2" {
+						return true, nil, errors.New("patch error for cronjob2")
 					}
 					return false, nil, nil
 				})
@@ -20,10 +20,15 @@ import (
 	"context"
 
 	appsV1 "k8s.io/api/apps/v1"
+	apiCoreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	coreV1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
 // deploymentItem wraps appsV1.Deployment to implement ResourceItem interface.
@@ -47,12 +52,45 @@ func (d *deploymentItem) SetAnnotations(annotations map[string]string) {
 	d.Annotations = annotations
 }
 
+func (d *deploymentItem) GetObject() client.Object {
+	return d.Deployment
+}
+
+// Ready reports whether all desired replicas are ready.
+func (d *deploymentItem) Ready() (bool, string) {
+	return base.ReplicasReady(d.Generation, d.Status.ObservedGeneration, d.Spec.Replicas, d.Status.ReadyReplicas)
+}
+
+// Released returns the resources requested by the pods removed by the scale-down.
+func (d *deploymentItem) Released() apiCoreV1.ResourceList {
+	return base.ReleasedRequests(d.Annotations, d.Spec.Replicas, &d.Spec.Template.Spec)
+}
+
+// deploymentsGVR identifies the Deployment resource in the informer cache.
+var deploymentsGVR = appsV1.SchemeGroupVersion.WithResource("deployments")
+
 // deploymentLister implements ResourceLister for deployments.
 type deploymentLister struct {
 	client v1.AppsV1Interface
+	// cache serves the list when set, the API server otherwise.
+	cache *utils.InformerCache
 }
 
 func (l *deploymentLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
+	if l.cache != nil {
+		cached, err := utils.ListCached[*appsV1.Deployment](ctx, l.cache, deploymentsGVR, namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]base.ResourceItem, len(cached))
+		for i := range cached {
+			items[i] = &deploymentItem{Deployment: cached[i]}
+		}
+
+		return items, nil
+	}
+
 	list, err := l.client.Deployments(namespace).List(ctx, opts)
 	if err != nil {
 		return nil, err
@@ -69,6 +107,8 @@ func (l *deploymentLister) List(ctx context.Context, namespace string, opts meta
 // deploymentGetter implements ResourceGetter for deployments.
 type deploymentGetter struct {
 	client v1.AppsV1Interface
+	// pods serves the pods diagnosed when the resource is not ready; nil disables the diagnosis.
+	pods coreV1.PodsGetter
 }
 
 func (g *deploymentGetter) Get(ctx context.Context, namespace, name string, opts metaV1.GetOptions) (base.ResourceItem, error) {
@@ -80,28 +120,36 @@ func (g *deploymentGetter) Get(ctx context.Context, namespace, name string, opts
 	return &deploymentItem{Deployment: deploy}, nil
 }
 
-// deploymentUpdater implements ResourceUpdater for deployments.
-type deploymentUpdater struct {
-	client v1.AppsV1Interface
-}
+func (g *deploymentGetter) DiagnoseReadiness(ctx context.Context, resource base.ResourceItem) (string, error) {
+	if g.pods == nil {
+		return "", nil
+	}
 
-func (u *deploymentUpdater) Update(
-	ctx context.Context,
-	namespace string,
-	resource base.ResourceItem,
-	opts metaV1.UpdateOptions,
-) (base.ResourceItem, error) {
 	item, ok := resource.(*deploymentItem)
 	if !ok {
-		return nil, base.NewTypeAssertionError("*deploymentItem", resource)
+		return "", base.NewTypeAssertionError("*deploymentItem", resource)
 	}
 
-	updated, err := u.client.Deployments(namespace).Update(ctx, item.Deployment, opts)
+	return utils.DiagnosePods(ctx, g.pods.Pods(item.Namespace), item.Spec.Selector)
+}
+
+// deploymentPatcher implements ResourcePatcher for deployments.
+type deploymentPatcher struct {
+	client v1.AppsV1Interface
+}
+
+func (u *deploymentPatcher) Patch(
+	ctx context.Context,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
+) (base.ResourceItem, error) {
+	patched, err := u.client.Deployments(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &deploymentItem{Deployment: updated}, nil
+	return &deploymentItem{Deployment: patched}, nil
 }
 
 // getReplicas returns the replicas from a deployment.
@@ -1,6 +1,6 @@
 package deployments
 
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
 
 import (
 	"context"
@@ -14,14 +14,15 @@ import (
 
 func (d *Deployments) init(client kubernetes.Interface) {
 	d.Client = client.AppsV1()
+	d.Pods = client.CoreV1()
 }
 
 // SetState sets the state of Deployment resources based on the current period.
 func (d *Deployments) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
 	// Create adapters
-	lister := &deploymentLister{client: d.Client}
-	getter := &deploymentGetter{client: d.Client}
-	updater := &deploymentUpdater{client: d.Client}
+	lister := &deploymentLister{client: d.Client, cache: d.Resource.Cache}
+	getter := &deploymentGetter{client: d.Client, pods: d.Pods}
+	patcher := &deploymentPatcher{client: d.Client}
 
 	// Create annotation manager
 	annotationMgr := utils.NewAnnotationManager()
@@ -39,7 +40,7 @@ func (d *Deployments) SetState(ctx context.Context) ([]common.ScalerStatusSucces
 	processor := base.NewProcessor(
 		lister,
 		getter,
-		updater,
+		patcher,
 		strategy,
 		d.Resource,
 		d.Logger,
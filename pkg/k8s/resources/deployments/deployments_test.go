@@ -10,8 +10,10 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog/log"
 	appsV1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
@@ -249,7 +251,11 @@ var _ = Describe("Deployments", func() {
 				_, err := fakeClient.AppsV1().Deployments(testNamespace).Create(ctx, testDeployment, metaV1.CreateOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
-				// Mock the fake client to return an error when getting a specific deployment
+				// Conflict on the patch so the deployment is fetched again, then fail that get
+				fakeClient.PrependReactor("patch", "deployments", func(action testing.Action) (bool, runtime.Object, error) {
+					patchAction := action.(testing.PatchAction)
+					return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "deployments"}, patchAction.GetName(), errors.New("modified"))
+				})
 				fakeClient.PrependReactor("get", "deployments", func(action testing.Action) (bool, runtime.Object, error) {
 					return true, nil, errors.New("get error")
 				})
@@ -264,7 +270,7 @@ var _ = Describe("Deployments", func() {
 				Expect(failed[0].Reason).To(ContainSubstring("get error"))
 			})
 
-			It("should handle deployment update error", func() {
+			It("should handle deployment patch error", func() {
 				// Create a deployment in the fake client
 				testDeployment := &appsV1.Deployment{
 					ObjectMeta: metaV1.ObjectMeta{
@@ -279,9 +285,9 @@ var _ = Describe("Deployments", func() {
 				_, err := fakeClient.AppsV1().Deployments(testNamespace).Create(ctx, testDeployment, metaV1.CreateOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
-				// Mock the fake client to return an error when updating deployments
-				fakeClient.PrependReactor("update", "deployments", func(action testing.Action) (bool, runtime.Object, error) {
-					return true, nil, errors.New("update error")
+				// Mock the fake client to return an error when patching deployments
+				fakeClient.PrependReactor("patch", "deployments", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("patch error")
 				})
 
 				success, failed, err := deployments.SetState(ctx)
@@ -291,7 +297,7 @@ var _ = Describe("Deployments", func() {
 				Expect(failed).To(HaveLen(1))
 				Expect(failed[0].Kind).To(Equal("deployment"))
 				Expect(failed[0].Name).To(Equal(testDeploy))
-				Expect(failed[0].Reason).To(ContainSubstring("update error"))
+				Expect(failed[0].Reason).To(ContainSubstring("patch error"))
 			})
 
 			It("should handle annotation restoration error", func() {
@@ -364,11 +370,11 @@ var _ = Describe("Deployments", func() {
 			})
 
 			It("should continue processing when one deployment fails", func() {
-				// Mock one deployment to fail on update
-				fakeClient.PrependReactor("update", "deployments", func(action testing.Action) (bool, runtime.Object, error) {
-					updateAction := action.(testing.UpdateAction)
-					if updateAction.GetObject().(*appsV1.Deployment).Name == "deploy2" {
-						return true, nil, errors.New("update error for deploy2")
+				// Mock one deployment to fail on patch
+				fakeClient.PrependReactor("patch", "deployments", func(action testing.Action) (bool, runtime.Object, error) {
+					patchAction := action.(testing.PatchAction)
+					if patchAction.GetName() == "deploy2" {
+						return true, nil, errors.New("patch error for deploy2")
 					}
 					return false, nil, nil
 				})
@@ -425,6 +431,60 @@ var _ = Describe("Deployments", func() {
 			})
 		})
 
+		Context("with an informer cache", func() {
+			BeforeEach(func() {
+				mockPeriod.Type = common.PeriodTypeDown
+				mockPeriod.MinReplicas = 0
+
+				for _, ns := range []string{testNamespace, "other-namespace"} {
+					testDeployment := &appsV1.Deployment{
+						ObjectMeta: metaV1.ObjectMeta{
+							Name:      testDeploy,
+							Namespace: ns,
+						},
+						Spec: appsV1.DeploymentSpec{
+							Replicas: ptr.To(int32(3)),
+						},
+					}
+
+					_, err := fakeClient.AppsV1().Deployments(ns).Create(ctx, testDeployment, metaV1.CreateOptions{})
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				informerCache := utils.NewInformerCache(fakeClient, nil)
+				DeferCleanup(informerCache.Stop)
+				deployments.Resource.Cache = informerCache
+			})
+
+			It("should scale the cached deployments of the listed namespaces", func() {
+				success, failed, err := deployments.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+				Expect(failed).To(BeEmpty())
+				Expect(success[0].Name).To(Equal(testDeploy))
+
+				updatedDeployment, err := fakeClient.AppsV1().Deployments(testNamespace).Get(ctx, testDeploy, metaV1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(*updatedDeployment.Spec.Replicas).To(Equal(int32(0)))
+			})
+
+			It("should not list deployments from the API server on every reconciliation", func() {
+				for range 2 {
+					_, _, err := deployments.SetState(ctx)
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				lists := 0
+				for _, action := range fakeClient.Actions() {
+					if action.Matches("list", "deployments") {
+						lists++
+					}
+				}
+				Expect(lists).To(Equal(1))
+			})
+		})
+
 		Context("edge cases", func() {
 			It("should handle deployment with nil replicas", func() {
 				mockPeriod.Type = common.PeriodTypeDown
@@ -26,23 +26,18 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
-var runnerSetGVK = schema.GroupVersionKind{
-	Group:   "actions.github.com",
-	Version: "v1alpha1",
-	Kind:    "AutoscalingRunnerSet",
-}
-
 // runnerSetItem wraps actionsV1alpha1.AutoscalingRunnerSet to implement ResourceItem interface.
 type runnerSetItem struct {
 	*actionsV1alpha1.AutoscalingRunnerSet
-	unstructured *unstructured.Unstructured
 }
 
 func (r *runnerSetItem) GetName() string {
@@ -61,21 +56,26 @@ func (r *runnerSetItem) SetAnnotations(annotations map[string]string) {
 	r.Annotations = annotations
 }
 
+func (r *runnerSetItem) GetObject() client.Object {
+	return r.AutoscalingRunnerSet
+}
+
 // runnerSetLister implements ResourceLister for autoscaling runner sets.
 type runnerSetLister struct {
 	client dynamic.NamespaceableResourceInterface
+	// cache serves the list when set, the API server otherwise.
+	cache  *utils.InformerCache
 	logger *zerolog.Logger
 }
 
 func (l *runnerSetLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
-	list, err := l.client.Namespace(namespace).List(ctx, opts)
+	list, err := l.list(ctx, namespace, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	items := make([]base.ResourceItem, 0, len(list.Items))
-	for i := range list.Items {
-		item := &list.Items[i]
+	items := make([]base.ResourceItem, 0, len(list))
+	for _, item := range list {
 		runnerSet := &actionsV1alpha1.AutoscalingRunnerSet{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, runnerSet); err != nil {
 			if l.logger != nil {
@@ -86,10 +86,26 @@ func (l *runnerSetLister) List(ctx context.Context, namespace string, opts metaV
 			}
 			continue
 		}
-		items = append(items, &runnerSetItem{
-			AutoscalingRunnerSet: runnerSet,
-			unstructured:         item,
-		})
+		items = append(items, &runnerSetItem{AutoscalingRunnerSet: runnerSet})
+	}
+
+	return items, nil
+}
+
+// list returns the AutoscalingRunnerSet objects from the informer cache when there is one, from the API server otherwise.
+func (l *runnerSetLister) list(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]*unstructured.Unstructured, error) {
+	if l.cache != nil {
+		return utils.ListCached[*unstructured.Unstructured](ctx, l.cache, runnerSetGVR, namespace, opts)
+	}
+
+	list, err := l.client.Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		items[i] = &list.Items[i]
 	}
 
 	return items, nil
@@ -111,54 +127,31 @@ func (g *runnerSetGetter) Get(ctx context.Context, namespace, name string, opts
 		return nil, fmt.Errorf("error converting unstructured to AutoscalingRunnerSet: %w", err)
 	}
 
-	return &runnerSetItem{
-		AutoscalingRunnerSet: runnerSet,
-		unstructured:         item,
-	}, nil
+	return &runnerSetItem{AutoscalingRunnerSet: runnerSet}, nil
 }
 
-// runnerSetUpdater implements ResourceUpdater for autoscaling runner sets.
-type runnerSetUpdater struct {
+// runnerSetPatcher implements ResourcePatcher for autoscaling runner sets.
+type runnerSetPatcher struct {
 	client dynamic.NamespaceableResourceInterface
 }
 
-func (u *runnerSetUpdater) Update(
+func (u *runnerSetPatcher) Patch(
 	ctx context.Context,
-	namespace string,
-	resource base.ResourceItem,
-	opts metaV1.UpdateOptions,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
 ) (base.ResourceItem, error) {
-	item, ok := resource.(*runnerSetItem)
-	if !ok {
-		return nil, base.NewTypeAssertionError("*runnerSetItem", resource)
-	}
-
-	// Set GVK before conversion
-	item.SetGroupVersionKind(runnerSetGVK)
-
-	unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(item.AutoscalingRunnerSet)
-	if err != nil {
-		return nil, fmt.Errorf("error converting AutoscalingRunnerSet to unstructured: %w", err)
-	}
-
-	updated, err := u.client.Namespace(namespace).Update(
-		ctx,
-		&unstructured.Unstructured{Object: unstructuredObj},
-		opts,
-	)
+	patched, err := u.client.Namespace(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	runnerSet := &actionsV1alpha1.AutoscalingRunnerSet{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(updated.Object, runnerSet); err != nil {
-		return nil, fmt.Errorf("error converting updated unstructured to AutoscalingRunnerSet: %w", err)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(patched.Object, runnerSet); err != nil {
+		return nil, fmt.Errorf("error converting patched unstructured to AutoscalingRunnerSet: %w", err)
 	}
 
-	return &runnerSetItem{
-		AutoscalingRunnerSet: runnerSet,
-		unstructured:         updated,
-	}, nil
+	return &runnerSetItem{AutoscalingRunnerSet: runnerSet}, nil
 }
 
 // getMinMaxReplicas returns the min and max replicas from an autoscaling runner set.
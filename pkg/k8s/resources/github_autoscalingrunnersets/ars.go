@@ -1,6 +1,6 @@
 package ars
 
-// +kubebuilder:rbac:groups=actions.github.com,resources=autoscalingrunnersets,verbs=get;list;update;patch
+// +kubebuilder:rbac:groups=actions.github.com,resources=autoscalingrunnersets,verbs=get;list;watch;update;patch
 
 import (
 	"context"
@@ -15,21 +15,24 @@ import (
 
 const runnerSetKind = "autoscalingrunnerset"
 
+// runnerSetGVR identifies the AutoscalingRunnerSet resource served by the dynamic client.
+var runnerSetGVR = schema.GroupVersionResource{
+	Group:    "actions.github.com",
+	Version:  "v1alpha1",
+	Resource: "autoscalingrunnersets",
+}
+
 func (h *GithubAutoscalingRunnersets) init(client dynamic.Interface) {
-	h.Client = client.Resource(schema.GroupVersionResource{
-		Group:    "actions.github.com",
-		Version:  "v1alpha1",
-		Resource: "autoscalingrunnersets",
-	})
+	h.Client = client.Resource(runnerSetGVR)
 	h.AnnotationManager = utils.NewAnnotationManager()
 }
 
 // SetState sets the state of Github Autoscaling Runnersets resources based on the current period.
 func (h *GithubAutoscalingRunnersets) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
 	// Create adapters
-	lister := &runnerSetLister{client: h.Client, logger: h.Logger}
+	lister := &runnerSetLister{client: h.Client, cache: h.Resource.Cache, logger: h.Logger}
 	getter := &runnerSetGetter{client: h.Client}
-	updater := &runnerSetUpdater{client: h.Client}
+	patcher := &runnerSetPatcher{client: h.Client}
 
 	// Create scaling strategy
 	strategy := base.NewMinMaxReplicasStrategy(
@@ -44,7 +47,7 @@ func (h *GithubAutoscalingRunnersets) SetState(ctx context.Context) ([]common.Sc
 	processor := base.NewProcessor(
 		lister,
 		getter,
-		updater,
+		patcher,
 		strategy,
 		h.Resource,
 		h.Logger,
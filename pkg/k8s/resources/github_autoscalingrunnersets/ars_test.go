@@ -234,11 +234,11 @@ var _ = Describe("GithubAutoscalingRunnersets", func() {
 			Expect(failed[0].Reason).To(ContainSubstring("error parsing min value"))
 		})
 
-		It("records update failures", func() {
-			setupManager(newRunnerSet("rs-update", "test-ns", 2, 3))
+		It("records patch failures", func() {
+			setupManager(newRunnerSet("rs-patch", "test-ns", 2, 3))
 
-			dynClient.PrependReactor("update", "autoscalingrunnersets", func(action testing.Action) (bool, runtime.Object, error) {
-				return true, nil, errors.New("update failure")
+			dynClient.PrependReactor("patch", "autoscalingrunnersets", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("patch failure")
 			})
 
 			success, failed, err := manager.SetState(ctx)
@@ -246,8 +246,8 @@ var _ = Describe("GithubAutoscalingRunnersets", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(success).To(BeEmpty())
 			Expect(failed).To(HaveLen(1))
-			Expect(failed[0].Name).To(Equal("rs-update"))
-			Expect(failed[0].Reason).To(ContainSubstring("update failure"))
+			Expect(failed[0].Name).To(Equal("rs-patch"))
+			Expect(failed[0].Reason).To(ContainSubstring("patch failure"))
 		})
 	})
 
@@ -21,9 +21,12 @@ import (
 
 	autoscaleV2 "k8s.io/api/autoscaling/v2"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v2 "k8s.io/client-go/kubernetes/typed/autoscaling/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
 // hpaItem wraps autoscaleV2.HorizontalPodAutoscaler to implement ResourceItem interface.
@@ -47,12 +50,35 @@ func (h *hpaItem) SetAnnotations(annotations map[string]string) {
 	h.Annotations = annotations
 }
 
+func (h *hpaItem) GetObject() client.Object {
+	return h.HorizontalPodAutoscaler
+}
+
+// hpaGVR identifies the HorizontalPodAutoscaler resource in the informer cache.
+var hpaGVR = autoscaleV2.SchemeGroupVersion.WithResource("horizontalpodautoscalers")
+
 // hpaLister implements ResourceLister for HPAs.
 type hpaLister struct {
 	client v2.AutoscalingV2Interface
+	// cache serves the list when set, the API server otherwise.
+	cache *utils.InformerCache
 }
 
 func (l *hpaLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
+	if l.cache != nil {
+		cached, err := utils.ListCached[*autoscaleV2.HorizontalPodAutoscaler](ctx, l.cache, hpaGVR, namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]base.ResourceItem, len(cached))
+		for i := range cached {
+			items[i] = &hpaItem{HorizontalPodAutoscaler: cached[i]}
+		}
+
+		return items, nil
+	}
+
 	list, err := l.client.HorizontalPodAutoscalers(namespace).List(ctx, opts)
 	if err != nil {
 		return nil, err
@@ -80,28 +106,23 @@ func (g *hpaGetter) Get(ctx context.Context, namespace, name string, opts metaV1
 	return &hpaItem{HorizontalPodAutoscaler: hpa}, nil
 }
 
-// hpaUpdater implements ResourceUpdater for HPAs.
-type hpaUpdater struct {
+// hpaPatcher implements ResourcePatcher for HPAs.
+type hpaPatcher struct {
 	client v2.AutoscalingV2Interface
 }
 
-func (u *hpaUpdater) Update(
+func (u *hpaPatcher) Patch(
 	ctx context.Context,
-	namespace string,
-	resource base.ResourceItem,
-	opts metaV1.UpdateOptions,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
 ) (base.ResourceItem, error) {
-	item, ok := resource.(*hpaItem)
-	if !ok {
-		return nil, base.NewTypeAssertionError("*hpaItem", resource)
-	}
-
-	updated, err := u.client.HorizontalPodAutoscalers(namespace).Update(ctx, item.HorizontalPodAutoscaler, opts)
+	patched, err := u.client.HorizontalPodAutoscalers(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &hpaItem{HorizontalPodAutoscaler: updated}, nil
+	return &hpaItem{HorizontalPodAutoscaler: patched}, nil
 }
 
 // getMinMaxReplicas returns the min and max replicas from an HPA.
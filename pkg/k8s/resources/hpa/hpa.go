@@ -1,6 +1,6 @@
 package hpa
 
-// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;update;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;update;patch
 
 import (
 	"context"
@@ -19,9 +19,9 @@ func (h *HorizontalPodAutoscalers) init(client kubernetes.Interface) {
 // SetState sets the state of HorizontalPodAutoscaler resources based on the current period.
 func (h *HorizontalPodAutoscalers) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
 	// Create adapters
-	lister := &hpaLister{client: h.Client}
+	lister := &hpaLister{client: h.Client, cache: h.Resource.Cache}
 	getter := &hpaGetter{client: h.Client}
-	updater := &hpaUpdater{client: h.Client}
+	patcher := &hpaPatcher{client: h.Client}
 
 	// Create annotation manager
 	annotationMgr := utils.NewAnnotationManager()
@@ -39,7 +39,7 @@ func (h *HorizontalPodAutoscalers) SetState(ctx context.Context) ([]common.Scale
 	processor := base.NewProcessor(
 		lister,
 		getter,
-		updater,
+		patcher,
 		strategy,
 		h.Resource,
 		h.Logger,
@@ -10,8 +10,10 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog/log"
 	autoscaleV2 "k8s.io/api/autoscaling/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
@@ -263,7 +265,11 @@ var _ = Describe("HPA", func() {
 				_, err := fakeClient.AutoscalingV2().HorizontalPodAutoscalers(testNamespace).Create(ctx, testHPAObj, metaV1.CreateOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
-				// Mock the fake client to return an error when getting a specific HPA
+				// Conflict on the patch so the HPA is fetched again, then fail that get
+				fakeClient.PrependReactor("patch", "horizontalpodautoscalers", func(action testing.Action) (bool, runtime.Object, error) {
+					patchAction := action.(testing.PatchAction)
+					return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "horizontalpodautoscalers"}, patchAction.GetName(), errors.New("modified"))
+				})
 				fakeClient.PrependReactor("get", "horizontalpodautoscalers", func(action testing.Action) (bool, runtime.Object, error) {
 					return true, nil, errors.New("get error")
 				})
@@ -278,7 +284,7 @@ var _ = Describe("HPA", func() {
 				Expect(failed[0].Reason).To(ContainSubstring("get error"))
 			})
 
-			It("should handle HPA update error", func() {
+			It("should handle HPA patch error", func() {
 				// Create an HPA in the fake client
 				testHPAObj := &autoscaleV2.HorizontalPodAutoscaler{
 					ObjectMeta: metaV1.ObjectMeta{
@@ -294,9 +300,9 @@ var _ = Describe("HPA", func() {
 				_, err := fakeClient.AutoscalingV2().HorizontalPodAutoscalers(testNamespace).Create(ctx, testHPAObj, metaV1.CreateOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
-				// Mock the fake client to return an error when updating HPAs
-				fakeClient.PrependReactor("update", "horizontalpodautoscalers", func(action testing.Action) (bool, runtime.Object, error) {
-					return true, nil, errors.New("update error")
+				// Mock the fake client to return an error when patching HPAs
+				fakeClient.PrependReactor("patch", "horizontalpodautoscalers", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("patch error")
 				})
 
 				success, failed, err := hpaResource.SetState(ctx)
@@ -306,7 +312,7 @@ var _ = Describe("HPA", func() {
 				Expect(failed).To(HaveLen(1))
 				Expect(failed[0].Kind).To(Equal("hpa"))
 				Expect(failed[0].Name).To(Equal(testHPA))
-				Expect(failed[0].Reason).To(ContainSubstring("update error"))
+				Expect(failed[0].Reason).To(ContainSubstring("patch error"))
 			})
 
 			It("should handle annotation restoration error", func() {
@@ -383,11 +389,11 @@ var _ = Describe("HPA", func() {
 			})
 
 			It("should continue processing when one HPA fails", func() {
-				// Mock one HPA to fail on update
-				fakeClient.PrependReactor("update", "horizontalpodautoscalers", func(action testing.Action) (bool, runtime.Object, error) {
-					updateAction := action.(testing.UpdateAction)
-					if updateAction.GetObject().(*autoscaleV2.HorizontalPodAutoscaler).Name == "hpa2" {
-						return true, nil, errors.New("update error for hpa2")
+				// Mock one HPA to fail on patch
+				fakeClient.PrependReactor("patch", "horizontalpodautoscalers", func(action testing.Action) (bool, runtime.Object, error) {
+					patchAction := action.(testing.PatchAction)
+					if patchAction.GetName() == "hpa2" {
+						return true, nil, errors.New("patch error for hpa2")
 					}
 					return false, nil, nil
 				})
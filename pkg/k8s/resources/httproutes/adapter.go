@@ -0,0 +1,214 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httproutes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilJSON "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+// httpRouteItem wraps an unstructured HTTPRoute to implement the ResourceItem interface.
+// The object is kept unstructured so every Gateway API field survives;
+// only spec.rules[].backendRefs and annotations are modified.
+type httpRouteItem struct {
+	*unstructured.Unstructured
+}
+
+func (h *httpRouteItem) GetObject() client.Object {
+	return h.Unstructured
+}
+
+// httpRouteLister implements ResourceLister for HTTPRoutes.
+type httpRouteLister struct {
+	client dynamic.NamespaceableResourceInterface
+	// cache serves the list when set, the API server otherwise.
+	cache *utils.InformerCache
+}
+
+func (l *httpRouteLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
+	list, err := l.list(ctx, namespace, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]base.ResourceItem, len(list))
+	for i := range list {
+		items[i] = &httpRouteItem{Unstructured: list[i]}
+	}
+
+	return items, nil
+}
+
+// list returns the HTTPRoute objects from the informer cache when there is one, from the API server otherwise.
+func (l *httpRouteLister) list(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]*unstructured.Unstructured, error) {
+	if l.cache != nil {
+		return utils.ListCached[*unstructured.Unstructured](ctx, l.cache, httpRouteGVR, namespace, opts)
+	}
+
+	list, err := l.client.Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		items[i] = &list.Items[i]
+	}
+
+	return items, nil
+}
+
+// httpRouteGetter implements ResourceGetter for HTTPRoutes.
+type httpRouteGetter struct {
+	client dynamic.NamespaceableResourceInterface
+}
+
+func (g *httpRouteGetter) Get(ctx context.Context, namespace, name string, opts metaV1.GetOptions) (base.ResourceItem, error) {
+	item, err := g.client.Namespace(namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpRouteItem{Unstructured: item}, nil
+}
+
+// httpRoutePatcher implements ResourcePatcher for HTTPRoutes.
+type httpRoutePatcher struct {
+	client dynamic.NamespaceableResourceInterface
+}
+
+func (u *httpRoutePatcher) Patch(
+	ctx context.Context,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
+) (base.ResourceItem, error) {
+	patched, err := u.client.Namespace(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpRouteItem{Unstructured: patched}, nil
+}
+
+// getRules returns the spec.rules of an HTTPRoute.
+func getRules(item base.ResourceItem) (*httpRouteItem, []interface{}, error) {
+	r, ok := item.(*httpRouteItem)
+	if !ok {
+		return nil, nil, base.NewTypeAssertionError("*httpRouteItem", item)
+	}
+
+	rules, _, err := unstructured.NestedSlice(r.Object, "spec", "rules")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading httproute rules: %w", err)
+	}
+
+	return r, rules, nil
+}
+
+// getBackends serializes the backendRefs of every rule of an HTTPRoute.
+func getBackends(item base.ResourceItem) (string, error) {
+	_, rules, err := getRules(item)
+	if err != nil {
+		return "", err
+	}
+
+	backends := make([]interface{}, len(rules))
+	for i, rule := range rules {
+		if ruleMap, ok := rule.(map[string]interface{}); ok {
+			backends[i] = ruleMap["backendRefs"]
+		}
+	}
+
+	data, err := json.Marshal(backends)
+	if err != nil {
+		return "", fmt.Errorf("error encoding httproute backends: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// setBackends puts serialized backendRefs back on an HTTPRoute. The number of
+// rules must still match the one recorded when the route was switched.
+func setBackends(item base.ResourceItem, value string) error {
+	r, rules, err := getRules(item)
+	if err != nil {
+		return err
+	}
+
+	var backends []interface{}
+	if err := utilJSON.Unmarshal([]byte(value), &backends); err != nil {
+		return fmt.Errorf("error parsing httproute backends: %w", err)
+	}
+
+	if len(backends) != len(rules) {
+		return fmt.Errorf("%w: expected %d rules, got %d", errBackendsMismatch, len(backends), len(rules))
+	}
+
+	for i, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok || backends[i] == nil {
+			continue
+		}
+		ruleMap["backendRefs"] = backends[i]
+	}
+
+	return unstructured.SetNestedSlice(r.Object, rules, "spec", "rules")
+}
+
+// sleepBackends returns a function pointing the backendRefs of every rule of an
+// HTTPRoute at the sleeping Service. Rules without backendRefs, such as
+// redirects, are left untouched.
+func sleepBackends(sleeping *common.SleepingService) func(base.ResourceItem) error {
+	return func(item base.ResourceItem) error {
+		if sleeping == nil {
+			return errSleepingServiceRequired
+		}
+
+		r, rules, err := getRules(item)
+		if err != nil {
+			return err
+		}
+
+		for _, rule := range rules {
+			ruleMap, ok := rule.(map[string]interface{})
+			if !ok || ruleMap["backendRefs"] == nil {
+				continue
+			}
+			ruleMap["backendRefs"] = []interface{}{
+				map[string]interface{}{
+					"name": sleeping.Name,
+					"port": int64(sleeping.Port),
+				},
+			}
+		}
+
+		return unstructured.SetNestedSlice(r.Object, rules, "spec", "rules")
+	}
+}
@@ -0,0 +1,63 @@
+package httproutes
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;update;patch
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+const (
+	httpRouteKind    = "httproute"
+	httpRouteGroup   = "gateway.networking.k8s.io"
+	httpRouteVersion = "v1"
+)
+
+// httpRouteGVR identifies the HTTPRoute resource served by the dynamic client.
+var httpRouteGVR = schema.GroupVersionResource{
+	Group:    httpRouteGroup,
+	Version:  httpRouteVersion,
+	Resource: "httproutes",
+}
+
+func (h *HTTPRoutes) init(client dynamic.Interface) {
+	h.Client = client.Resource(httpRouteGVR)
+	h.AnnotationManager = utils.NewAnnotationManager()
+}
+
+// SetState sets the state of Gateway API HTTPRoute resources based on the current period.
+func (h *HTTPRoutes) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
+	// Create adapters
+	lister := &httpRouteLister{client: h.Client, cache: h.Resource.Cache}
+	getter := &httpRouteGetter{client: h.Client}
+	patcher := &httpRoutePatcher{client: h.Client}
+
+	// Create backend switch strategy
+	strategy := base.NewBackendSwitchStrategy(
+		httpRouteKind,
+		getBackends,
+		setBackends,
+		sleepBackends(h.SleepingService),
+		h.Logger,
+		h.AnnotationManager,
+	)
+
+	// Create processor
+	processor := base.NewProcessor(
+		lister,
+		getter,
+		patcher,
+		strategy,
+		h.Resource,
+		h.Logger,
+	)
+
+	// Process resources
+	return processor.ProcessResources(ctx)
+}
@@ -0,0 +1,209 @@
+package httproutes_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog/log"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/testing"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/httproutes"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+)
+
+var gvr = schema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1",
+	Resource: "httproutes",
+}
+
+func backendRef(name string, port int64) interface{} {
+	return map[string]interface{}{"name": name, "port": port}
+}
+
+func newHTTPRoute(name, namespace string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{
+					"backendRefs": []interface{}{backendRef("web", 80), backendRef("web-canary", 80)},
+				},
+				map[string]interface{}{
+					"backendRefs": []interface{}{backendRef("api", 8080)},
+				},
+			},
+		},
+	}}
+}
+
+func routeRules(dynClient *dynamicfake.FakeDynamicClient, namespace, name string) []interface{} {
+	route, err := dynClient.Resource(gvr).Namespace(namespace).Get(context.Background(), name, metaV1.GetOptions{})
+	Expect(err).ToNot(HaveOccurred())
+	rules, _, err := unstructured.NestedSlice(route.Object, "spec", "rules")
+	Expect(err).ToNot(HaveOccurred())
+	return rules
+}
+
+var _ = Describe("HTTPRoutes", func() {
+	var (
+		ctx           context.Context
+		dynClient     *dynamicfake.FakeDynamicClient
+		mockPeriod    *period.Period
+		manager       *httproutes.HTTPRoutes
+		testNamespace = "test-namespace"
+		testRoute     = "test-route"
+		origKey       = utils.AnnotationsPrefix + "/" + utils.AnnotationsOrigValue
+	)
+
+	setupManager := func(objs ...runtime.Object) {
+		dynClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+			runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{
+				gvr: "HTTPRouteList",
+			},
+			objs...,
+		)
+
+		manager = &httproutes.HTTPRoutes{
+			Resource: &utils.K8sResource{
+				NsList:      []string{testNamespace},
+				ListOptions: metaV1.ListOptions{},
+				Period:      mockPeriod,
+			},
+			Logger:            &log.Logger,
+			AnnotationManager: utils.NewAnnotationManager(),
+			SleepingService:   &common.SleepingService{Name: "sleeping", Port: 8080},
+		}
+		manager.Client = dynClient.Resource(gvr)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mockPeriod = &period.Period{
+			Type:      common.PeriodTypeDown,
+			IsActive:  true,
+			StartTime: time.Now(),
+			EndTime:   time.Now(),
+			Spec: &common.RecurringPeriod{
+				Days:      []common.DayOfWeek{common.DayAll},
+				StartTime: "00:00",
+				EndTime:   "23:59",
+			},
+		}
+	})
+
+	Describe("SetState", func() {
+		Context("when switching routes", func() {
+			BeforeEach(func() {
+				setupManager(newHTTPRoute(testRoute, testNamespace))
+			})
+
+			It("should route every rule to the sleeping service", func() {
+				success, failed, err := manager.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+				Expect(failed).To(BeEmpty())
+				Expect(success[0].Kind).To(Equal("httproute"))
+				Expect(success[0].Name).To(Equal(testRoute))
+
+				for _, rule := range routeRules(dynClient, testNamespace, testRoute) {
+					Expect(rule.(map[string]interface{})["backendRefs"]).To(Equal([]interface{}{backendRef("sleeping", 8080)}))
+				}
+			})
+
+			It("should fail when no sleeping service is configured", func() {
+				manager.SleepingService = nil
+
+				success, failed, err := manager.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(BeEmpty())
+				Expect(failed).To(HaveLen(1))
+				Expect(failed[0].Reason).To(ContainSubstring("sleepingService is required"))
+			})
+		})
+
+		Context("when restoring routes", func() {
+			BeforeEach(func() {
+				setupManager(newHTTPRoute(testRoute, testNamespace))
+
+				_, _, err := manager.SetState(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				mockPeriod.Type = "restore"
+			})
+
+			It("should restore the original backendRefs", func() {
+				success, failed, err := manager.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+				Expect(failed).To(BeEmpty())
+
+				Expect(routeRules(dynClient, testNamespace, testRoute)).To(Equal(newHTTPRoute(testRoute, testNamespace).Object["spec"].(map[string]interface{})["rules"]))
+
+				route, err := dynClient.Resource(gvr).Namespace(testNamespace).Get(ctx, testRoute, metaV1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(route.GetAnnotations()).ToNot(HaveKey(origKey))
+			})
+
+			It("should handle already restored routes", func() {
+				success, _, err := manager.SetState(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+
+				success, _, err = manager.SetState(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(BeEmpty())
+			})
+		})
+
+		Context("when handling errors", func() {
+			It("should handle route list error", func() {
+				setupManager()
+				dynClient.PrependReactor("list", "httproutes", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("list error")
+				})
+
+				success, failed, err := manager.SetState(ctx)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("error listing httproutes"))
+				Expect(success).To(BeEmpty())
+				Expect(failed).To(BeEmpty())
+			})
+
+			It("should handle route patch error", func() {
+				setupManager(newHTTPRoute(testRoute, testNamespace))
+				dynClient.PrependReactor("patch", "httproutes", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("patch error")
+				})
+
+				success, failed, err := manager.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(BeEmpty())
+				Expect(failed).To(HaveLen(1))
+				Expect(failed[0].Kind).To(Equal("httproute"))
+				Expect(failed[0].Reason).To(ContainSubstring("patch error"))
+			})
+		})
+	})
+})
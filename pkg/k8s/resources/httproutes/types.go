@@ -0,0 +1,28 @@
+// Package httproutes provides type definitions for Gateway API HTTPRoute resource management.
+package httproutes
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+var (
+	// errSleepingServiceRequired is returned when a route must be switched but no sleeping Service is configured.
+	errSleepingServiceRequired = errors.New("sleepingService is required to switch httproute backends")
+	// errBackendsMismatch is returned when the route rules changed since its backends were recorded.
+	errBackendsMismatch = errors.New("httproute rules changed since backends were recorded")
+)
+
+// HTTPRoutes represents a Gateway API HTTPRoute resource manager.
+type HTTPRoutes struct {
+	Resource          *utils.K8sResource
+	Client            dynamic.NamespaceableResourceInterface
+	Logger            *zerolog.Logger
+	AnnotationManager utils.AnnotationManager
+	SleepingService   *common.SleepingService
+}
@@ -0,0 +1,33 @@
+// Package httproutes provides utility functions for Gateway API HTTPRoute resource management.
+package httproutes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+// New creates a new Gateway API HTTPRoute resource manager.
+func New(ctx context.Context, config *utils.Config) (*HTTPRoutes, error) {
+	logger := zerolog.Ctx(ctx)
+	clientAdapter := utils.NewKubernetesClientAdapter(config.Client)
+	namespaceMgr := utils.NewNamespaceManager(clientAdapter, *logger, nil)
+
+	k8sResource, err := namespaceMgr.InitConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing k8s config: %w", err)
+	}
+
+	resource := &HTTPRoutes{
+		Resource:        k8sResource,
+		Logger:          logger,
+		SleepingService: config.SleepingService,
+	}
+
+	resource.init(config.DynamicClient)
+
+	return resource, nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingresses
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	v1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+// ingressItem wraps networkingV1.Ingress to implement ResourceItem interface.
+type ingressItem struct {
+	*networkingV1.Ingress
+}
+
+func (i *ingressItem) GetName() string {
+	return i.Name
+}
+
+func (i *ingressItem) GetNamespace() string {
+	return i.Namespace
+}
+
+func (i *ingressItem) GetAnnotations() map[string]string {
+	return i.Annotations
+}
+
+func (i *ingressItem) SetAnnotations(annotations map[string]string) {
+	i.Annotations = annotations
+}
+
+func (i *ingressItem) GetObject() client.Object {
+	return i.Ingress
+}
+
+// ingressesGVR identifies the Ingress resource in the informer cache.
+var ingressesGVR = networkingV1.SchemeGroupVersion.WithResource("ingresses")
+
+// ingressLister implements ResourceLister for ingresses.
+type ingressLister struct {
+	client v1.NetworkingV1Interface
+	// cache serves the list when set, the API server otherwise.
+	cache *utils.InformerCache
+}
+
+func (l *ingressLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
+	if l.cache != nil {
+		cached, err := utils.ListCached[*networkingV1.Ingress](ctx, l.cache, ingressesGVR, namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]base.ResourceItem, len(cached))
+		for i := range cached {
+			items[i] = &ingressItem{Ingress: cached[i]}
+		}
+
+		return items, nil
+	}
+
+	list, err := l.client.Ingresses(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]base.ResourceItem, len(list.Items))
+	for i := range list.Items {
+		items[i] = &ingressItem{Ingress: &list.Items[i]}
+	}
+
+	return items, nil
+}
+
+// ingressGetter implements ResourceGetter for ingresses.
+type ingressGetter struct {
+	client v1.NetworkingV1Interface
+}
+
+func (g *ingressGetter) Get(ctx context.Context, namespace, name string, opts metaV1.GetOptions) (base.ResourceItem, error) {
+	ingress, err := g.client.Ingresses(namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ingressItem{Ingress: ingress}, nil
+}
+
+// ingressPatcher implements ResourcePatcher for ingresses.
+type ingressPatcher struct {
+	client v1.NetworkingV1Interface
+}
+
+func (u *ingressPatcher) Patch(
+	ctx context.Context,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
+) (base.ResourceItem, error) {
+	patched, err := u.client.Ingresses(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ingressItem{Ingress: patched}, nil
+}
+
+// ingressBackends holds the backends of an Ingress: the default backend and,
+// for each rule, the backend of every HTTP path in order.
+type ingressBackends struct {
+	DefaultBackend *networkingV1.IngressBackend    `json:"defaultBackend,omitempty"`
+	Rules          [][]networkingV1.IngressBackend `json:"rules,omitempty"`
+}
+
+// getBackends serializes the backends of an ingress.
+func getBackends(item base.ResourceItem) (string, error) {
+	i, ok := item.(*ingressItem)
+	if !ok {
+		return "", base.NewTypeAssertionError("*ingressItem", item)
+	}
+
+	backends := ingressBackends{
+		DefaultBackend: i.Spec.DefaultBackend,
+		Rules:          make([][]networkingV1.IngressBackend, len(i.Spec.Rules)),
+	}
+	for r, rule := range i.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			backends.Rules[r] = append(backends.Rules[r], path.Backend)
+		}
+	}
+
+	data, err := json.Marshal(backends)
+	if err != nil {
+		return "", fmt.Errorf("error encoding ingress backends: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// setBackends puts serialized backends back on an ingress. The rules and paths
+// must still match the ones recorded when the ingress was switched.
+func setBackends(item base.ResourceItem, value string) error {
+	i, ok := item.(*ingressItem)
+	if !ok {
+		return base.NewTypeAssertionError("*ingressItem", item)
+	}
+
+	backends := ingressBackends{}
+	if err := json.Unmarshal([]byte(value), &backends); err != nil {
+		return fmt.Errorf("error parsing ingress backends: %w", err)
+	}
+
+	if len(backends.Rules) != len(i.Spec.Rules) {
+		return fmt.Errorf("%w: expected %d rules, got %d", errBackendsMismatch, len(backends.Rules), len(i.Spec.Rules))
+	}
+	for r, rule := range i.Spec.Rules {
+		var paths []networkingV1.HTTPIngressPath
+		if rule.HTTP != nil {
+			paths = rule.HTTP.Paths
+		}
+		if len(backends.Rules[r]) != len(paths) {
+			return fmt.Errorf("%w: expected %d paths in rule %d, got %d", errBackendsMismatch, len(backends.Rules[r]), r, len(paths))
+		}
+	}
+
+	i.Spec.DefaultBackend = backends.DefaultBackend
+	for r := range i.Spec.Rules {
+		for p := range backends.Rules[r] {
+			i.Spec.Rules[r].HTTP.Paths[p].Backend = backends.Rules[r][p]
+		}
+	}
+
+	return nil
+}
+
+// sleepBackends returns a function pointing every backend of an ingress at the
+// sleeping Service.
+func sleepBackends(sleeping *common.SleepingService) func(base.ResourceItem) error {
+	return func(item base.ResourceItem) error {
+		if sleeping == nil {
+			return errSleepingServiceRequired
+		}
+
+		i, ok := item.(*ingressItem)
+		if !ok {
+			return base.NewTypeAssertionError("*ingressItem", item)
+		}
+
+		backend := networkingV1.IngressBackend{
+			Service: &networkingV1.IngressServiceBackend{
+				Name: sleeping.Name,
+				Port: networkingV1.ServiceBackendPort{Number: sleeping.Port},
+			},
+		}
+
+		if i.Spec.DefaultBackend != nil {
+			i.Spec.DefaultBackend = backend.DeepCopy()
+		}
+		for r := range i.Spec.Rules {
+			if i.Spec.Rules[r].HTTP == nil {
+				continue
+			}
+			for p := range i.Spec.Rules[r].HTTP.Paths {
+				i.Spec.Rules[r].HTTP.Paths[p].Backend = *backend.DeepCopy()
+			}
+		}
+
+		return nil
+	}
+}
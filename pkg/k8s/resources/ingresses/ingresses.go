@@ -0,0 +1,51 @@
+package ingresses
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;update;patch
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+const ingressKind = "ingress"
+
+func (i *Ingresses) init(client kubernetes.Interface) {
+	i.Client = client.NetworkingV1()
+	i.AnnotationManager = utils.NewAnnotationManager()
+}
+
+// SetState sets the state of Ingress resources based on the current period.
+func (i *Ingresses) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
+	// Create adapters
+	lister := &ingressLister{client: i.Client, cache: i.Resource.Cache}
+	getter := &ingressGetter{client: i.Client}
+	patcher := &ingressPatcher{client: i.Client}
+
+	// Create backend switch strategy
+	strategy := base.NewBackendSwitchStrategy(
+		ingressKind,
+		getBackends,
+		setBackends,
+		sleepBackends(i.SleepingService),
+		i.Logger,
+		i.AnnotationManager,
+	)
+
+	// Create processor
+	processor := base.NewProcessor(
+		lister,
+		getter,
+		patcher,
+		strategy,
+		i.Resource,
+		i.Logger,
+	)
+
+	// Process resources
+	return processor.ProcessResources(ctx)
+}
@@ -0,0 +1,213 @@
+package ingresses_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog/log"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	ingressesPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/ingresses"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+)
+
+var _ = Describe("Ingresses", func() {
+	var (
+		ctx           context.Context
+		ingresses     *ingressesPkg.Ingresses
+		fakeClient    *fake.Clientset
+		mockPeriod    *period.Period
+		testNamespace = "test-namespace"
+		testIngress   = "test-ingress"
+		origKey       = utils.AnnotationsPrefix + "/" + utils.AnnotationsOrigValue
+	)
+
+	backend := func(name string, port int32) *networkingV1.IngressBackend {
+		return &networkingV1.IngressBackend{
+			Service: &networkingV1.IngressServiceBackend{
+				Name: name,
+				Port: networkingV1.ServiceBackendPort{Number: port},
+			},
+		}
+	}
+
+	newIngress := func(name string) *networkingV1.Ingress {
+		return &networkingV1.Ingress{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Spec: networkingV1.IngressSpec{
+				DefaultBackend: backend("web", 80),
+				Rules: []networkingV1.IngressRule{
+					{
+						Host: "app.example.com",
+						IngressRuleValue: networkingV1.IngressRuleValue{
+							HTTP: &networkingV1.HTTPIngressRuleValue{
+								Paths: []networkingV1.HTTPIngressPath{
+									{Path: "/", Backend: *backend("web", 80)},
+									{Path: "/api", Backend: *backend("api", 8080)},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = fake.NewSimpleClientset()
+
+		mockPeriod = &period.Period{
+			Type:      common.PeriodTypeDown,
+			IsActive:  true,
+			StartTime: time.Now(),
+			EndTime:   time.Now(),
+			Spec: &common.RecurringPeriod{
+				Days:      []common.DayOfWeek{common.DayAll},
+				StartTime: "00:00",
+				EndTime:   "23:59",
+			},
+		}
+
+		ingresses = &ingressesPkg.Ingresses{
+			Resource: &utils.K8sResource{
+				NsList:      []string{testNamespace},
+				ListOptions: metaV1.ListOptions{},
+				Period:      mockPeriod,
+			},
+			Logger:            &log.Logger,
+			AnnotationManager: utils.NewAnnotationManager(),
+			SleepingService:   &common.SleepingService{Name: "sleeping", Port: 8080},
+		}
+		ingresses.Client = fakeClient.NetworkingV1()
+	})
+
+	Describe("SetState", func() {
+		Context("when switching ingresses", func() {
+			BeforeEach(func() {
+				_, err := fakeClient.NetworkingV1().Ingresses(testNamespace).Create(ctx, newIngress(testIngress), metaV1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should route every backend to the sleeping service", func() {
+				success, failed, err := ingresses.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+				Expect(failed).To(BeEmpty())
+				Expect(success[0].Kind).To(Equal("ingress"))
+				Expect(success[0].Name).To(Equal(testIngress))
+
+				updated, err := fakeClient.NetworkingV1().Ingresses(testNamespace).Get(ctx, testIngress, metaV1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated.Spec.DefaultBackend).To(Equal(backend("sleeping", 8080)))
+				for _, path := range updated.Spec.Rules[0].HTTP.Paths {
+					Expect(path.Backend).To(Equal(*backend("sleeping", 8080)))
+				}
+				Expect(updated.Annotations).To(HaveKey(origKey))
+			})
+
+			It("should fail when no sleeping service is configured", func() {
+				ingresses.SleepingService = nil
+
+				success, failed, err := ingresses.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(BeEmpty())
+				Expect(failed).To(HaveLen(1))
+				Expect(failed[0].Reason).To(ContainSubstring("sleepingService is required"))
+			})
+		})
+
+		Context("when restoring ingresses", func() {
+			BeforeEach(func() {
+				_, err := fakeClient.NetworkingV1().Ingresses(testNamespace).Create(ctx, newIngress(testIngress), metaV1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = ingresses.SetState(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				mockPeriod.Type = "restore"
+			})
+
+			It("should restore the original backends", func() {
+				success, failed, err := ingresses.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+				Expect(failed).To(BeEmpty())
+
+				restored, err := fakeClient.NetworkingV1().Ingresses(testNamespace).Get(ctx, testIngress, metaV1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(restored.Spec).To(Equal(newIngress(testIngress).Spec))
+				Expect(restored.Annotations).ToNot(HaveKey(origKey))
+			})
+
+			It("should handle already restored ingresses", func() {
+				success, _, err := ingresses.SetState(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+
+				success, _, err = ingresses.SetState(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(BeEmpty())
+			})
+
+			It("should refuse to restore when the rules changed", func() {
+				current, err := fakeClient.NetworkingV1().Ingresses(testNamespace).Get(ctx, testIngress, metaV1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				current.Spec.Rules[0].HTTP.Paths = current.Spec.Rules[0].HTTP.Paths[:1]
+				_, err = fakeClient.NetworkingV1().Ingresses(testNamespace).Update(ctx, current, metaV1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				success, failed, err := ingresses.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(BeEmpty())
+				Expect(failed).To(HaveLen(1))
+				Expect(failed[0].Reason).To(ContainSubstring("rules changed"))
+			})
+		})
+
+		Context("when handling errors", func() {
+			It("should handle ingress list error", func() {
+				fakeClient.PrependReactor("list", "ingresses", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("list error")
+				})
+
+				success, failed, err := ingresses.SetState(ctx)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("error listing ingresss"))
+				Expect(success).To(BeEmpty())
+				Expect(failed).To(BeEmpty())
+			})
+
+			It("should handle ingress patch error", func() {
+				_, err := fakeClient.NetworkingV1().Ingresses(testNamespace).Create(ctx, newIngress(testIngress), metaV1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				fakeClient.PrependReactor("patch", "ingresses", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("patch error")
+				})
+
+				success, failed, err := ingresses.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(BeEmpty())
+				Expect(failed).To(HaveLen(1))
+				Expect(failed[0].Kind).To(Equal("ingress"))
+				Expect(failed[0].Reason).To(ContainSubstring("patch error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,28 @@
+// Package ingresses provides type definitions for Ingress resource management.
+package ingresses
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+	v1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+var (
+	// errSleepingServiceRequired is returned when an ingress must be switched but no sleeping Service is configured.
+	errSleepingServiceRequired = errors.New("sleepingService is required to switch ingress backends")
+	// errBackendsMismatch is returned when the ingress rules changed since its backends were recorded.
+	errBackendsMismatch = errors.New("ingress rules changed since backends were recorded")
+)
+
+// Ingresses represents an Ingress resource manager.
+type Ingresses struct {
+	Resource          *utils.K8sResource
+	Client            v1.NetworkingV1Interface
+	Logger            *zerolog.Logger
+	AnnotationManager utils.AnnotationManager
+	SleepingService   *common.SleepingService
+}
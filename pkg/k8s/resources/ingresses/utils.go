@@ -0,0 +1,33 @@
+// Package ingresses provides utility functions for Ingress resource management.
+package ingresses
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+// New creates a new Ingresses resource manager.
+func New(ctx context.Context, config *utils.Config) (*Ingresses, error) {
+	logger := zerolog.Ctx(ctx)
+	clientAdapter := utils.NewKubernetesClientAdapter(config.Client)
+	namespaceMgr := utils.NewNamespaceManager(clientAdapter, *logger, nil)
+
+	k8sResource, err := namespaceMgr.InitConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing k8s config: %w", err)
+	}
+
+	resource := &Ingresses{
+		Resource:        k8sResource,
+		Logger:          logger,
+		SleepingService: config.SleepingService,
+	}
+
+	resource.init(config.Client)
+
+	return resource, nil
+}
@@ -24,10 +24,13 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
 // scaledObjectItem wraps a ScaledObject to implement ResourceItem interface.
@@ -52,21 +55,33 @@ func (s *scaledObjectItem) SetAnnotations(annotations map[string]string) {
 	s.Annotations = annotations
 }
 
+func (s *scaledObjectItem) GetObject() client.Object {
+	// Start from a deep copy of the full live object so all KEDA fields not
+	// mirrored in the local ScaledObject type (scaleTargetRef, triggers, etc.)
+	// are preserved. Only the fields we manage are taken from the local type.
+	obj := s.unstructured.DeepCopy()
+	obj.SetAnnotations(s.ScaledObject.GetAnnotations())
+	// Cannot fail: the local type was converted from this object, so its spec is a map.
+	_ = syncSpecReplicas(s.Spec, obj)
+	return obj
+}
+
 // scaledObjectLister implements ResourceLister for KEDA ScaledObjects.
 type scaledObjectLister struct {
 	client dynamic.NamespaceableResourceInterface
+	// cache serves the list when set, the API server otherwise.
+	cache  *utils.InformerCache
 	logger *zerolog.Logger
 }
 
 func (l *scaledObjectLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
-	list, err := l.client.Namespace(namespace).List(ctx, opts)
+	list, err := l.list(ctx, namespace, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	items := make([]base.ResourceItem, 0, len(list.Items))
-	for i := range list.Items {
-		item := &list.Items[i]
+	items := make([]base.ResourceItem, 0, len(list))
+	for _, item := range list {
 		so := &ScaledObject{}
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, so); err != nil {
 			if l.logger != nil {
@@ -86,6 +101,25 @@ func (l *scaledObjectLister) List(ctx context.Context, namespace string, opts me
 	return items, nil
 }
 
+// list returns the ScaledObject objects from the informer cache when there is one, from the API server otherwise.
+func (l *scaledObjectLister) list(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]*unstructured.Unstructured, error) {
+	if l.cache != nil {
+		return utils.ListCached[*unstructured.Unstructured](ctx, l.cache, scaledObjectGVR, namespace, opts)
+	}
+
+	list, err := l.client.Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		items[i] = &list.Items[i]
+	}
+
+	return items, nil
+}
+
 // scaledObjectGetter implements ResourceGetter for KEDA ScaledObjects.
 type scaledObjectGetter struct {
 	client dynamic.NamespaceableResourceInterface
@@ -108,44 +142,30 @@ func (g *scaledObjectGetter) Get(ctx context.Context, namespace, name string, op
 	}, nil
 }
 
-// scaledObjectUpdater implements ResourceUpdater for KEDA ScaledObjects.
-type scaledObjectUpdater struct {
+// scaledObjectPatcher implements ResourcePatcher for KEDA ScaledObjects.
+type scaledObjectPatcher struct {
 	client dynamic.NamespaceableResourceInterface
 }
 
-func (u *scaledObjectUpdater) Update(
+func (u *scaledObjectPatcher) Patch(
 	ctx context.Context,
-	namespace string,
-	resource base.ResourceItem,
-	opts metaV1.UpdateOptions,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
 ) (base.ResourceItem, error) {
-	item, ok := resource.(*scaledObjectItem)
-	if !ok {
-		return nil, base.NewTypeAssertionError("*scaledObjectItem", resource)
-	}
-
-	// Start from a deep copy of the full live object so all KEDA fields not
-	// mirrored in the local ScaledObject type (scaleTargetRef, triggers, etc.)
-	// are preserved. Only patch the fields we manage.
-	obj := item.unstructured.DeepCopy()
-	obj.SetAnnotations(item.ScaledObject.GetAnnotations())
-	if err := syncSpecReplicas(item.Spec, obj); err != nil {
-		return nil, fmt.Errorf("error syncing spec replicas to unstructured: %w", err)
-	}
-
-	updated, err := u.client.Namespace(namespace).Update(ctx, obj, opts)
+	patched, err := u.client.Namespace(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	so := &ScaledObject{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(updated.Object, so); err != nil {
-		return nil, fmt.Errorf("error converting updated unstructured to ScaledObject: %w", err)
+	scaledobject := &ScaledObject{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(patched.Object, scaledobject); err != nil {
+		return nil, fmt.Errorf("error converting patched unstructured to ScaledObject: %w", err)
 	}
 
 	return &scaledObjectItem{
-		ScaledObject: so,
-		unstructured: updated,
+		ScaledObject: scaledobject,
+		unstructured: patched,
 	}, nil
 }
 
@@ -1,6 +1,6 @@
 package scaledobjects
 
-// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;update;patch
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;update;patch
 
 import (
 	"context"
@@ -19,21 +19,24 @@ const (
 	scaledObjectVersion = "v1alpha1"
 )
 
+// scaledObjectGVR identifies the ScaledObject resource served by the dynamic client.
+var scaledObjectGVR = schema.GroupVersionResource{
+	Group:    scaledObjectGroup,
+	Version:  scaledObjectVersion,
+	Resource: "scaledobjects",
+}
+
 func (s *ScaledObjects) init(client dynamic.Interface) {
-	s.Client = client.Resource(schema.GroupVersionResource{
-		Group:    scaledObjectGroup,
-		Version:  scaledObjectVersion,
-		Resource: "scaledobjects",
-	})
+	s.Client = client.Resource(scaledObjectGVR)
 	s.AnnotationManager = utils.NewAnnotationManager()
 }
 
 // SetState sets the state of KEDA ScaledObject resources based on the current period.
 func (s *ScaledObjects) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
 	// Create adapters
-	lister := &scaledObjectLister{client: s.Client, logger: s.Logger}
+	lister := &scaledObjectLister{client: s.Client, cache: s.Resource.Cache, logger: s.Logger}
 	getter := &scaledObjectGetter{client: s.Client}
-	updater := &scaledObjectUpdater{client: s.Client}
+	patcher := &scaledObjectPatcher{client: s.Client}
 
 	// Create KEDA pause strategy
 	strategy := base.NewKedaPauseStrategy(
@@ -48,7 +51,7 @@ func (s *ScaledObjects) SetState(ctx context.Context) ([]common.ScalerStatusSucc
 	processor := base.NewProcessor(
 		lister,
 		getter,
-		updater,
+		patcher,
 		strategy,
 		s.Resource,
 		s.Logger,
@@ -307,11 +307,11 @@ var _ = Describe("ScaledObjects", func() {
 			Expect(failed[0].Reason).To(ContainSubstring("error parsing min value"))
 		})
 
-		It("records update failures", func() {
-			setupManager(newScaledObject("so-update", "test-ns", 2, 3, nil))
+		It("records patch failures", func() {
+			setupManager(newScaledObject("so-patch", "test-ns", 2, 3, nil))
 
-			dynClient.PrependReactor("update", "scaledobjects", func(action testing.Action) (bool, runtime.Object, error) {
-				return true, nil, errors.New("update failure")
+			dynClient.PrependReactor("patch", "scaledobjects", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("patch failure")
 			})
 
 			success, failed, err := manager.SetState(ctx)
@@ -319,8 +319,8 @@ var _ = Describe("ScaledObjects", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(success).To(BeEmpty())
 			Expect(failed).To(HaveLen(1))
-			Expect(failed[0].Name).To(Equal("so-update"))
-			Expect(failed[0].Reason).To(ContainSubstring("update failure"))
+			Expect(failed[0].Name).To(Equal("so-patch"))
+			Expect(failed[0].Reason).To(ContainSubstring("patch failure"))
 		})
 	})
 
@@ -0,0 +1,135 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+// serviceItem wraps coreV1.Service to implement ResourceItem interface.
+type serviceItem struct {
+	*coreV1.Service
+}
+
+func (s *serviceItem) GetName() string {
+	return s.Name
+}
+
+func (s *serviceItem) GetNamespace() string {
+	return s.Namespace
+}
+
+func (s *serviceItem) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+func (s *serviceItem) SetAnnotations(annotations map[string]string) {
+	s.Annotations = annotations
+}
+
+func (s *serviceItem) GetObject() client.Object {
+	return s.Service
+}
+
+// servicesGVR identifies the Service resource in the informer cache.
+var servicesGVR = coreV1.SchemeGroupVersion.WithResource("services")
+
+// serviceLister implements ResourceLister for services.
+type serviceLister struct {
+	client v1.CoreV1Interface
+	// cache serves the list when set, the API server otherwise.
+	cache *utils.InformerCache
+}
+
+func (l *serviceLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
+	if l.cache != nil {
+		cached, err := utils.ListCached[*coreV1.Service](ctx, l.cache, servicesGVR, namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]base.ResourceItem, len(cached))
+		for i := range cached {
+			items[i] = &serviceItem{Service: cached[i]}
+		}
+
+		return items, nil
+	}
+
+	list, err := l.client.Services(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]base.ResourceItem, len(list.Items))
+	for i := range list.Items {
+		items[i] = &serviceItem{Service: &list.Items[i]}
+	}
+
+	return items, nil
+}
+
+// serviceGetter implements ResourceGetter for services.
+type serviceGetter struct {
+	client v1.CoreV1Interface
+}
+
+func (g *serviceGetter) Get(ctx context.Context, namespace, name string, opts metaV1.GetOptions) (base.ResourceItem, error) {
+	svc, err := g.client.Services(namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceItem{Service: svc}, nil
+}
+
+// servicePatcher implements ResourcePatcher for services.
+type servicePatcher struct {
+	client v1.CoreV1Interface
+}
+
+func (u *servicePatcher) Patch(
+	ctx context.Context,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
+) (base.ResourceItem, error) {
+	patched, err := u.client.Services(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceItem{Service: patched}, nil
+}
+
+// getSpec returns the spec of a service.
+func getSpec(item base.ResourceItem) *coreV1.ServiceSpec {
+	s, ok := item.(*serviceItem)
+	if !ok {
+		return nil
+	}
+	return &s.Spec
+}
@@ -0,0 +1,49 @@
+package services
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+func (s *Services) init(client kubernetes.Interface) {
+	s.Client = client.CoreV1()
+}
+
+// SetState sets the state of Service resources based on the current period.
+func (s *Services) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
+	// Create adapters
+	lister := &serviceLister{client: s.Client, cache: s.Resource.Cache}
+	getter := &serviceGetter{client: s.Client}
+	patcher := &servicePatcher{client: s.Client}
+
+	// Create annotation manager
+	annotationMgr := utils.NewAnnotationManager()
+
+	// Create service type strategy
+	strategy := base.NewServiceTypeStrategy(
+		"service",
+		getSpec,
+		s.Logger,
+		annotationMgr,
+	)
+
+	// Create processor
+	processor := base.NewProcessor(
+		lister,
+		getter,
+		patcher,
+		strategy,
+		s.Resource,
+		s.Logger,
+	)
+
+	// Process resources
+	return processor.ProcessResources(ctx)
+}
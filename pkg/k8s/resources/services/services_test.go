@@ -0,0 +1,193 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog/log"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	servicesPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/services"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+)
+
+var _ = Describe("Services", func() {
+	var (
+		ctx           context.Context
+		services      *servicesPkg.Services
+		fakeClient    *fake.Clientset
+		mockPeriod    *period.Period
+		testNamespace = "test-namespace"
+		testService   = "test-service"
+		origKey       = utils.AnnotationsPrefix + "/" + utils.AnnotationsOrigService
+		staticIPKey   = "networking.gke.io/load-balancer-ip-addresses"
+	)
+
+	newLoadBalancer := func(name string) *coreV1.Service {
+		return &coreV1.Service{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:        name,
+				Namespace:   testNamespace,
+				Annotations: map[string]string{staticIPKey: "preview-ip"},
+			},
+			Spec: coreV1.ServiceSpec{
+				Type:           coreV1.ServiceTypeLoadBalancer,
+				LoadBalancerIP: "203.0.113.10",
+				Ports: []coreV1.ServicePort{
+					{Name: "http", Port: 80, NodePort: 30080},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = fake.NewSimpleClientset()
+
+		mockPeriod = &period.Period{
+			Type:      common.PeriodTypeDown,
+			IsActive:  true,
+			StartTime: time.Now(),
+			EndTime:   time.Now(),
+			Spec: &common.RecurringPeriod{
+				Days:      []common.DayOfWeek{common.DayAll},
+				StartTime: "00:00",
+				EndTime:   "23:59",
+			},
+		}
+
+		services = &servicesPkg.Services{
+			Resource: &utils.K8sResource{
+				NsList:      []string{testNamespace},
+				ListOptions: metaV1.ListOptions{},
+				Period:      mockPeriod,
+			},
+			Logger: &log.Logger,
+		}
+		services.Client = fakeClient.CoreV1()
+	})
+
+	Describe("SetState", func() {
+		Context("when downgrading services", func() {
+			BeforeEach(func() {
+				_, err := fakeClient.CoreV1().Services(testNamespace).Create(ctx, newLoadBalancer(testService), metaV1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should switch LoadBalancer services to ClusterIP", func() {
+				success, failed, err := services.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+				Expect(failed).To(BeEmpty())
+				Expect(success[0].Kind).To(Equal("service"))
+				Expect(success[0].Name).To(Equal(testService))
+
+				updated, err := fakeClient.CoreV1().Services(testNamespace).Get(ctx, testService, metaV1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated.Spec.Type).To(Equal(coreV1.ServiceTypeClusterIP))
+				Expect(updated.Spec.LoadBalancerIP).To(BeEmpty())
+				Expect(updated.Spec.Ports[0].NodePort).To(BeZero())
+				Expect(updated.Annotations).To(HaveKey(origKey))
+			})
+
+			It("should skip services that are not LoadBalancers", func() {
+				internal := &coreV1.Service{
+					ObjectMeta: metaV1.ObjectMeta{Name: "internal", Namespace: testNamespace},
+					Spec:       coreV1.ServiceSpec{Type: coreV1.ServiceTypeClusterIP},
+				}
+				_, err := fakeClient.CoreV1().Services(testNamespace).Create(ctx, internal, metaV1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				success, failed, err := services.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+				Expect(failed).To(BeEmpty())
+				Expect(success[0].Name).To(Equal(testService))
+
+				unchanged, err := fakeClient.CoreV1().Services(testNamespace).Get(ctx, "internal", metaV1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(unchanged.Annotations).ToNot(HaveKey(origKey))
+			})
+		})
+
+		Context("when restoring services", func() {
+			BeforeEach(func() {
+				_, err := fakeClient.CoreV1().Services(testNamespace).Create(ctx, newLoadBalancer(testService), metaV1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = services.SetState(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				mockPeriod.Type = "restore"
+			})
+
+			It("should restore the original type, annotations, loadBalancerIP and ports", func() {
+				success, failed, err := services.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+				Expect(failed).To(BeEmpty())
+
+				restored, err := fakeClient.CoreV1().Services(testNamespace).Get(ctx, testService, metaV1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(restored.Spec.Type).To(Equal(coreV1.ServiceTypeLoadBalancer))
+				Expect(restored.Spec.LoadBalancerIP).To(Equal("203.0.113.10"))
+				Expect(restored.Spec.Ports[0].NodePort).To(Equal(int32(30080)))
+				Expect(restored.Annotations).To(Equal(map[string]string{staticIPKey: "preview-ip"}))
+			})
+
+			It("should handle already restored services", func() {
+				success, _, err := services.SetState(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(HaveLen(1))
+
+				success, _, err = services.SetState(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(BeEmpty())
+			})
+		})
+
+		Context("when handling errors", func() {
+			It("should handle service list error", func() {
+				fakeClient.PrependReactor("list", "services", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("list error")
+				})
+
+				success, failed, err := services.SetState(ctx)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("error listing services"))
+				Expect(success).To(BeEmpty())
+				Expect(failed).To(BeEmpty())
+			})
+
+			It("should handle service patch error", func() {
+				_, err := fakeClient.CoreV1().Services(testNamespace).Create(ctx, newLoadBalancer(testService), metaV1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				fakeClient.PrependReactor("patch", "services", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("patch error")
+				})
+
+				success, failed, err := services.SetState(ctx)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(success).To(BeEmpty())
+				Expect(failed).To(HaveLen(1))
+				Expect(failed[0].Kind).To(Equal("service"))
+				Expect(failed[0].Reason).To(ContainSubstring("patch error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,16 @@
+// Package services provides type definitions for Service resource management.
+package services
+
+import (
+	"github.com/rs/zerolog"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+// Services represents a Service resource manager.
+type Services struct {
+	Resource *utils.K8sResource
+	Client   v1.CoreV1Interface
+	Logger   *zerolog.Logger
+}
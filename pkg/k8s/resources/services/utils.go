@@ -0,0 +1,32 @@
+// Package services provides utility functions for Service resource management.
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+// New creates a new Services resource manager.
+func New(ctx context.Context, config *utils.Config) (*Services, error) {
+	logger := zerolog.Ctx(ctx)
+	clientAdapter := utils.NewKubernetesClientAdapter(config.Client)
+	namespaceMgr := utils.NewNamespaceManager(clientAdapter, *logger, nil)
+
+	k8sResource, err := namespaceMgr.InitConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing k8s config: %w", err)
+	}
+
+	resource := &Services{
+		Resource: k8sResource,
+		Logger:   logger,
+	}
+
+	resource.init(config.Client)
+
+	return resource, nil
+}
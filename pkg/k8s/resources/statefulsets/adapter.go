@@ -20,10 +20,15 @@ import (
 	"context"
 
 	appsV1 "k8s.io/api/apps/v1"
+	apiCoreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	coreV1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/base"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
 
 // statefulSetItem wraps appsV1.StatefulSet to implement ResourceItem interface.
@@ -47,12 +52,45 @@ func (s *statefulSetItem) SetAnnotations(annotations map[string]string) {
 	s.Annotations = annotations
 }
 
+func (s *statefulSetItem) GetObject() client.Object {
+	return s.StatefulSet
+}
+
+// Ready reports whether all desired replicas are ready.
+func (s *statefulSetItem) Ready() (bool, string) {
+	return base.ReplicasReady(s.Generation, s.Status.ObservedGeneration, s.Spec.Replicas, s.Status.ReadyReplicas)
+}
+
+// Released returns the resources requested by the pods removed by the scale-down.
+func (s *statefulSetItem) Released() apiCoreV1.ResourceList {
+	return base.ReleasedRequests(s.Annotations, s.Spec.Replicas, &s.Spec.Template.Spec)
+}
+
+// statefulSetsGVR identifies the StatefulSet resource in the informer cache.
+var statefulSetsGVR = appsV1.SchemeGroupVersion.WithResource("statefulsets")
+
 // statefulSetLister implements ResourceLister for statefulsets.
 type statefulSetLister struct {
 	client v1.AppsV1Interface
+	// cache serves the list when set, the API server otherwise.
+	cache *utils.InformerCache
 }
 
 func (l *statefulSetLister) List(ctx context.Context, namespace string, opts metaV1.ListOptions) ([]base.ResourceItem, error) {
+	if l.cache != nil {
+		cached, err := utils.ListCached[*appsV1.StatefulSet](ctx, l.cache, statefulSetsGVR, namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]base.ResourceItem, len(cached))
+		for i := range cached {
+			items[i] = &statefulSetItem{StatefulSet: cached[i]}
+		}
+
+		return items, nil
+	}
+
 	list, err := l.client.StatefulSets(namespace).List(ctx, opts)
 	if err != nil {
 		return nil, err
@@ -69,6 +107,8 @@ func (l *statefulSetLister) List(ctx context.Context, namespace string, opts met
 // statefulSetGetter implements ResourceGetter for statefulsets.
 type statefulSetGetter struct {
 	client v1.AppsV1Interface
+	// pods serves the pods diagnosed when the resource is not ready; nil disables the diagnosis.
+	pods coreV1.PodsGetter
 }
 
 func (g *statefulSetGetter) Get(ctx context.Context, namespace, name string, opts metaV1.GetOptions) (base.ResourceItem, error) {
@@ -80,28 +120,36 @@ func (g *statefulSetGetter) Get(ctx context.Context, namespace, name string, opt
 	return &statefulSetItem{StatefulSet: stateful}, nil
 }
 
-// statefulSetUpdater implements ResourceUpdater for statefulsets.
-type statefulSetUpdater struct {
-	client v1.AppsV1Interface
-}
+func (g *statefulSetGetter) DiagnoseReadiness(ctx context.Context, resource base.ResourceItem) (string, error) {
+	if g.pods == nil {
+		return "", nil
+	}
 
-func (u *statefulSetUpdater) Update(
-	ctx context.Context,
-	namespace string,
-	resource base.ResourceItem,
-	opts metaV1.UpdateOptions,
-) (base.ResourceItem, error) {
 	item, ok := resource.(*statefulSetItem)
 	if !ok {
-		return nil, base.NewTypeAssertionError("*statefulSetItem", resource)
+		return "", base.NewTypeAssertionError("*statefulSetItem", resource)
 	}
 
-	updated, err := u.client.StatefulSets(namespace).Update(ctx, item.StatefulSet, opts)
+	return utils.DiagnosePods(ctx, g.pods.Pods(item.Namespace), item.Spec.Selector)
+}
+
+// statefulSetPatcher implements ResourcePatcher for statefulsets.
+type statefulSetPatcher struct {
+	client v1.AppsV1Interface
+}
+
+func (u *statefulSetPatcher) Patch(
+	ctx context.Context,
+	namespace, name string,
+	data []byte,
+	opts metaV1.PatchOptions,
+) (base.ResourceItem, error) {
+	patched, err := u.client.StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, data, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &statefulSetItem{StatefulSet: updated}, nil
+	return &statefulSetItem{StatefulSet: patched}, nil
 }
 
 // getReplicas returns the replicas from a statefulset.
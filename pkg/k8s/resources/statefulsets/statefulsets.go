@@ -1,6 +1,6 @@
 package statefulsets
 
-// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;update;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;update;patch
 
 import (
 	"context"
@@ -14,14 +14,15 @@ import (
 
 func (s *Statefulsets) init(client kubernetes.Interface) {
 	s.Client = client.AppsV1()
+	s.Pods = client.CoreV1()
 }
 
 // SetState sets the state of StatefulSet resources based on the current period.
 func (s *Statefulsets) SetState(ctx context.Context) ([]common.ScalerStatusSuccess, []common.ScalerStatusFailed, error) {
 	// Create adapters
-	lister := &statefulSetLister{client: s.Client}
-	getter := &statefulSetGetter{client: s.Client}
-	updater := &statefulSetUpdater{client: s.Client}
+	lister := &statefulSetLister{client: s.Client, cache: s.Resource.Cache}
+	getter := &statefulSetGetter{client: s.Client, pods: s.Pods}
+	patcher := &statefulSetPatcher{client: s.Client}
 
 	// Create annotation manager
 	annotationMgr := utils.NewAnnotationManager()
@@ -39,7 +40,7 @@ func (s *Statefulsets) SetState(ctx context.Context) ([]common.ScalerStatusSucce
 	processor := base.NewProcessor(
 		lister,
 		getter,
-		updater,
+		patcher,
 		strategy,
 		s.Resource,
 		s.Logger,
@@ -10,8 +10,10 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rs/zerolog/log"
 	appsV1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
@@ -251,7 +253,11 @@ var _ = Describe("StatefulSets", func() {
 				_, err := fakeClient.AppsV1().StatefulSets(testNamespace).Create(ctx, testStatefulSetObj, metaV1.CreateOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
-				// Mock the fake client to return an error when getting a specific statefulset
+				// Conflict on the patch so the statefulset is fetched again, then fail that get
+				fakeClient.PrependReactor("patch", "statefulsets", func(action testing.Action) (bool, runtime.Object, error) {
+					patchAction := action.(testing.PatchAction)
+					return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "statefulsets"}, patchAction.GetName(), errors.New("modified"))
+				})
 				fakeClient.PrependReactor("get", "statefulsets", func(action testing.Action) (bool, runtime.Object, error) {
 					return true, nil, errors.New("get error")
 				})
@@ -266,7 +272,7 @@ var _ = Describe("StatefulSets", func() {
 				Expect(failed[0].Reason).To(ContainSubstring("get error"))
 			})
 
-			It("should handle statefulset update error", func() {
+			It("should handle statefulset patch error", func() {
 				// Create a statefulset in the fake client
 				testStatefulSetObj := &appsV1.StatefulSet{
 					ObjectMeta: metaV1.ObjectMeta{
@@ -281,9 +287,9 @@ var _ = Describe("StatefulSets", func() {
 				_, err := fakeClient.AppsV1().StatefulSets(testNamespace).Create(ctx, testStatefulSetObj, metaV1.CreateOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
-				// Mock the fake client to return an error when updating statefulsets
-				fakeClient.PrependReactor("update", "statefulsets", func(action testing.Action) (bool, runtime.Object, error) {
-					return true, nil, errors.New("update error")
+				// Mock the fake client to return an error when patching statefulsets
+				fakeClient.PrependReactor("patch", "statefulsets", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.New("patch error")
 				})
 
 				success, failed, err := statefulsets.SetState(ctx)
@@ -293,7 +299,7 @@ var _ = Describe("StatefulSets", func() {
 				Expect(failed).To(HaveLen(1))
 				Expect(failed[0].Kind).To(Equal("statefulset"))
 				Expect(failed[0].Name).To(Equal(testStatefulSet))
-				Expect(failed[0].Reason).To(ContainSubstring("update error"))
+				Expect(failed[0].Reason).To(ContainSubstring("patch error"))
 			})
 
 			It("should handle annotation restoration error", func() {
@@ -366,11 +372,11 @@ var _ = Describe("StatefulSets", func() {
 			})
 
 			It("should continue processing when one statefulset fails", func() {
-				// Mock one statefulset to fail on update
-				fakeClient.PrependReactor("update", "statefulsets", func(action testing.Action) (bool, runtime.Object, error) {
-					updateAction := action.(testing.UpdateAction)
-					if updateAction.GetObject().(*appsV1.StatefulSet).Name == "statefulset2" {
-						return true, nil, errors.New("update error for statefulset2")
+				// Mock one statefulset to fail on patch
+				fakeClient.PrependReactor("patch", "statefulsets", func(action testing.Action) (bool, runtime.Object, error) {
+					patchAction := action.(testing.PatchAction)
+					if patchAction.GetName() == "statefulset2" {
+						return true, nil, errors.New("patch error for statefulset2")
 					}
 					return false, nil, nil
 				})
@@ -4,6 +4,7 @@ package statefulsets
 import (
 	"github.com/rs/zerolog"
 	v1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	coreV1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
 )
@@ -12,5 +13,6 @@ import (
 type Statefulsets struct {
 	Resource *utils.K8sResource
 	Client   v1.AppsV1Interface
+	Pods     coreV1.PodsGetter
 	Logger   *zerolog.Logger
 }
@@ -204,3 +204,24 @@ func (am *annotationManager) RestoreIntAnnotations(annot map[string]string) (boo
 	//nolint:gosec // G109: int32 conversion is safe for replica count values which are bounded
 	return isRestored, ptr.To(int32(repAsInt)), annot, nil
 }
+
+// AddStringAnnotations adds string annotation with original value
+func (am *annotationManager) AddStringAnnotations(annot map[string]string, curPeriod *periodPkg.Period, value string) map[string]string {
+	annotations := am.AddAnnotations(annot, curPeriod)
+
+	_, isExists := annotations[AnnotationsPrefix+"/"+AnnotationsOrigValue]
+	if !isExists {
+		annotations[AnnotationsPrefix+"/"+AnnotationsOrigValue] = value
+	}
+
+	return annotations
+}
+
+// RestoreStringAnnotations restores string value from annotations
+func (am *annotationManager) RestoreStringAnnotations(annot map[string]string) (bool, string, map[string]string) {
+	rep, isExists := annot[AnnotationsPrefix+"/"+AnnotationsOrigValue]
+
+	annot = am.RemoveAnnotations(annot)
+
+	return !isExists, rep, annot
+}
@@ -333,4 +333,50 @@ var _ = Describe("AnnotationManager", func() {
 			Expect(value).To(BeNil())
 		})
 	})
+
+	Context("AddStringAnnotations", func() {
+		It("should add string annotation with original value", func() {
+			annotations := make(map[string]string)
+
+			result := annotationMgr.AddStringAnnotations(annotations, mockPeriod, `{"name":"web"}`)
+
+			Expect(result).To(HaveKeyWithValue(AnnotationsPrefix+"/"+AnnotationsOrigValue, `{"name":"web"}`))
+			Expect(result).To(HaveKeyWithValue(AnnotationsPrefix+"/"+PeriodType, "test-period"))
+		})
+
+		It("should not overwrite existing original value", func() {
+			annotations := map[string]string{
+				AnnotationsPrefix + "/" + AnnotationsOrigValue: "first",
+			}
+
+			result := annotationMgr.AddStringAnnotations(annotations, mockPeriod, "second")
+
+			Expect(result).To(HaveKeyWithValue(AnnotationsPrefix+"/"+AnnotationsOrigValue, "first"))
+		})
+	})
+
+	Context("RestoreStringAnnotations", func() {
+		It("should restore string value from annotations", func() {
+			annotations := map[string]string{
+				AnnotationsPrefix + "/" + AnnotationsOrigValue: "original",
+				"other-annotation": "kept",
+			}
+
+			isRestored, value, result := annotationMgr.RestoreStringAnnotations(annotations)
+
+			Expect(isRestored).To(BeFalse())
+			Expect(value).To(Equal("original"))
+			Expect(result).To(Equal(map[string]string{"other-annotation": "kept"}))
+		})
+
+		It("should return true when no annotation exists", func() {
+			annotations := map[string]string{}
+
+			isRestored, value, result := annotationMgr.RestoreStringAnnotations(annotations)
+
+			Expect(isRestored).To(BeTrue())
+			Expect(value).To(BeEmpty())
+			Expect(result).To(BeEmpty())
+		})
+	})
 })
@@ -19,26 +19,58 @@ package clients
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 // configBuilder implements ConfigBuilder interface
 type configBuilder struct {
-	envProvider EnvironmentProvider
+	envProvider  EnvironmentProvider
+	execCommands []string
+}
+
+// ConfigBuilderOption configures a config builder at construction time.
+type ConfigBuilderOption func(*configBuilder)
+
+// WithExecCommands lists the exec plugin commands the kubeconfigs of secrets may run, as written
+// in the kubeconfigs. Without it, kubeconfigs relying on an exec plugin are rejected.
+func WithExecCommands(commands ...string) ConfigBuilderOption {
+	return func(cb *configBuilder) {
+		cb.execCommands = commands
+	}
 }
 
 // NewConfigBuilder creates a new config builder
-func NewConfigBuilder(envProvider EnvironmentProvider) ConfigBuilder {
-	return &configBuilder{
+func NewConfigBuilder(envProvider EnvironmentProvider, opts ...ConfigBuilderOption) ConfigBuilder {
+	cb := &configBuilder{
 		envProvider: envProvider,
 	}
+	for _, opt := range opts {
+		opt(cb)
+	}
+	return cb
 }
 
-// BuildFromSecret builds a Kubernetes config from a secret
+// Keys of the auth secrets of remote clusters.
+const (
+	// KubeconfigKey holds a complete kubeconfig. When set, the other keys but ContextKey are ignored.
+	KubeconfigKey = "kubeconfig"
+	// ContextKey selects the context of the kubeconfig, instead of its current context.
+	ContextKey = "context"
+	// URLKey holds the URL of the API server, for secrets without a kubeconfig.
+	URLKey = "URL"
+	// InsecureKey disables the verification of the API server certificate.
+	InsecureKey = "insecure"
+)
+
+// BuildFromSecret builds the config of the cluster described by secret: either a kubeconfig,
+// possibly relying on an exec plugin, or the URL of the API server with a bearer token or a
+// client certificate.
 func (cb *configBuilder) BuildFromSecret(secret *corev1.Secret) (*rest.Config, error) {
 	if secret == nil {
 		return nil, fmt.Errorf("secret cannot be nil")
@@ -48,22 +80,28 @@ func (cb *configBuilder) BuildFromSecret(secret *corev1.Secret) (*rest.Config, e
 		return nil, fmt.Errorf("secret data cannot be nil")
 	}
 
+	if kubeconfig, ok := secret.Data[KubeconfigKey]; ok {
+		return cb.buildFromKubeconfigData(kubeconfig, string(secret.Data[ContextKey]))
+	}
+
 	// Validate required fields
 	if err := cb.validateSecretData(secret); err != nil {
 		return nil, fmt.Errorf("invalid secret data: %w", err)
 	}
 
 	// Parse insecure flag
-	insecure, err := strconv.ParseBool(string(secret.Data["insecure"]))
+	insecure, err := strconv.ParseBool(string(secret.Data[InsecureKey]))
 	if err != nil {
 		return nil, fmt.Errorf("error parsing insecure flag: %w", err)
 	}
 
 	config := &rest.Config{
-		Host:        string(secret.Data["URL"]),
+		Host:        string(secret.Data[URLKey]),
 		BearerToken: string(secret.Data[corev1.ServiceAccountTokenKey]),
 		TLSClientConfig: rest.TLSClientConfig{
 			CAData:   secret.Data[corev1.ServiceAccountRootCAKey],
+			CertData: secret.Data[corev1.TLSCertKey],
+			KeyData:  secret.Data[corev1.TLSPrivateKeyKey],
 			Insecure: insecure,
 		},
 	}
@@ -71,6 +109,66 @@ func (cb *configBuilder) BuildFromSecret(secret *corev1.Secret) (*rest.Config, e
 	return config, nil
 }
 
+// buildFromKubeconfigData builds the config of the context contextName of kubeconfig, or of its
+// current context when empty.
+func (cb *configBuilder) buildFromKubeconfigData(kubeconfig []byte, contextName string) (*rest.Config, error) {
+	raw, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubeconfig: %w", err)
+	}
+
+	if err := cb.validateKubeconfig(raw, contextName); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: contextName}
+	config, err := clientcmd.NewNonInteractiveClientConfig(*raw, contextName, overrides, nil).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error building config from kubeconfig: %w", err)
+	}
+
+	return config, nil
+}
+
+// validateKubeconfig rejects the context contextName of kubeconfig when its cluster or user read
+// files of the operator pod, or when its user runs an exec plugin that is not allowed. Secrets
+// are written by users who must not reach the files of the operator, nor run commands in its pod.
+func (cb *configBuilder) validateKubeconfig(kubeconfig *clientcmdapi.Config, contextName string) error {
+	if contextName == "" {
+		contextName = kubeconfig.CurrentContext
+	}
+	kubeContext, ok := kubeconfig.Contexts[contextName]
+	if !ok {
+		// Reported when building the config
+		return nil
+	}
+
+	if cluster, ok := kubeconfig.Clusters[kubeContext.Cluster]; ok && cluster.CertificateAuthority != "" {
+		return fmt.Errorf("cluster %s reads certificate-authority from a file: use certificate-authority-data",
+			kubeContext.Cluster)
+	}
+
+	user, ok := kubeconfig.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return nil
+	}
+	switch {
+	case user.TokenFile != "":
+		return fmt.Errorf("user %s reads tokenFile from a file: use token", kubeContext.AuthInfo)
+	case user.ClientCertificate != "" || user.ClientKey != "":
+		return fmt.Errorf("user %s reads client-certificate or client-key from a file: "+
+			"use client-certificate-data and client-key-data", kubeContext.AuthInfo)
+	case user.AuthProvider != nil:
+		return fmt.Errorf("user %s relies on the %s auth provider: use an exec plugin",
+			kubeContext.AuthInfo, user.AuthProvider.Name)
+	case user.Exec != nil && !slices.Contains(cb.execCommands, user.Exec.Command):
+		return fmt.Errorf("user %s runs the exec plugin %s, which is not allowed by the operator",
+			kubeContext.AuthInfo, user.Exec.Command)
+	}
+
+	return nil
+}
+
 // BuildFromEnvironment builds a Kubernetes config from environment
 func (cb *configBuilder) BuildFromEnvironment() (*rest.Config, error) {
 	// Try in-cluster config first
@@ -106,10 +204,9 @@ func (cb *configBuilder) BuildFromKubeconfig(kubeconfigPath string) (*rest.Confi
 // validateSecretData validates that the secret contains required data
 func (cb *configBuilder) validateSecretData(secret *corev1.Secret) error {
 	requiredFields := []string{
-		"URL",
-		corev1.ServiceAccountTokenKey,
+		URLKey,
 		corev1.ServiceAccountRootCAKey,
-		"insecure",
+		InsecureKey,
 	}
 
 	for _, field := range requiredFields {
@@ -118,5 +215,16 @@ func (cb *configBuilder) validateSecretData(secret *corev1.Secret) error {
 		}
 	}
 
+	// Authenticate with either a bearer token or a client certificate
+	if _, exists := secret.Data[corev1.ServiceAccountTokenKey]; exists {
+		return nil
+	}
+	for _, field := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if _, exists := secret.Data[field]; !exists {
+			return fmt.Errorf("missing required field: %s, or %s and %s",
+				corev1.ServiceAccountTokenKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		}
+	}
+
 	return nil
 }
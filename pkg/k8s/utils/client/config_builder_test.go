@@ -18,6 +18,7 @@ package clients_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	clients "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils/client"
@@ -31,6 +32,39 @@ func TestConfigBuilder(t *testing.T) {
 	// RunSpecs is handled by suite_test.go
 }
 
+// testKubeconfig holds two clusters, reached with an exec plugin and with a token.
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: eu
+clusters:
+- name: eu
+  cluster:
+    server: https://eu.example.com
+- name: us
+  cluster:
+    server: https://us.example.com
+contexts:
+- name: eu
+  context:
+    cluster: eu
+    user: oidc
+- name: us
+  context:
+    cluster: us
+    user: us-token
+users:
+- name: oidc
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: kubelogin
+      args: [get-token, --oidc-issuer-url=https://issuer.example.com]
+      interactiveMode: Never
+- name: us-token
+  user:
+    token: us-token
+`
+
 var _ = Describe("ConfigBuilder", func() {
 	var (
 		configBuilder   clients.ConfigBuilder
@@ -79,6 +113,163 @@ var _ = Describe("ConfigBuilder", func() {
 			Expect(config.TLSClientConfig.Insecure).To(BeTrue())
 		})
 
+		It("should build config from a client certificate", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					"URL":                          []byte("https://test-cluster.example.com"),
+					corev1.TLSCertKey:              []byte("test-cert"),
+					corev1.TLSPrivateKeyKey:        []byte("test-key"),
+					corev1.ServiceAccountRootCAKey: []byte("test-ca-data"),
+					"insecure":                     []byte("false"),
+				},
+			}
+
+			config, err := configBuilder.BuildFromSecret(secret)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.BearerToken).To(BeEmpty())
+			Expect(config.TLSClientConfig.CertData).To(Equal([]byte("test-cert")))
+			Expect(config.TLSClientConfig.KeyData).To(Equal([]byte("test-key")))
+		})
+
+		It("should return error for a client certificate without its key", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					"URL":                          []byte("https://test-cluster.example.com"),
+					corev1.TLSCertKey:              []byte("test-cert"),
+					corev1.ServiceAccountRootCAKey: []byte("test-ca-data"),
+					"insecure":                     []byte("false"),
+				},
+			}
+
+			config, err := configBuilder.BuildFromSecret(secret)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("missing required field: token, or tls.crt and tls.key"))
+			Expect(config).To(BeNil())
+		})
+
+		It("should build config from a kubeconfig with an exec plugin", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					clients.KubeconfigKey: []byte(testKubeconfig),
+				},
+			}
+
+			configBuilder = clients.NewConfigBuilder(mockEnvProvider, clients.WithExecCommands("kubelogin"))
+			config, err := configBuilder.BuildFromSecret(secret)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Host).To(Equal("https://eu.example.com"))
+			Expect(config.ExecProvider).ToNot(BeNil())
+			Expect(config.ExecProvider.Command).To(Equal("kubelogin"))
+		})
+
+		It("should return error for an exec plugin that is not allowed", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					clients.KubeconfigKey: []byte(testKubeconfig),
+				},
+			}
+
+			config, err := configBuilder.BuildFromSecret(secret)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("user oidc runs the exec plugin kubelogin, which is not allowed"))
+			Expect(config).To(BeNil())
+
+			configBuilder = clients.NewConfigBuilder(mockEnvProvider, clients.WithExecCommands("gke-gcloud-auth-plugin"))
+			_, err = configBuilder.BuildFromSecret(secret)
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return error for a kubeconfig reading local files", func() {
+			for field, value := range map[string]string{
+				"certificate-authority": "cluster:\n    server: https://eu.example.com\n    certificate-authority: /etc/ca.crt",
+				"tokenFile":             "user:\n    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token",
+				"client-certificate":    "user:\n    client-certificate: /etc/tls.crt\n    client-key: /etc/tls.key",
+			} {
+				kubeconfig := `apiVersion: v1
+kind: Config
+current-context: eu
+clusters:
+- name: eu
+  cluster:
+    server: https://eu.example.com
+contexts:
+- name: eu
+  context:
+    cluster: eu
+    user: eu
+users:
+- name: eu
+  user:
+    token: eu-token
+`
+				if strings.HasPrefix(value, "cluster:") {
+					kubeconfig = strings.Replace(kubeconfig, "cluster:\n    server: https://eu.example.com", value, 1)
+				} else {
+					kubeconfig = strings.Replace(kubeconfig, "user:\n    token: eu-token", value, 1)
+				}
+				secret := &corev1.Secret{
+					Data: map[string][]byte{
+						clients.KubeconfigKey: []byte(kubeconfig),
+					},
+				}
+
+				config, err := configBuilder.BuildFromSecret(secret)
+
+				Expect(err).To(HaveOccurred(), field)
+				Expect(err.Error()).To(ContainSubstring(field))
+				Expect(config).To(BeNil())
+			}
+		})
+
+		It("should build config from the context selected in the secret", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					clients.KubeconfigKey: []byte(testKubeconfig),
+					clients.ContextKey:    []byte("us"),
+				},
+			}
+
+			config, err := configBuilder.BuildFromSecret(secret)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Host).To(Equal("https://us.example.com"))
+			Expect(config.BearerToken).To(Equal("us-token"))
+		})
+
+		It("should return error for an unknown context", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					clients.KubeconfigKey: []byte(testKubeconfig),
+					clients.ContextKey:    []byte("asia"),
+				},
+			}
+
+			config, err := configBuilder.BuildFromSecret(secret)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error building config from kubeconfig"))
+			Expect(config).To(BeNil())
+		})
+
+		It("should return error for an invalid kubeconfig", func() {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					clients.KubeconfigKey: []byte("clusters: {"),
+				},
+			}
+
+			config, err := configBuilder.BuildFromSecret(secret)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error loading kubeconfig"))
+			Expect(config).To(BeNil())
+		})
+
 		It("should return error for nil secret", func() {
 			config, err := configBuilder.BuildFromSecret(nil)
 
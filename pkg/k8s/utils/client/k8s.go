@@ -9,10 +9,10 @@ import (
 
 // GetClient returns a kubernetes clientset and dynamic client using the new architecture
 // This is a convenience function that maintains backward compatibility
-func GetClient(secret *corev1.Secret) (*kubernetes.Clientset, dynamic.Interface, error) {
+func GetClient(secret *corev1.Secret, opts ...ConfigBuilderOption) (*kubernetes.Clientset, dynamic.Interface, error) {
 	// Create the new client manager with dependencies
 	envProvider := NewEnvironmentProvider()
-	configBuilder := NewConfigBuilder(envProvider)
+	configBuilder := NewConfigBuilder(envProvider, opts...)
 	clientFactory := NewClientFactory()
 	clientManager := NewClientManager(configBuilder, clientFactory)
 
@@ -1,7 +1,11 @@
 //nolint:nolintlint,revive // package name 'utils' is acceptable for K8s utility functions
 package utils
 
-import kubeconsts "github.com/kubecloudscaler/kubecloudscaler/pkg/consts"
+import (
+	"time"
+
+	kubeconsts "github.com/kubecloudscaler/kubecloudscaler/pkg/consts"
+)
 
 // Re-export shared constants for backward compatibility.
 const (
@@ -17,4 +21,23 @@ const (
 
 	// AnnotationIgnore is the annotation key for ignoring the resource (K8s-specific).
 	AnnotationIgnore = "ignore"
+	// AnnotationsOrigService is the annotation key holding the LoadBalancer settings
+	// of a Service downgraded to ClusterIP (K8s-specific).
+	AnnotationsOrigService = "original-service"
 )
+
+// DefaultDeploymentGracePeriod is how long scale-down is postponed after a deployment
+// when no grace period is configured.
+const DefaultDeploymentGracePeriod = time.Hour
+
+// DefaultConcurrency is how many resource kinds, namespaces and resources are processed in
+// parallel when no concurrency is configured.
+const DefaultConcurrency = 4
+
+// DefaultReadinessTimeout is how long the resources of a scaling group are awaited until ready
+// when no timeout is configured.
+const DefaultReadinessTimeout = 5 * time.Minute
+
+// MaxReadinessTimeout bounds the readiness timeouts, since a reconciliation holds a worker of the
+// controller while it waits.
+const MaxReadinessTimeout = 15 * time.Minute
@@ -0,0 +1,358 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DefaultCacheSyncTimeout is how long a list request waits for a new informer to sync.
+const DefaultCacheSyncTimeout = 30 * time.Second
+
+// cacheSyncPollInterval is how often a list request checks whether a new informer has synced.
+const cacheSyncPollInterval = 100 * time.Millisecond
+
+// ErrInformerCacheStopped is returned when listing from a stopped InformerCache.
+var ErrInformerCacheStopped = errors.New("informer cache stopped")
+
+// InformerCache serves the list requests of a single cluster from shared informers. An informer
+// is started on the first list of a resource type and kept in sync by a watch, so successive
+// reconciliations do not list from the API server again.
+type InformerCache struct {
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	// SyncTimeout bounds the wait for a new informer to sync (default: DefaultCacheSyncTimeout).
+	SyncTimeout time.Duration
+	// OnChange, when set before the first list, is called with each object created after its
+	// informer synced, and each object whose generation, labels or annotations change. Status
+	// updates of resources tracking their generation and deletions are not reported.
+	OnChange func(gvr schema.GroupVersionResource, obj metaV1.Object)
+
+	mu        sync.Mutex
+	informers map[schema.GroupVersionResource]*resourceInformer
+	stopped   bool
+}
+
+// resourceInformer is a running informer together with the means to stop it.
+type resourceInformer struct {
+	informer cache.SharedIndexInformer
+	cancel   context.CancelFunc
+
+	failOnce sync.Once
+	failed   chan struct{}
+	err      error
+}
+
+// fail records the error preventing the informer from syncing.
+func (r *resourceInformer) fail(err error) {
+	r.failOnce.Do(func() {
+		r.err = err
+		close(r.failed)
+	})
+}
+
+// NewInformerCache creates an InformerCache for the cluster reached by client and dynamicClient.
+// Built-in resources are watched with client, other resources with dynamicClient.
+func NewInformerCache(client kubernetes.Interface, dynamicClient dynamic.Interface) *InformerCache {
+	return &InformerCache{
+		client:        client,
+		dynamicClient: dynamicClient,
+		SyncTimeout:   DefaultCacheSyncTimeout,
+		informers:     make(map[schema.GroupVersionResource]*resourceInformer),
+	}
+}
+
+// List returns the cached objects of gvr in namespace (all namespaces when empty) that match the
+// label selector of opts, sorted by namespace and name like an API list. The objects are shared
+// with the cache and must not be modified.
+func (c *InformerCache) List(
+	ctx context.Context,
+	gvr schema.GroupVersionResource,
+	namespace string,
+	opts metaV1.ListOptions,
+) ([]runtime.Object, error) {
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	informer, err := c.syncedInformer(ctx, gvr)
+	if err != nil {
+		return nil, err
+	}
+
+	var cached []any
+	if namespace == "" {
+		cached = informer.GetIndexer().List()
+	} else {
+		cached, err = informer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	objects := make([]runtime.Object, 0, len(cached))
+	for _, item := range cached {
+		obj, ok := item.(runtime.Object)
+		if !ok {
+			continue
+		}
+		accessor, err := meta.Accessor(obj)
+		if err != nil || !selector.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	slices.SortFunc(objects, func(a, b runtime.Object) int {
+		metaA, _ := meta.Accessor(a)
+		metaB, _ := meta.Accessor(b)
+		if n := strings.Compare(metaA.GetNamespace(), metaB.GetNamespace()); n != 0 {
+			return n
+		}
+		return strings.Compare(metaA.GetName(), metaB.GetName())
+	})
+
+	return objects, nil
+}
+
+// Stop stops all informers. Later list requests fail with ErrInformerCacheStopped.
+func (c *InformerCache) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stopped = true
+	for gvr, r := range c.informers {
+		r.cancel()
+		delete(c.informers, gvr)
+	}
+}
+
+// syncedInformer returns the informer of gvr once it has synced, starting it if needed. An
+// informer that fails to sync, e.g. because the resource is not served by the cluster or is
+// forbidden, is stopped and its error returned; the next request starts a new one.
+func (c *InformerCache) syncedInformer(ctx context.Context, gvr schema.GroupVersionResource) (cache.SharedIndexInformer, error) {
+	r, err := c.informerFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	if r.informer.HasSynced() {
+		return r.informer, nil
+	}
+
+	timeout := c.SyncTimeout
+	if timeout <= 0 {
+		timeout = DefaultCacheSyncTimeout
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = wait.PollUntilContextCancel(syncCtx, cacheSyncPollInterval, true, func(context.Context) (bool, error) {
+		select {
+		case <-r.failed:
+			return false, r.err
+		default:
+			return r.informer.HasSynced(), nil
+		}
+	})
+	if err == nil {
+		return r.informer, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	c.drop(gvr, r)
+	if wait.Interrupted(err) {
+		return nil, fmt.Errorf("timed out waiting for %s cache to sync", gvr.Resource)
+	}
+	return nil, err
+}
+
+// informerFor returns the informer of gvr, starting a new one if there is none.
+func (c *InformerCache) informerFor(gvr schema.GroupVersionResource) (*resourceInformer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopped {
+		return nil, ErrInformerCacheStopped
+	}
+	if r, ok := c.informers[gvr]; ok {
+		return r, nil
+	}
+
+	informer := c.newInformer(gvr)
+	r := &resourceInformer{informer: informer, failed: make(chan struct{})}
+
+	// Managed fields are never read: drop them to save memory
+	_ = informer.SetTransform(func(obj any) (any, error) {
+		if accessor, err := meta.Accessor(obj); err == nil {
+			accessor.SetManagedFields(nil)
+		}
+		return obj, nil
+	})
+	// A list error before the first sync fails the pending requests instead of retrying silently
+	_ = informer.SetWatchErrorHandlerWithContext(func(ctx context.Context, reflector *cache.Reflector, err error) {
+		if !informer.HasSynced() {
+			r.fail(err)
+		}
+		cache.DefaultWatchErrorHandler(ctx, reflector, err)
+	})
+
+	if onChange := c.OnChange; onChange != nil {
+		_, _ = informer.AddEventHandler(changeHandler(gvr, onChange))
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	go informer.RunWithContext(runCtx)
+
+	c.informers[gvr] = r
+	return r, nil
+}
+
+// changeHandler reports the objects created after the initial list, and those whose desired
+// state may have changed, to onChange.
+func changeHandler(
+	gvr schema.GroupVersionResource,
+	onChange func(schema.GroupVersionResource, metaV1.Object),
+) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			if accessor, err := meta.Accessor(obj); err == nil && !isInInitialList {
+				onChange(gvr, accessor)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			oldAccessor, err := meta.Accessor(oldObj)
+			if err != nil {
+				return
+			}
+			newAccessor, err := meta.Accessor(newObj)
+			if err != nil {
+				return
+			}
+			// Resources that do not track their generation, like services, compare their spec
+			generation := newAccessor.GetGeneration()
+			if generation == 0 && specChanged(oldObj, newObj) ||
+				generation != 0 && oldAccessor.GetGeneration() != generation ||
+				!maps.Equal(oldAccessor.GetLabels(), newAccessor.GetLabels()) ||
+				!maps.Equal(oldAccessor.GetAnnotations(), newAccessor.GetAnnotations()) {
+				onChange(gvr, newAccessor)
+			}
+		},
+	}
+}
+
+// specChanged reports whether the spec of two versions of an object differs, or whether it
+// cannot be told.
+func specChanged(oldObj, newObj any) bool {
+	oldSpec, oldOk := objectSpec(oldObj)
+	newSpec, newOk := objectSpec(newObj)
+	return !oldOk || !newOk || !equality.Semantic.DeepEqual(oldSpec, newSpec)
+}
+
+// objectSpec returns the spec field of obj in its unstructured form.
+func objectSpec(obj any) (any, bool) {
+	var content map[string]any
+	switch typed := obj.(type) {
+	case *unstructured.Unstructured:
+		content = typed.Object
+	case runtime.Object:
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(typed); err != nil {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	return content["spec"], true
+}
+
+// newInformer builds an informer over all namespaces for gvr: a typed one for built-in
+// resources, a dynamic one otherwise. A new factory is used every time so that an informer
+// dropped after a failed sync is never handed out again.
+func (c *InformerCache) newInformer(gvr schema.GroupVersionResource) cache.SharedIndexInformer {
+	if c.client != nil {
+		// Factory informers are indexed by namespace
+		factory := informers.NewSharedInformerFactory(c.client, 0)
+		if generic, err := factory.ForResource(gvr); err == nil {
+			return generic.Informer()
+		}
+	}
+
+	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	return dynamicinformer.NewFilteredDynamicInformer(c.dynamicClient, gvr, metaV1.NamespaceAll, 0, indexers, nil).Informer()
+}
+
+// drop stops the informer r of gvr and forgets it, unless it has been replaced already.
+func (c *InformerCache) drop(gvr schema.GroupVersionResource, r *resourceInformer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r.cancel()
+	if c.informers[gvr] == r {
+		delete(c.informers, gvr)
+	}
+}
+
+// ListCached lists the objects of gvr in namespace matching opts from c. It returns deep copies
+// of type T that callers may modify.
+func ListCached[T runtime.Object](
+	ctx context.Context,
+	c *InformerCache,
+	gvr schema.GroupVersionResource,
+	namespace string,
+	opts metaV1.ListOptions,
+) ([]T, error) {
+	objects, err := c.List(ctx, gvr, namespace, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]T, 0, len(objects))
+	for _, obj := range objects {
+		item, ok := obj.DeepCopyObject().(T)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %T in %s cache", obj, gvr.Resource)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+var _ = Describe("InformerCache", func() {
+	var (
+		ctx            context.Context
+		deploymentsGVR = appsV1.SchemeGroupVersion.WithResource("deployments")
+		runnerSetsGVR  = schema.GroupVersionResource{
+			Group:    "actions.github.com",
+			Version:  "v1alpha1",
+			Resource: "autoscalingrunnersets",
+		}
+	)
+
+	newDeployment := func(namespace, name string, labels map[string]string) *appsV1.Deployment {
+		return &appsV1.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		}
+	}
+
+	newRunnerSet := func(namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("actions.github.com/v1alpha1")
+		obj.SetKind("AutoscalingRunnerSet")
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+
+	newDynamicClient := func(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+			runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{runnerSetsGVR: "AutoscalingRunnerSetList"},
+			objects...,
+		)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	Context("with built-in resources", func() {
+		var (
+			fakeClient *fake.Clientset
+			cache      *utils.InformerCache
+		)
+
+		BeforeEach(func() {
+			fakeClient = fake.NewSimpleClientset(
+				newDeployment("ns-b", "web", map[string]string{"app": "web"}),
+				newDeployment("ns-a", "worker", map[string]string{"app": "worker"}),
+				newDeployment("ns-a", "api", map[string]string{"app": "web"}),
+			)
+			cache = utils.NewInformerCache(fakeClient, newDynamicClient())
+			DeferCleanup(cache.Stop)
+		})
+
+		It("should list all namespaces sorted by namespace and name", func() {
+			items, err := utils.ListCached[*appsV1.Deployment](ctx, cache, deploymentsGVR, "", metaV1.ListOptions{})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(items).To(HaveLen(3))
+			Expect(items[0].Namespace + "/" + items[0].Name).To(Equal("ns-a/api"))
+			Expect(items[1].Namespace + "/" + items[1].Name).To(Equal("ns-a/worker"))
+			Expect(items[2].Namespace + "/" + items[2].Name).To(Equal("ns-b/web"))
+		})
+
+		It("should filter by namespace and label selector", func() {
+			items, err := utils.ListCached[*appsV1.Deployment](ctx, cache, deploymentsGVR, "ns-a",
+				metaV1.ListOptions{LabelSelector: "app=web"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Name).To(Equal("api"))
+		})
+
+		It("should reject an invalid label selector", func() {
+			_, err := cache.List(ctx, deploymentsGVR, "", metaV1.ListOptions{LabelSelector: "app in (web"})
+
+			Expect(err).To(MatchError(ContainSubstring("invalid label selector")))
+		})
+
+		It("should list from the API server only once", func() {
+			for range 3 {
+				_, err := cache.List(ctx, deploymentsGVR, "", metaV1.ListOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			lists := 0
+			for _, action := range fakeClient.Actions() {
+				if action.Matches("list", "deployments") {
+					lists++
+				}
+			}
+			Expect(lists).To(Equal(1))
+		})
+
+		It("should pick up resources created after the first list", func() {
+			_, err := cache.List(ctx, deploymentsGVR, "", metaV1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = fakeClient.AppsV1().Deployments("ns-c").Create(ctx,
+				newDeployment("ns-c", "late", nil), metaV1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() ([]runtime.Object, error) {
+				return cache.List(ctx, deploymentsGVR, "ns-c", metaV1.ListOptions{})
+			}).Should(HaveLen(1))
+		})
+
+		It("should return copies that do not alter the cache", func() {
+			items, err := utils.ListCached[*appsV1.Deployment](ctx, cache, deploymentsGVR, "ns-b", metaV1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			items[0].Labels["app"] = "changed"
+
+			items, err = utils.ListCached[*appsV1.Deployment](ctx, cache, deploymentsGVR, "ns-b", metaV1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(items[0].Labels).To(HaveKeyWithValue("app", "web"))
+		})
+
+		It("should report created and changed objects, not status updates", func() {
+			changes := make(chan string, 10)
+			cache.OnChange = func(gvr schema.GroupVersionResource, obj metaV1.Object) {
+				changes <- gvr.Resource + ":" + obj.GetName()
+			}
+			_, err := cache.List(ctx, deploymentsGVR, "", metaV1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Consistently(changes).ShouldNot(Receive())
+
+			_, err = fakeClient.AppsV1().Deployments("ns-c").Create(ctx,
+				newDeployment("ns-c", "late", nil), metaV1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(changes).Should(Receive(Equal("deployments:late")))
+
+			web, err := fakeClient.AppsV1().Deployments("ns-b").Get(ctx, "web", metaV1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			web.Generation = 1
+			web.Status.Replicas = 2
+			_, err = fakeClient.AppsV1().Deployments("ns-b").Update(ctx, web, metaV1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(changes).Should(Receive(Equal("deployments:web")))
+
+			web.Status.Replicas = 3
+			web, err = fakeClient.AppsV1().Deployments("ns-b").Update(ctx, web, metaV1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Consistently(changes).ShouldNot(Receive())
+
+			web.Annotations = map[string]string{"scaled": "true"}
+			_, err = fakeClient.AppsV1().Deployments("ns-b").Update(ctx, web, metaV1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(changes).Should(Receive(Equal("deployments:web")))
+		})
+
+		It("should report spec changes of resources without generation, not status updates", func() {
+			servicesGVR := coreV1.SchemeGroupVersion.WithResource("services")
+			_, err := fakeClient.CoreV1().Services("ns-b").Create(ctx, &coreV1.Service{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "ns-b"},
+				Spec:       coreV1.ServiceSpec{Type: coreV1.ServiceTypeClusterIP},
+			}, metaV1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			changes := make(chan string, 10)
+			cache.OnChange = func(gvr schema.GroupVersionResource, obj metaV1.Object) {
+				changes <- gvr.Resource + ":" + obj.GetName()
+			}
+			_, err = cache.List(ctx, servicesGVR, "", metaV1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			web, err := fakeClient.CoreV1().Services("ns-b").Get(ctx, "web", metaV1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			web.Status.LoadBalancer.Ingress = []coreV1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			web, err = fakeClient.CoreV1().Services("ns-b").Update(ctx, web, metaV1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Consistently(changes).ShouldNot(Receive())
+
+			web.Spec.Type = coreV1.ServiceTypeLoadBalancer
+			_, err = fakeClient.CoreV1().Services("ns-b").Update(ctx, web, metaV1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(changes).Should(Receive(Equal("services:web")))
+		})
+
+		It("should fail once stopped", func() {
+			cache.Stop()
+
+			_, err := cache.List(ctx, deploymentsGVR, "", metaV1.ListOptions{})
+
+			Expect(err).To(MatchError(utils.ErrInformerCacheStopped))
+		})
+	})
+
+	Context("with custom resources", func() {
+		It("should list them through the dynamic client", func() {
+			cache := utils.NewInformerCache(fake.NewSimpleClientset(),
+				newDynamicClient(newRunnerSet("ci", "runners"), newRunnerSet("other", "runners")))
+			DeferCleanup(cache.Stop)
+
+			items, err := utils.ListCached[*unstructured.Unstructured](ctx, cache, runnerSetsGVR, "ci", metaV1.ListOptions{})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].GetNamespace()).To(Equal("ci"))
+		})
+
+		It("should return the list error instead of waiting for the sync timeout", func() {
+			dynClient := newDynamicClient()
+			dynClient.PrependReactor("list", "autoscalingrunnersets",
+				func(k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(runnerSetsGVR.GroupResource(), "", nil)
+				})
+			cache := utils.NewInformerCache(fake.NewSimpleClientset(), dynClient)
+			cache.SyncTimeout = time.Minute
+			DeferCleanup(cache.Stop)
+
+			start := time.Now()
+			_, err := cache.List(ctx, runnerSetsGVR, "", metaV1.ListOptions{})
+
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+		})
+
+		It("should time out when the cache does not sync", func() {
+			release := make(chan struct{})
+			DeferCleanup(func() { close(release) })
+			dynClient := newDynamicClient()
+			dynClient.PrependReactor("list", "autoscalingrunnersets",
+				func(k8stesting.Action) (bool, runtime.Object, error) {
+					<-release
+					return true, nil, context.Canceled
+				})
+			cache := utils.NewInformerCache(fake.NewSimpleClientset(), dynClient)
+			cache.SyncTimeout = 200 * time.Millisecond
+			DeferCleanup(cache.Stop)
+
+			_, err := cache.List(ctx, runnerSetsGVR, "", metaV1.ListOptions{})
+
+			Expect(err).To(MatchError(ContainSubstring("timed out waiting for autoscalingrunnersets cache to sync")))
+		})
+	})
+
+	Context("as the namespace source", func() {
+		It("should list namespaces from the cache", func() {
+			cache := utils.NewInformerCache(fake.NewSimpleClientset(
+				&coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-b"}},
+				&coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-a"}},
+				&coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "kube-system"}},
+			), newDynamicClient())
+			DeferCleanup(cache.Stop)
+
+			namespaceMgr := utils.NewNamespaceManager(utils.NewFakeKubernetesClient(), zerolog.Nop(), nil)
+			nsList, err := namespaceMgr.SetNamespaceList(ctx, &utils.Config{
+				Cache:                        cache,
+				ExcludeNamespaces:            []string{"team-b"},
+				ForceExcludeSystemNamespaces: true,
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nsList).To(Equal([]string{"team-a"}))
+		})
+
+		It("should select namespaces by label from the cache", func() {
+			preview := map[string]string{"env": "preview"}
+			cache := utils.NewInformerCache(fake.NewSimpleClientset(
+				&coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "pr-12", Labels: preview}},
+				&coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "pr-7", Labels: preview}},
+				&coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-a"}},
+			), newDynamicClient())
+			DeferCleanup(cache.Stop)
+
+			namespaceMgr := utils.NewNamespaceManager(utils.NewFakeKubernetesClient(), zerolog.Nop(), nil)
+			nsList, err := namespaceMgr.SetNamespaceList(ctx, &utils.Config{
+				Cache:             cache,
+				ExcludeNamespaces: []string{"pr-7"},
+				NamespaceSelector: &metaV1.LabelSelector{MatchLabels: preview},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nsList).To(Equal([]string{"pr-12"}))
+		})
+	})
+})
@@ -69,6 +69,8 @@ type AnnotationManager interface {
 	RestoreBoolAnnotations(annot map[string]string) (bool, *bool, map[string]string, error)
 	AddIntAnnotations(annot map[string]string, curPeriod *periodPkg.Period, value *int32) map[string]string
 	RestoreIntAnnotations(annot map[string]string) (bool, *int32, map[string]string, error)
+	AddStringAnnotations(annot map[string]string, curPeriod *periodPkg.Period, value string) map[string]string
+	RestoreStringAnnotations(annot map[string]string) (bool, string, map[string]string)
 }
 
 // Ensure that the concrete types implement the interfaces
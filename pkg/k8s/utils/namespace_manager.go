@@ -26,11 +26,16 @@ import (
 	"time"
 
 	"github.com/rs/zerolog"
+	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/tracing"
 )
 
 const namespaceCacheTTL = 5 * time.Minute
 
+var namespacesGVR = coreV1.SchemeGroupVersion.WithResource("namespaces")
+
 // EnvProvider provides environment variable access (injectable for testability).
 // clients.EnvironmentProvider from pkg/k8s/utils/client implements this interface.
 type EnvProvider interface {
@@ -68,15 +73,28 @@ func NewNamespaceManager(client KubernetesClient, logger zerolog.Logger, envProv
 }
 
 // SetNamespaceList sets the namespace list based on configuration
-func (nm *namespaceManager) SetNamespaceList(ctx context.Context, config *Config) ([]string, error) {
-	var nsList []string
+func (nm *namespaceManager) SetNamespaceList(ctx context.Context, config *Config) (nsList []string, err error) {
+	ctx, span := tracing.Start(ctx, "k8s.ListNamespaces")
+	defer func() {
+		span.SetAttributes(tracing.AttrItems.Int(len(nsList)))
+		tracing.End(span, err)
+	}()
 
 	if len(config.Namespaces) > 0 {
 		nsList = config.Namespaces
+	} else if config.NamespaceSelector != nil {
+		nsList, err = nm.listSelectedNamespaces(ctx, config)
+		if err != nil {
+			return []string{}, err
+		}
+	} else if config.Cache != nil {
+		nsList, err = nm.listCachedNamespaces(ctx, config.Cache, config.ExcludeNamespaces, metaV1.ListOptions{})
+		if err != nil {
+			return []string{}, err
+		}
 	} else if cached := nm.readCachedNamespaces(config.ExcludeNamespaces); cached != nil {
 		nsList = cached
 	} else {
-		var err error
 		nsList, err = nm.refreshNamespaceCache(ctx, config.ExcludeNamespaces)
 		if err != nil {
 			return []string{}, err
@@ -138,6 +156,60 @@ func (nm *namespaceManager) refreshNamespaceCache(ctx context.Context, excludeNa
 	return nsList, nil
 }
 
+// listSelectedNamespaces lists the namespaces matching the namespace selector of config. Without
+// an informer cache, the namespaces are listed from the API server on every call, since the TTL
+// cache only holds their names.
+func (nm *namespaceManager) listSelectedNamespaces(ctx context.Context, config *Config) ([]string, error) {
+	selector, err := metaV1.LabelSelectorAsSelector(config.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+	opts := metaV1.ListOptions{LabelSelector: selector.String()}
+
+	if config.Cache != nil {
+		return nm.listCachedNamespaces(ctx, config.Cache, config.ExcludeNamespaces, opts)
+	}
+
+	nsListItems, err := nm.client.CoreV1().Namespaces().List(ctx, opts)
+	if err != nil {
+		nm.logger.Debug().Msg("error listing namespaces")
+		return nil, fmt.Errorf("error listing namespaces: %w", err)
+	}
+
+	nsList := make([]string, 0, len(nsListItems.Items))
+	for _, ns := range nsListItems.Items {
+		if slices.Contains(config.ExcludeNamespaces, ns.Name) {
+			continue
+		}
+		nsList = append(nsList, ns.Name)
+	}
+	return nsList, nil
+}
+
+// listCachedNamespaces lists the namespaces matching opts from the informer cache, which is kept
+// up to date by its watch and needs no TTL.
+func (nm *namespaceManager) listCachedNamespaces(
+	ctx context.Context,
+	informerCache *InformerCache,
+	excludeNamespaces []string,
+	opts metaV1.ListOptions,
+) ([]string, error) {
+	namespaces, err := ListCached[*coreV1.Namespace](ctx, informerCache, namespacesGVR, "", opts)
+	if err != nil {
+		nm.logger.Debug().Msg("error listing cached namespaces")
+		return nil, fmt.Errorf("error listing namespaces: %w", err)
+	}
+
+	nsList := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if slices.Contains(excludeNamespaces, ns.Name) {
+			continue
+		}
+		nsList = append(nsList, ns.Name)
+	}
+	return nsList, nil
+}
+
 // PrepareSearch prepares search parameters for Kubernetes resources
 func (nm *namespaceManager) PrepareSearch(ctx context.Context, config *Config) ([]string, metaV1.ListOptions, error) {
 	nsList, err := nm.SetNamespaceList(ctx, config)
@@ -172,8 +244,26 @@ func (nm *namespaceManager) PrepareSearch(ctx context.Context, config *Config) (
 // InitConfig initializes a K8sResource with the given configuration
 func (nm *namespaceManager) InitConfig(ctx context.Context, config *Config) (*K8sResource, error) {
 	resource := &K8sResource{
-		Period: config.Period,
-		Names:  config.Names,
+		Period:                   config.Period,
+		Names:                    config.Names,
+		DryRun:                   config.DryRun,
+		DeploymentTimeAnnotation: config.DeploymentTimeAnnotation,
+		DeploymentGracePeriod:    config.DeploymentGracePeriod,
+		Recorder:                 config.Recorder,
+		Scaler:                   config.Scaler,
+		Concurrency:              config.Concurrency,
+		Calls:                    config.Calls,
+		Cache:                    config.Cache,
+		OnDrift:                  config.OnDrift,
+		OnRelease:                config.OnRelease,
+		Filter:                   config.Filter,
+		ReadinessTimeout:         config.ReadinessTimeout,
+	}
+	if resource.DeploymentGracePeriod <= 0 {
+		resource.DeploymentGracePeriod = DefaultDeploymentGracePeriod
+	}
+	if resource.Concurrency <= 0 {
+		resource.Concurrency = DefaultConcurrency
 	}
 
 	nsList, listOptions, err := nm.PrepareSearch(ctx, config)
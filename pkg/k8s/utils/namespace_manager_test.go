@@ -120,6 +120,45 @@ var _ = Describe("NamespaceManager", func() {
 			Expect(nsList).ToNot(ContainElement("exclude-ns"))
 		})
 
+		It("should list the namespaces matching the namespace selector", func() {
+			var selector string
+			config.NamespaceSelector = &metaV1.LabelSelector{MatchLabels: map[string]string{"env": "preview"}}
+			config.ExcludeNamespaces = []string{"preview-frozen"}
+			mockClient.CoreV1Func = func() utils.CoreV1Interface {
+				return &testutil.MockCoreV1Interface{
+					NamespacesFunc: func() utils.NamespaceLister {
+						return &testutil.MockNamespaceLister{
+							ListFunc: func(ctx context.Context, opts metaV1.ListOptions) (*coreV1.NamespaceList, error) {
+								selector = opts.LabelSelector
+								return &coreV1.NamespaceList{
+									Items: []coreV1.Namespace{
+										{ObjectMeta: metaV1.ObjectMeta{Name: "preview-42"}},
+										{ObjectMeta: metaV1.ObjectMeta{Name: "preview-frozen"}},
+									},
+								}, nil
+							},
+						}
+					},
+				}
+			}
+
+			nsList, err := namespaceMgr.SetNamespaceList(ctx, config)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selector).To(Equal("env=preview"))
+			Expect(nsList).To(Equal([]string{"preview-42"}))
+		})
+
+		It("should prefer the specified namespaces to the namespace selector", func() {
+			config.Namespaces = []string{"namespace1"}
+			config.NamespaceSelector = &metaV1.LabelSelector{MatchLabels: map[string]string{"env": "preview"}}
+
+			nsList, err := namespaceMgr.SetNamespaceList(ctx, config)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nsList).To(Equal([]string{"namespace1"}))
+		})
+
 		It("should return error when client fails to list namespaces", func() {
 			config.Namespaces = []string{}
 			mockClient.CoreV1Func = func() utils.CoreV1Interface {
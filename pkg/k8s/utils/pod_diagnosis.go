@@ -0,0 +1,95 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedCoreV1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// maxDiagnosisMessageLength bounds the pod or container message included in a diagnosis.
+const maxDiagnosisMessageLength = 200
+
+// startingReasons are the waiting reasons of containers that are still starting normally.
+var startingReasons = []string{"ContainerCreating", "PodInitializing"}
+
+// DiagnosePods returns why the pods matching selector are not ready, such as an image that
+// cannot be pulled, a container crash-looping, or pods that cannot be scheduled. It returns an
+// empty string when no pod explains it.
+func DiagnosePods(ctx context.Context, pods typedCoreV1.PodInterface, selector *metaV1.LabelSelector) (string, error) {
+	if selector == nil {
+		return "", nil
+	}
+
+	list, err := pods.List(ctx, metaV1.ListOptions{LabelSelector: metaV1.FormatLabelSelector(selector)})
+	if err != nil {
+		return "", fmt.Errorf("error listing pods: %w", err)
+	}
+
+	items := list.Items
+	slices.SortFunc(items, func(a, b coreV1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i := range items {
+		if reason := diagnosePod(&items[i]); reason != "" {
+			return reason, nil
+		}
+	}
+
+	return "", nil
+}
+
+// diagnosePod returns why pod is not ready, or an empty string when it is ready or starting.
+func diagnosePod(pod *coreV1.Pod) string {
+	if pod.DeletionTimestamp != nil {
+		return ""
+	}
+
+	statuses := slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses)
+	for _, status := range statuses {
+		waiting := status.State.Waiting
+		if waiting == nil || waiting.Reason == "" || slices.Contains(startingReasons, waiting.Reason) {
+			continue
+		}
+		return fmt.Sprintf("pod %s: container %s: %s", pod.Name, status.Name, withMessage(waiting.Reason, waiting.Message))
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == coreV1.PodScheduled && condition.Status == coreV1.ConditionFalse {
+			return fmt.Sprintf("pod %s: %s", pod.Name, withMessage(condition.Reason, condition.Message))
+		}
+	}
+
+	return ""
+}
+
+// withMessage appends message, shortened if needed, to reason.
+func withMessage(reason, message string) string {
+	if message == "" {
+		return reason
+	}
+	if len(message) > maxDiagnosisMessageLength {
+		message = message[:maxDiagnosisMessageLength-3] + "..."
+	}
+	return reason + ": " + message
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/utils"
+)
+
+var _ = Describe("DiagnosePods", func() {
+	var (
+		ctx      context.Context
+		selector *metaV1.LabelSelector
+	)
+
+	newPod := func(name string, status coreV1.PodStatus) *coreV1.Pod {
+		return &coreV1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Status:     status,
+		}
+	}
+
+	waiting := func(reason, message string) coreV1.PodStatus {
+		return coreV1.PodStatus{ContainerStatuses: []coreV1.ContainerStatus{{
+			Name:  "web",
+			State: coreV1.ContainerState{Waiting: &coreV1.ContainerStateWaiting{Reason: reason, Message: message}},
+		}}}
+	}
+
+	diagnose := func(pods ...*coreV1.Pod) string {
+		client := fake.NewSimpleClientset()
+		for _, pod := range pods {
+			_, err := client.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metaV1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		reason, err := utils.DiagnosePods(ctx, client.CoreV1().Pods("default"), selector)
+		Expect(err).ToNot(HaveOccurred())
+		return reason
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		selector = &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	})
+
+	It("should report containers failing to start", func() {
+		Expect(diagnose(
+			newPod("web-b", waiting("CrashLoopBackOff", "")),
+			newPod("web-a", waiting("ImagePullBackOff", `Back-off pulling image "web:missing"`)),
+		)).To(Equal(`pod web-a: container web: ImagePullBackOff: Back-off pulling image "web:missing"`))
+	})
+
+	It("should report pods that cannot be scheduled", func() {
+		Expect(diagnose(newPod("web-a", coreV1.PodStatus{
+			Phase: coreV1.PodPending,
+			Conditions: []coreV1.PodCondition{{
+				Type:    coreV1.PodScheduled,
+				Status:  coreV1.ConditionFalse,
+				Reason:  "Unschedulable",
+				Message: "0/3 nodes are available: 3 Insufficient cpu.",
+			}},
+		}))).To(Equal("pod web-a: Unschedulable: 0/3 nodes are available: 3 Insufficient cpu."))
+	})
+
+	It("should not report pods still starting or of other workloads", func() {
+		other := newPod("api-a", waiting("ErrImagePull", ""))
+		other.Labels = map[string]string{"app": "api"}
+
+		Expect(diagnose(newPod("web-a", waiting("ContainerCreating", "")), other)).To(BeEmpty())
+	})
+
+	It("should not list pods without a selector", func() {
+		selector = nil
+
+		Expect(diagnose(newPod("web-a", waiting("ImagePullBackOff", "")))).To(BeEmpty())
+	})
+})
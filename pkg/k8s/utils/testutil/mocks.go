@@ -127,6 +127,8 @@ type MockAnnotationManager struct {
 	RestoreBoolAnnotationsFunc   func(annot map[string]string) (bool, *bool, map[string]string, error)
 	AddIntAnnotationsFunc        func(annot map[string]string, curPeriod *periodPkg.Period, value *int32) map[string]string
 	RestoreIntAnnotationsFunc    func(annot map[string]string) (bool, *int32, map[string]string, error)
+	AddStringAnnotationsFunc     func(annot map[string]string, curPeriod *periodPkg.Period, value string) map[string]string
+	RestoreStringAnnotationsFunc func(annot map[string]string) (bool, string, map[string]string)
 }
 
 // AddAnnotations returns mock annotations with period information.
@@ -200,6 +202,22 @@ func (m *MockAnnotationManager) RestoreIntAnnotations(annot map[string]string) (
 	return true, nil, annot, nil
 }
 
+// AddStringAnnotations returns mock annotations with string value information.
+func (m *MockAnnotationManager) AddStringAnnotations(annot map[string]string, curPeriod *periodPkg.Period, value string) map[string]string {
+	if m.AddStringAnnotationsFunc != nil {
+		return m.AddStringAnnotationsFunc(annot, curPeriod, value)
+	}
+	return annot
+}
+
+// RestoreStringAnnotations returns mock restored string annotations.
+func (m *MockAnnotationManager) RestoreStringAnnotations(annot map[string]string) (bool, string, map[string]string) {
+	if m.RestoreStringAnnotationsFunc != nil {
+		return m.RestoreStringAnnotationsFunc(annot)
+	}
+	return true, "", annot
+}
+
 // Helper functions for creating test data
 
 // NewMockKubernetesClientWithNamespaces creates a mock client that returns the specified namespaces
@@ -4,28 +4,80 @@
 package utils
 
 import (
+	"time"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
 	periodPkg "github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+	"golang.org/x/sync/semaphore"
+	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 )
 
+// ReleaseFunc receives the kind of a resource scaled down and the resources requested by the
+// pods it no longer runs.
+type ReleaseFunc func(kind string, requests coreV1.ResourceList)
+
 // K8sResource represents a Kubernetes resource configuration.
 type K8sResource struct {
 	NsList      []string
 	ListOptions metaV1.ListOptions
 	Period      *periodPkg.Period `json:"period,omitempty"`
 	Names       []string
+	DryRun      bool
+	// DeploymentTimeAnnotation holds the last deploy time of a resource; empty disables the check.
+	DeploymentTimeAnnotation string
+	// DeploymentGracePeriod postpones the scale-down of resources deployed more recently.
+	DeploymentGracePeriod time.Duration
+	// Recorder records events on scaled resources; nil disables them.
+	Recorder events.EventRecorder
+	// Scaler is the scaler object referenced by resource events.
+	Scaler runtime.Object
+	// Concurrency bounds the namespaces listed and the resources scaled in parallel.
+	Concurrency int
+	// Calls bounds the lists and scalings made in parallel across the resource kinds sharing
+	// it; nil leaves them to Concurrency alone.
+	Calls *semaphore.Weighted
+	// Cache serves resource lists from informers; nil lists from the API server.
+	Cache *InformerCache
+	// OnDrift is called, possibly concurrently, with the kind of each resource brought back to
+	// the period's state after drifting from it; nil disables it.
+	OnDrift func(kind string)
+	// OnRelease is called, possibly concurrently, for each resource kept scaled down; nil
+	// disables it.
+	OnRelease ReleaseFunc
+	// Filter keeps the listed resources it returns true for; nil keeps them all.
+	Filter func(obj metaV1.Object) bool
+	// ReadinessTimeout is how long the resources scaled outside of down periods are awaited until
+	// ready; zero does not wait.
+	ReadinessTimeout time.Duration
 }
 
 // Config defines the configuration for Kubernetes resource management.
 type Config struct {
-	Namespaces                   []string              `json:"namespaces,omitempty"`
-	ExcludeNamespaces            []string              `json:"excludeNamespaces,omitempty"`
-	Client                       kubernetes.Interface  `json:"client"`
-	DynamicClient                dynamic.Interface     `json:"dynamicClient,omitempty"`
-	LabelSelector                *metaV1.LabelSelector `json:"labelSelector,omitempty"`
-	Period                       *periodPkg.Period     `json:"period,omitempty"`
-	ForceExcludeSystemNamespaces bool                  `json:"forceExcludeSystemNamespaces,omitempty"`
-	Names                        []string              `json:"names,omitempty"`
+	Namespaces                   []string                 `json:"namespaces,omitempty"`
+	ExcludeNamespaces            []string                 `json:"excludeNamespaces,omitempty"`
+	NamespaceSelector            *metaV1.LabelSelector    `json:"namespaceSelector,omitempty"`
+	Client                       kubernetes.Interface     `json:"client"`
+	DynamicClient                dynamic.Interface        `json:"dynamicClient,omitempty"`
+	LabelSelector                *metaV1.LabelSelector    `json:"labelSelector,omitempty"`
+	Period                       *periodPkg.Period        `json:"period,omitempty"`
+	ForceExcludeSystemNamespaces bool                     `json:"forceExcludeSystemNamespaces,omitempty"`
+	Names                        []string                 `json:"names,omitempty"`
+	SleepingService              *common.SleepingService  `json:"sleepingService,omitempty"`
+	DryRun                       bool                     `json:"dryRun,omitempty"`
+	DeploymentTimeAnnotation     string                   `json:"deploymentTimeAnnotation,omitempty"`
+	DeploymentGracePeriod        time.Duration            `json:"deploymentGracePeriod,omitempty"`
+	Recorder                     events.EventRecorder     `json:"-"`
+	Scaler                       runtime.Object           `json:"-"`
+	Concurrency                  int                      `json:"concurrency,omitempty"`
+	Calls                        *semaphore.Weighted      `json:"-"`
+	Cache                        *InformerCache           `json:"-"`
+	OnDrift                      func(kind string)        `json:"-"`
+	OnRelease                    ReleaseFunc              `json:"-"`
+	Filter                       func(metaV1.Object) bool `json:"-"`
+	ReadinessTimeout             time.Duration            `json:"readinessTimeout,omitempty"`
 }
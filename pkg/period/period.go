@@ -180,11 +180,31 @@ func isPeriodActive(
 		return onDay, time.Time{}, time.Time{}, nil, nil
 	}
 
-	startTime, err := getTime(period.StartTime, periodType, localTime, timeLocation)
+	startTime, endTime, err := getBoundaries(period, periodType, localTime, timeLocation)
 	if err != nil {
 		return false, time.Time{}, time.Time{}, nil, err
 	}
 
+	isActive := localTime.After(startTime) && localTime.Before(endTime)
+	if ptr.Deref(period.Reverse, false) {
+		isActive = !isActive
+	}
+
+	return isActive, startTime, endTime, period.Once, nil
+}
+
+// getBoundaries returns the start and end times of period on the day of localTime.
+func getBoundaries(
+	period *common.RecurringPeriod,
+	periodType string,
+	localTime time.Time,
+	timeLocation *time.Location,
+) (time.Time, time.Time, error) {
+	startTime, err := getTime(period.StartTime, periodType, localTime, timeLocation)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
 	endTimeStr := period.EndTime
 	if endTimeStr == "00:00" {
 		// if the end time is 00:00, it means the period ends at the end of the day
@@ -193,7 +213,7 @@ func isPeriodActive(
 
 	endTime, err := getTime(endTimeStr, periodType, localTime, timeLocation)
 	if err != nil {
-		return false, time.Time{}, time.Time{}, nil, err
+		return time.Time{}, time.Time{}, err
 	}
 
 	endTime = endTime.Add(time.Second * EndTimeInclusiveSeconds) // end time is inclusive, so we add 59 seconds
@@ -204,15 +224,10 @@ func isPeriodActive(
 	// To achieve a similar effect, use reverse: true on a daytime period instead.
 	// Example: startTime="07:00" endTime="22:00" reverse=true is equivalent to 22:00→07:00.
 	if startTime.After(endTime) {
-		return false, time.Time{}, time.Time{}, nil, ErrStartAfterEnd
+		return time.Time{}, time.Time{}, ErrStartAfterEnd
 	}
 
-	isActive := localTime.After(startTime) && localTime.Before(endTime)
-	if ptr.Deref(period.Reverse, false) {
-		isActive = !isActive
-	}
-
-	return isActive, startTime, endTime, period.Once, nil
+	return startTime, endTime, nil
 }
 
 func convertFixedToRecurring(fixed *common.FixedPeriod) *common.RecurringPeriod {
@@ -0,0 +1,132 @@
+// Package period provides the computation of the next transition of periods.
+package period
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+)
+
+// transitionLookaheadDays bounds the search for the next transition of recurring periods:
+// every day of the week occurs within a week after today.
+const transitionLookaheadDays = 7
+
+// NextTransition returns the first time after now at which period starts or ends. It returns
+// false when the period never starts nor ends again, like a fixed period in the past.
+func NextTransition(period *common.ScalerPeriod, now time.Time) (time.Time, bool, error) {
+	spec, periodType, lookahead := convertFixedToRecurring(period.Time.Fixed), PeriodFixedName, 0
+	if spec == nil {
+		spec, periodType, lookahead = period.Time.Recurring, PeriodRecurringName, transitionLookaheadDays
+	}
+	if spec == nil {
+		return time.Time{}, false, nil
+	}
+
+	timeLocation := time.Local
+	if spec.Timezone != nil {
+		var err error
+		timeLocation, err = time.LoadLocation(*spec.Timezone)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("error loading timezone: %w", err)
+		}
+	}
+
+	localTime := now.In(timeLocation)
+	for offset := 0; offset <= lookahead; offset++ {
+		day := localTime.AddDate(0, 0, offset)
+
+		onDay, err := isAnyDay(spec.Days, &day)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		if !onDay {
+			continue
+		}
+
+		startTime, endTime, err := getBoundaries(spec, periodType, day, timeLocation)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		for _, boundary := range []time.Time{startTime, endTime} {
+			if boundary.After(now) {
+				return boundary, true, nil
+			}
+		}
+	}
+
+	return time.Time{}, false, nil
+}
+
+// maxScaleDownSteps bounds the transitions examined by NextScaleDown.
+const maxScaleDownSteps = 64
+
+// fixedClock is a Clock stopped at a given time.
+type fixedClock time.Time
+
+// Now returns the time the clock is stopped at.
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+// Active returns the index of the period active at the given time among periods, evaluated in
+// order like the operator does, or -1 when none is.
+func Active(periods []common.ScalerPeriod, at time.Time) (int, error) {
+	for i := range periods {
+		p, err := NewWithClock(&periods[i], fixedClock(at))
+		if err != nil {
+			return -1, err
+		}
+		if p.IsActive {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// NextScaleDown returns the first time after from, and not after until, at which a down period
+// becomes the active period among periods, along with the index of that period. down tells
+// whether resources are already scaled down at from: moving from a down period to another one
+// does not scale them down again. It returns false when no period scales resources down by then.
+func NextScaleDown(periods []common.ScalerPeriod, from, until time.Time, down bool) (time.Time, int, bool, error) {
+	current := from
+	for range maxScaleDownSteps {
+		var next time.Time
+		for i := range periods {
+			transition, ok, err := NextTransition(&periods[i], current)
+			if err != nil {
+				return time.Time{}, -1, false, err
+			}
+			if ok && (next.IsZero() || transition.Before(next)) {
+				next = transition
+			}
+		}
+		if next.IsZero() || next.After(until) {
+			break
+		}
+
+		// Periods start strictly after their start time: evaluate them once the transition passed
+		active, err := Active(periods, next.Add(time.Second))
+		if err != nil {
+			return time.Time{}, -1, false, err
+		}
+		isDown := active >= 0 && periods[active].Type == common.PeriodTypeDown
+		if isDown && !down {
+			return next, active, true, nil
+		}
+
+		current, down = next, isDown
+	}
+
+	return time.Time{}, -1, false, nil
+}
+
+// isAnyDay reports whether localTime falls on one of days.
+func isAnyDay(days []common.DayOfWeek, localTime *time.Time) (bool, error) {
+	for _, day := range days {
+		onDay, err := isDay(day, localTime)
+		if err != nil || onDay {
+			return onDay, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,155 @@
+package period_test
+
+import (
+	"time"
+
+	"github.com/kubecloudscaler/kubecloudscaler/api/common"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/period"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("NextTransition", func() {
+	// Wednesday
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	recurring := func(days []common.DayOfWeek, start, end string) *common.ScalerPeriod {
+		return &common.ScalerPeriod{
+			Type: common.PeriodTypeDown,
+			Time: common.TimePeriod{Recurring: &common.RecurringPeriod{
+				Days:      days,
+				StartTime: start,
+				EndTime:   end,
+				Timezone:  ptr.To("UTC"),
+			}},
+		}
+	}
+
+	fixed := func(start, end string) *common.ScalerPeriod {
+		return &common.ScalerPeriod{
+			Type: common.PeriodTypeUp,
+			Time: common.TimePeriod{Fixed: &common.FixedPeriod{
+				StartTime: start,
+				EndTime:   end,
+				Timezone:  ptr.To("UTC"),
+			}},
+		}
+	}
+
+	It("returns the end of a period in progress", func() {
+		next, ok, err := period.NextTransition(recurring([]common.DayOfWeek{common.DayAll}, "08:00", "18:00"), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(next).To(Equal(time.Date(2025, 1, 15, 18, 0, 59, 0, time.UTC)))
+	})
+
+	It("returns the start of a period later today", func() {
+		next, ok, err := period.NextTransition(recurring([]common.DayOfWeek{common.DayAll}, "19:00", "23:00"), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(next).To(Equal(time.Date(2025, 1, 15, 19, 0, 0, 0, time.UTC)))
+	})
+
+	It("returns the start of a period on a following day", func() {
+		next, ok, err := period.NextTransition(recurring([]common.DayOfWeek{"mon"}, "08:00", "18:00"), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(next).To(Equal(time.Date(2025, 1, 20, 8, 0, 0, 0, time.UTC)))
+	})
+
+	It("returns the start of a period next week on the same day", func() {
+		next, ok, err := period.NextTransition(recurring([]common.DayOfWeek{"wed"}, "08:00", "10:00"), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(next).To(Equal(time.Date(2025, 1, 22, 8, 0, 0, 0, time.UTC)))
+	})
+
+	It("returns the boundaries of a fixed period", func() {
+		next, ok, err := period.NextTransition(fixed("2025-01-20 08:00:00", "2025-01-21 08:00:00"), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(next).To(Equal(time.Date(2025, 1, 20, 8, 0, 0, 0, time.UTC)))
+	})
+
+	It("reports no transition for a fixed period in the past", func() {
+		_, ok, err := period.NextTransition(fixed("2025-01-01 08:00:00", "2025-01-02 08:00:00"), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("reports invalid periods", func() {
+		_, _, err := period.NextTransition(recurring([]common.DayOfWeek{"x"}, "08:00", "18:00"), now)
+		Expect(err).To(MatchError(period.ErrBadDay))
+	})
+})
+
+var _ = Describe("NextScaleDown", func() {
+	// Wednesday
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	until := now.Add(24 * time.Hour)
+
+	recurring := func(periodType common.PeriodType, start, end string, reverse bool) common.ScalerPeriod {
+		return common.ScalerPeriod{
+			Type: periodType,
+			Time: common.TimePeriod{Recurring: &common.RecurringPeriod{
+				Days:      []common.DayOfWeek{common.DayAll},
+				StartTime: start,
+				EndTime:   end,
+				Timezone:  ptr.To("UTC"),
+				Reverse:   ptr.To(reverse),
+			}},
+		}
+	}
+
+	It("returns the start of the next down period", func() {
+		periods := []common.ScalerPeriod{recurring(common.PeriodTypeDown, "19:00", "23:00", false)}
+
+		next, index, ok, err := period.NextScaleDown(periods, now, until, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(index).To(Equal(0))
+		Expect(next).To(Equal(time.Date(2025, 1, 15, 19, 0, 0, 0, time.UTC)))
+	})
+
+	It("returns the end of a reversed daytime period", func() {
+		periods := []common.ScalerPeriod{recurring(common.PeriodTypeDown, "07:00", "19:00", true)}
+
+		next, _, ok, err := period.NextScaleDown(periods, now, until, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(next).To(Equal(time.Date(2025, 1, 15, 19, 0, 59, 0, time.UTC)))
+	})
+
+	It("skips down periods hidden by an earlier period", func() {
+		periods := []common.ScalerPeriod{
+			recurring(common.PeriodTypeUp, "18:00", "20:00", false),
+			recurring(common.PeriodTypeDown, "19:00", "23:00", false),
+		}
+
+		next, index, ok, err := period.NextScaleDown(periods, now, until, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(index).To(Equal(1))
+		Expect(next).To(Equal(time.Date(2025, 1, 15, 20, 0, 59, 0, time.UTC)))
+	})
+
+	It("does not scale down again when resources are already down", func() {
+		periods := []common.ScalerPeriod{
+			recurring(common.PeriodTypeDown, "10:00", "19:00", false),
+			recurring(common.PeriodTypeDown, "19:00", "23:00", false),
+		}
+
+		_, _, ok, err := period.NextScaleDown(periods, now, now.Add(8*time.Hour), true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("ignores scale-downs after until", func() {
+		periods := []common.ScalerPeriod{recurring(common.PeriodTypeDown, "19:00", "23:00", false)}
+
+		_, _, ok, err := period.NextScaleDown(periods, now, now.Add(time.Hour), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+})
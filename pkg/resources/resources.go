@@ -12,7 +12,10 @@ import (
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/cronjobs"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/deployments"
 	ars "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/github_autoscalingrunnersets"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/httproutes"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/ingresses"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/scaledobjects"
+	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/services"
 	"github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/statefulsets"
 	// "github.com/kubecloudscaler/kubecloudscaler/pkg/k8s/resources/horizontalpodautoscalers"
 )
@@ -37,6 +40,12 @@ func NewResource(ctx context.Context, resourceName string, config Config, logger
 		return newScaledObjectsResource(ctx, config)
 	case "cnpg-clusters":
 		return newCNPGClustersResource(ctx, config)
+	case "services":
+		return newServicesResource(ctx, config)
+	case "ingresses":
+		return newIngressesResource(ctx, config)
+	case "httproutes":
+		return newHTTPRoutesResource(ctx, config)
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, resourceName)
 	}
@@ -125,3 +134,39 @@ func newCNPGClustersResource(ctx context.Context, config Config) (Resource, erro
 	}
 	return resource, nil
 }
+
+// newServicesResource creates a new services resource
+func newServicesResource(ctx context.Context, config Config) (Resource, error) {
+	if config.K8s == nil {
+		return nil, fmt.Errorf("K8s config is required for services resource")
+	}
+	resource, err := services.New(ctx, config.K8s)
+	if err != nil {
+		return nil, fmt.Errorf("error creating services resource: %w", err)
+	}
+	return resource, nil
+}
+
+// newIngressesResource creates a new ingresses resource
+func newIngressesResource(ctx context.Context, config Config) (Resource, error) {
+	if config.K8s == nil {
+		return nil, fmt.Errorf("K8s config is required for ingresses resource")
+	}
+	resource, err := ingresses.New(ctx, config.K8s)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ingresses resource: %w", err)
+	}
+	return resource, nil
+}
+
+// newHTTPRoutesResource creates a new httproutes resource
+func newHTTPRoutesResource(ctx context.Context, config Config) (Resource, error) {
+	if config.K8s == nil {
+		return nil, fmt.Errorf("K8s config is required for httproutes resource")
+	}
+	resource, err := httproutes.New(ctx, config.K8s)
+	if err != nil {
+		return nil, fmt.Errorf("error creating httproutes resource: %w", err)
+	}
+	return resource, nil
+}
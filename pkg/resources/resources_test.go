@@ -52,6 +52,9 @@ func TestNewResource_K8sResourcesWithNilK8sConfig(t *testing.T) {
 		"github-ars",
 		"scaledobjects",
 		"cnpg-clusters",
+		"services",
+		"ingresses",
+		"httproutes",
 	}
 
 	for _, resourceName := range k8sResources {
@@ -102,6 +105,9 @@ func TestGetAvailableResources(t *testing.T) {
 		"github-ars",
 		"scaledobjects",
 		"cnpg-clusters",
+		"services",
+		"ingresses",
+		"httproutes",
 	}
 
 	assert.Equal(t, expected, resources)
@@ -19,6 +19,9 @@ var availableResources = []string{
 	"github-ars",
 	"scaledobjects",
 	"cnpg-clusters",
+	"services",
+	"ingresses",
+	"httproutes",
 }
 
 // GetAvailableResources returns a copy of the available resource types for scaling.
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing provides the OpenTelemetry spans of the reconciliations and of the scaling of
+// resources, and their export over OTLP. Spans are dropped until Setup is called.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer creating the spans.
+const TracerName = "github.com/kubecloudscaler/kubecloudscaler"
+
+// ServiceName is the service name spans are exported with.
+const ServiceName = "kubecloudscaler"
+
+// Attributes set on spans.
+const (
+	// AttrController is the controller running the reconciliation (k8s_scaler, gcp_scaler, flow).
+	AttrController = attribute.Key("kubecloudscaler.controller")
+	// AttrScaler is the name of the reconciled resource.
+	AttrScaler = attribute.Key("kubecloudscaler.scaler")
+	// AttrPeriod is the name of the active period.
+	AttrPeriod = attribute.Key("kubecloudscaler.period")
+	// AttrPeriodType is the type of the active period (up, down, noaction).
+	AttrPeriodType = attribute.Key("kubecloudscaler.period.type")
+	// AttrResourceKind is the kind of the resources handled by the span.
+	AttrResourceKind = attribute.Key("kubecloudscaler.resource.kind")
+	// AttrResourceName is the name of the resource handled by the span.
+	AttrResourceName = attribute.Key("kubecloudscaler.resource.name")
+	// AttrNamespace is the namespace of the resource handled by the span.
+	AttrNamespace = attribute.Key("kubecloudscaler.namespace")
+	// AttrItems is the number of items listed, such as namespaces, zones or resources.
+	AttrItems = attribute.Key("kubecloudscaler.items")
+	// AttrSucceeded is the number of resources scaled successfully.
+	AttrSucceeded = attribute.Key("kubecloudscaler.items.succeeded")
+	// AttrFailed is the number of resources that failed to scale.
+	AttrFailed = attribute.Key("kubecloudscaler.items.failed")
+)
+
+// Start starts the span name as a child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on span, if any, then ends it.
+func End(span trace.Span, err error) {
+	SetError(span, err)
+	span.End()
+}
+
+// SetError records err on span and marks it as failed, unless err is nil.
+func SetError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// Options configures the export of spans.
+type Options struct {
+	// Endpoint is the host:port of the OTLP gRPC collector; empty disables the export
+	Endpoint string
+	// Insecure disables TLS towards the collector
+	Insecure bool
+	// SampleRatio is the ratio of reconciliations traced, from 0 to 1
+	SampleRatio float64
+}
+
+// Setup exports spans to the OTLP collector of opts and returns the function flushing and
+// stopping the export. It does nothing when no endpoint is set.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("error creating trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}